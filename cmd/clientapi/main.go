@@ -9,20 +9,35 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/caarlos0/env/v6"
 	"github.com/machinebox/progress"
+	"github.com/sp4rd4/ports/pkg/csvreader"
+	"github.com/sp4rd4/ports/pkg/decompress"
 	"github.com/sp4rd4/ports/pkg/delivery/httpserver"
+	"github.com/sp4rd4/ports/pkg/domain"
+	"github.com/sp4rd4/ports/pkg/domain/loader"
 	"github.com/sp4rd4/ports/pkg/jsonreader"
+	"github.com/sp4rd4/ports/pkg/ndjson"
 	"github.com/sp4rd4/ports/pkg/proto"
 	"github.com/sp4rd4/ports/pkg/service"
+	"github.com/sp4rd4/ports/pkg/storage/cache"
 	"github.com/sp4rd4/ports/pkg/storage/grpcclient"
+	"github.com/sp4rd4/ports/pkg/storage/memory"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
 
+const (
+	formatJSON   = "json"
+	formatNDJSON = "ndjson"
+	formatCSV    = "csv"
+)
+
 const (
 	loadNotifyInterval = 10 * time.Second
 	shutdownTimeout    = 4 * time.Second
@@ -31,15 +46,34 @@ const (
 type app struct {
 	server           *httpserver.Ports
 	loadService      *service.LoadService
+	cache            *cache.Storage
 	logger           *zap.Logger
+	file             *os.File
+	input            io.ReadCloser
 	PortsFilepath    string        `env:"PORTS_FILE,required"`
 	HTTPPort         string        `env:"HTTP_PORT,required"`
 	HTTPReadTimeout  time.Duration `env:"HTTP_READ_TIMEOUT" envDefault:"5s"`
 	HTTPWriteTimeout time.Duration `env:"HTTP_WRITE_TIMEOUT" envDefault:"10s"`
 	HTTPIdleTimeout  time.Duration `env:"HTTP_IDLE_TIMEOUT" envDefault:"120s"`
-	PortDomainHost   string        `env:"PORTS_DOMAIN_HOST,required"`
+	PortDomainHost   string        `env:"PORTS_DOMAIN_HOST"`
 	LoaderBufferSize int           `env:"JSON_BUFFER_SIZE" envDefault:"512"`
-	PoolSize         int           `env:"WORKER_POOL_SIZE" envDefault:"50"`
+	SkipInvalid      bool          `env:"JSON_SKIP_INVALID" envDefault:"false"`
+	LoadBatchSize    int           `env:"LOAD_BATCH_SIZE" envDefault:"500"`
+	// PortsFormat is format of PORTS_FILE, json, ndjson or csv (UN/LOCODE code list), it is chosen by file
+	// extension when not set.
+	PortsFormat string `env:"PORTS_FORMAT"`
+	// JSONCoordinates is order of coordinates arrays in PORTS_FILE.
+	JSONCoordinates domain.CoordinateOrder `env:"JSON_COORDINATE_ORDER" envDefault:"lonlat"`
+	// HTTPCoordinates is order of coordinates arrays in responses and request bodies.
+	HTTPCoordinates domain.CoordinateOrder `env:"HTTP_COORDINATE_ORDER" envDefault:"lonlat"`
+	// Standalone keeps ports in memory of clientapi process instead of port domain service,
+	// PORTS_DOMAIN_HOST is required otherwise.
+	Standalone bool `env:"STANDALONE" envDefault:"false"`
+	// CacheSize is number of ports cached by id in front of port domain service, 0 disables cache.
+	CacheSize          int           `env:"CACHE_SIZE" envDefault:"10000"`
+	CacheTTL           time.Duration `env:"CACHE_TTL" envDefault:"5m"`
+	CacheNegativeTTL   time.Duration `env:"CACHE_NEGATIVE_TTL" envDefault:"30s"`
+	CacheStatsInterval time.Duration `env:"CACHE_STATS_INTERVAL" envDefault:"1m"`
 }
 
 func newApp(ctx context.Context, logger *zap.Logger) (app, error) {
@@ -48,32 +82,38 @@ func newApp(ctx context.Context, logger *zap.Logger) (app, error) {
 		return app{}, err
 	}
 
-	conn, err := grpc.Dial(appVar.PortDomainHost, grpc.WithInsecure())
+	storage, importer, err := appVar.newStorage()
 	if err != nil {
-		return app{}, fmt.Errorf("portdomain connect: %w", err)
+		return app{}, err
 	}
-	storage := grpcclient.New(proto.NewPortsClient(conn))
 
 	info, err := os.Stat(appVar.PortsFilepath)
 	if err != nil {
 		return app{}, fmt.Errorf("get file info: %w", err)
 	}
 	size := info.Size()
-	file, err := os.Open(appVar.PortsFilepath)
+	appVar.file, err = os.Open(appVar.PortsFilepath)
 	if err != nil {
 		return app{}, fmt.Errorf("open file: %w", err)
 	}
 
-	loader := jsonreader.NewLoader(meterReader(file, size), appVar.LoaderBufferSize, ctx.Done())
-	loadService, err := service.NewLoadService(loader, storage, logger, appVar.PoolSize)
+	// progress is metered before decompression, so it is reported against size of the file
+	appVar.input, err = decompress.NewReader(meterReader(appVar.file, size))
+	if err != nil {
+		return app{}, fmt.Errorf("open file: %w", err)
+	}
+	ldr, err := appVar.newLoader(ctx, appVar.input)
+	if err != nil {
+		return app{}, err
+	}
+	loadService, err := service.NewLoadService(ldr, importer, appVar.LoadBatchSize)
 	if err != nil {
 		return app{}, fmt.Errorf("start service: %w", err)
 	}
-
 	appVar.loadService = &loadService
 
 	portService := service.NewPortService(storage)
-	controller := httpserver.New(portService, logger)
+	controller := httpserver.New(portService, logger, httpserver.Coordinates(appVar.HTTPCoordinates))
 
 	appVar.server = controller
 	appVar.logger = logger
@@ -81,6 +121,89 @@ func newApp(ctx context.Context, logger *zap.Logger) (app, error) {
 	return appVar, nil
 }
 
+// newLoader returns loader of PORTS_FORMAT or the one matching extension of PORTS_FILE, json by default.
+func (a *app) newLoader(ctx context.Context, r io.Reader) (loader.Ports, error) {
+	format := strings.ToLower(a.PortsFormat)
+	if format == "" {
+		switch strings.ToLower(decompress.Ext(a.PortsFilepath)) {
+		case ".csv":
+			format = formatCSV
+		case ".ndjson", ".jsonl":
+			format = formatNDJSON
+		default:
+			format = formatJSON
+		}
+	}
+
+	switch format {
+	case formatJSON:
+		opts := []jsonreader.Option{jsonreader.Coordinates(a.JSONCoordinates)}
+		if a.SkipInvalid {
+			opts = append(opts, jsonreader.SkipInvalid())
+		}
+		return jsonreader.NewLoader(r, a.LoaderBufferSize, ctx.Done(), opts...), nil
+	case formatNDJSON:
+		opts := []ndjson.Option{ndjson.Coordinates(a.JSONCoordinates)}
+		if a.SkipInvalid {
+			opts = append(opts, ndjson.SkipInvalid())
+		}
+		return ndjson.NewLoader(r, a.LoaderBufferSize, ctx.Done(), opts...), nil
+	case formatCSV:
+		var opts []csvreader.Option
+		if a.SkipInvalid {
+			opts = append(opts, csvreader.SkipInvalid())
+		}
+		return csvreader.NewLoader(r, a.LoaderBufferSize, ctx.Done(), opts...), nil
+	}
+	return nil, fmt.Errorf("unknown ports format %q", a.PortsFormat)
+}
+
+// newStorage returns port domain service client cached unless CACHE_SIZE is 0,
+// or in standalone mode in-memory repository which is filled by clientapi itself.
+func (a *app) newStorage() (domain.PortRepository, domain.PortImporter, error) {
+	if a.Standalone {
+		storage := memory.New()
+		return storage, service.NewPortService(storage), nil
+	}
+	if a.PortDomainHost == "" {
+		return nil, nil, errors.New(`env: required environment variable "PORTS_DOMAIN_HOST" is not set`)
+	}
+
+	conn, err := grpc.Dial(a.PortDomainHost, grpc.WithInsecure())
+	if err != nil {
+		return nil, nil, fmt.Errorf("portdomain connect: %w", err)
+	}
+	client := proto.NewPortsClient(conn)
+	storage := grpcclient.New(client)
+	if a.CacheSize <= 0 {
+		return storage, grpcclient.NewImporter(client), nil
+	}
+	a.cache = cache.New(storage, a.CacheSize, a.CacheTTL, cache.NegativeTTL(a.CacheNegativeTTL))
+	return a.cache, a.cache.Importer(grpcclient.NewImporter(client)), nil
+}
+
+// reportCache logs cache counters periodically until ctx is done.
+func (a *app) reportCache(ctx context.Context) {
+	if a.cache == nil || a.CacheStatsInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(a.CacheStatsInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			stats := a.cache.Stats()
+			a.logger.Info("cache stats",
+				zap.Uint64("hits", stats.Hits),
+				zap.Uint64("misses", stats.Misses),
+				zap.Int("entries", stats.Entries),
+			)
+		}
+	}
+}
+
 func (a *app) serve(ctx context.Context) {
 	srv := &http.Server{
 		ReadTimeout:  a.HTTPReadTimeout,
@@ -113,6 +236,43 @@ func (a *app) serve(ctx context.Context) {
 	a.logger.Info("stopped http clientapi")
 }
 
+func (a *app) load(ctx context.Context) {
+	ctx = domain.WithSource(ctx, "file:"+filepath.Base(a.PortsFilepath))
+	report, err := a.loadService.Load(ctx)
+	a.closeFile()
+	skippedRecords := make([]string, len(report.SkippedRecords))
+	for i := range report.SkippedRecords {
+		skippedRecords[i] = report.SkippedRecords[i].Error()
+	}
+	fields := []zap.Field{
+		zap.Bool("succeeded", err == nil && report.Succeeded()),
+		zap.Int("total", report.Total),
+		zap.Int("inserted", report.Inserted),
+		zap.Int("updated", report.Updated),
+		zap.Int("rejected", report.Rejected),
+		zap.Any("rejectedByCode", report.RejectedByCode),
+		zap.Strings("failedIds", report.FailedIDs),
+		zap.Int("skipped", report.Skipped),
+		zap.Strings("skippedRecords", skippedRecords),
+		zap.Duration("duration", report.Duration),
+	}
+	if err != nil {
+		a.logger.Error(fmt.Errorf("load ports: %w", err).Error(), fields...)
+		return
+	}
+	a.logger.Info("ports load finished", fields...)
+}
+
+// closeFile releases decompressor and ports file once they are loaded.
+func (a *app) closeFile() {
+	if err := a.input.Close(); err != nil {
+		a.logger.Error(fmt.Errorf("close decompressor: %w", err).Error())
+	}
+	if err := a.file.Close(); err != nil {
+		a.logger.Error(fmt.Errorf("close file: %w", err).Error())
+	}
+}
+
 func meterReader(r io.Reader, size int64) io.Reader {
 	meteredReader := progress.NewReader(r)
 
@@ -121,9 +281,9 @@ func meterReader(r io.Reader, size int64) io.Reader {
 		ctx := context.Background()
 		progressChan := progress.NewTicker(ctx, meteredReader, size, loadNotifyInterval)
 		for p := range progressChan {
-			fmt.Printf("%.2f%% of json processed...\n", p.Percent())
+			fmt.Printf("%.2f%% of ports file processed...\n", p.Percent())
 		}
-		fmt.Println("json is  processed.")
+		fmt.Println("ports file is processed.")
 	}()
 
 	return meteredReader
@@ -135,7 +295,7 @@ func main() {
 		panic(err)
 	}
 
-	signals := make(chan os.Signal)
+	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -150,7 +310,8 @@ func main() {
 		logger.Fatal(err.Error())
 	}
 
-	go app.loadService.Load()
+	go app.load(ctx)
+	go app.reportCache(ctx)
 
 	app.serve(ctx)
 }
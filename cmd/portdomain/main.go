@@ -10,6 +10,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/caarlos0/env/v6"
 	"github.com/sp4rd4/ports/pkg/delivery/grpcserver"
@@ -23,13 +24,16 @@ import (
 )
 
 type app struct {
-	grpcServer         *grpcserver.Ports
-	logger             *zap.Logger
-	GRPCPort           string `env:"GRPC_PORT,required"`
-	DBHost             string `env:"DATABASE_URL,required"`
-	DBMigrationsFolder string `env:"MIGRATIONS_FOLDER" envDefault:"migrations"`
-	DBMaxIdleConn      int    `env:"POSTGRES_MAX_IDLE_CONN" envDefault:"100"`
-	DBMaxConn          int    `env:"POSTGRES_MAX_CONN" envDefault:"100"`
+	grpcServer          *grpcserver.Ports
+	storage             postgres.Storage
+	logger              *zap.Logger
+	GRPCPort            string        `env:"GRPC_PORT,required"`
+	DBHost              string        `env:"DATABASE_URL,required"`
+	DBMigrationsFolder  string        `env:"MIGRATIONS_FOLDER" envDefault:"migrations"`
+	DBMaxIdleConn       int           `env:"POSTGRES_MAX_IDLE_CONN" envDefault:"100"`
+	DBMaxConn           int           `env:"POSTGRES_MAX_CONN" envDefault:"100"`
+	EventsRetention     time.Duration `env:"EVENTS_RETENTION" envDefault:"168h"`
+	EventsPruneInterval time.Duration `env:"EVENTS_PRUNE_INTERVAL" envDefault:"1h"`
 }
 
 func newApp(logger *zap.Logger) (app, error) {
@@ -48,7 +52,7 @@ func newApp(logger *zap.Logger) (app, error) {
 	}
 	db.SetMaxIdleConns(appVar.DBMaxIdleConn)
 	db.SetMaxOpenConns(appVar.DBMaxConn)
-	storage := postgres.New(db)
+	storage := postgres.New(db, postgres.Listen(appVar.DBHost))
 	err = storage.Migrate(dbMigrate, appVar.DBMigrationsFolder)
 	if err != nil {
 		return app{}, fmt.Errorf("postgres migrate: %w", err)
@@ -59,11 +63,34 @@ func newApp(logger *zap.Logger) (app, error) {
 	server := grpcserver.New(portService, logger)
 
 	appVar.grpcServer = server
+	appVar.storage = storage
 	appVar.logger = logger
 
 	return appVar, nil
 }
 
+// pruneEvents deletes watch events older than retention periodically until ctx is done.
+func (a *app) pruneEvents(ctx context.Context) {
+	if a.EventsRetention <= 0 || a.EventsPruneInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(a.EventsPruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			pruned, err := a.storage.PruneEvents(ctx, time.Now().Add(-a.EventsRetention))
+			if err != nil {
+				a.logger.Error(fmt.Errorf("prune events: %w", err).Error())
+				continue
+			}
+			a.logger.Info("pruned events", zap.Int64("events", pruned))
+		}
+	}
+}
+
 func (a *app) serve(ctx context.Context) {
 	lis, err := net.Listen("tcp", ":"+a.GRPCPort)
 	if err != nil {
@@ -77,11 +104,16 @@ func (a *app) serve(ctx context.Context) {
 	}()
 	a.logger.Info("started grpc portdomain")
 
+	go a.pruneEvents(ctx)
+
 	<-ctx.Done()
 
 	a.logger.Info("stopping grpc portdomain")
 
 	a.grpcServer.GracefulStop()
+	if err := a.storage.Close(); err != nil {
+		a.logger.Error(fmt.Errorf("close storage: %w", err).Error())
+	}
 
 	a.logger.Info("stopped grpc portdomain")
 }
@@ -92,7 +124,7 @@ func main() {
 		panic(err)
 	}
 
-	signals := make(chan os.Signal)
+	signals := make(chan os.Signal, 1)
 	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
 	ctx, cancel := context.WithCancel(context.Background())
 
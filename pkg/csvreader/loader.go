@@ -0,0 +1,316 @@
+package csvreader
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sp4rd4/ports/pkg/domain"
+	"github.com/sp4rd4/ports/pkg/domain/loader"
+)
+
+// Columns of UN/LOCODE code list, remarks column is optional.
+const (
+	columnChange = iota
+	columnCountry
+	columnLocation
+	columnName
+	columnNameWoDiacritics
+	columnSubdivision
+	columnFunction
+	columnStatus
+	columnDate
+	columnIATA
+	columnCoordinates
+	minColumns
+)
+
+const (
+	// changeReference marks entry referring to another one, e.g. "Peking = Beijing", it is not a location.
+	changeReference = "="
+	// changeRemoved marks entry which is going to be removed from code list.
+	changeRemoved = "X"
+	// functionPort is set in the first position of function classifier of ports.
+	functionPort = '1'
+)
+
+var (
+	ErrNoInput            = errors.New("no input")
+	ErrInvalidRow         = errors.New("invalid row")
+	ErrInvalidCoordinates = errors.New("invalid coordinates")
+)
+
+// smallWords stay in lower case inside country names, e.g. "Isle of Man".
+var smallWords = map[string]bool{"and": true, "of": true, "the": true}
+
+type loaderCSV struct {
+	reader      io.Reader
+	bufferSize  int
+	cancel      <-chan struct{}
+	skipInvalid bool
+	countries   map[string]string
+	err         error
+	skipped     []loader.ParseError
+}
+
+type Option func(*loaderCSV)
+
+// SkipInvalid makes loader skip rows which could not be parsed as port and collect
+// their errors instead of stopping.
+func SkipInvalid() Option {
+	return func(lc *loaderCSV) {
+		lc.skipInvalid = true
+	}
+}
+
+// NewLoader returns loader of UNECE UN/LOCODE code list in CSV format encoded in ISO-8859-1.
+// Only locations classified as ports are loaded, entries referring to other ones ("=") and
+// marked for removal ("X") are skipped, added ("+") and changed ones are loaded as others.
+func NewLoader(reader io.Reader, bufferSize int, cancel <-chan struct{}, opts ...Option) loader.Ports {
+	lc := &loaderCSV{
+		reader:     reader,
+		bufferSize: bufferSize,
+		cancel:     cancel,
+		countries:  map[string]string{},
+	}
+	for _, opt := range opts {
+		opt(lc)
+	}
+	return lc
+}
+
+func (lc *loaderCSV) Load() <-chan *domain.Port {
+	data := make(chan *domain.Port)
+
+	go lc.iterate(data)
+
+	return data
+}
+
+func (lc *loaderCSV) Err() error {
+	return lc.err
+}
+
+func (lc *loaderCSV) Skipped() []loader.ParseError {
+	return lc.skipped
+}
+
+func (lc *loaderCSV) iterate(data chan *domain.Port) {
+	defer close(data)
+
+	if lc.reader == nil {
+		lc.err = &loader.ParseError{Err: ErrNoInput}
+		return
+	}
+	// rows are read line by line to know their offsets, code list has no line breaks inside fields
+	reader := bufio.NewReaderSize(lc.reader, lc.bufferSize)
+	var offset int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			p, ok := lc.readPort(line, offset)
+			offset += int64(len(line))
+			if lc.err != nil {
+				return
+			}
+			if ok {
+				select {
+				case <-lc.cancel:
+					return
+				case data <- p:
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			lc.err = &loader.ParseError{Offset: offset, Err: err}
+			return
+		}
+	}
+}
+
+// readPort parses row of code list, ok is false if row is not a port, was skipped or loading failed.
+func (lc *loaderCSV) readPort(line []byte, offset int64) (*domain.Port, bool) {
+	row := strings.TrimRight(latin1(line), "\r\n")
+	if row == "" {
+		return nil, false
+	}
+	fields, err := splitRow(row)
+	if err == nil && len(fields) < minColumns {
+		err = fmt.Errorf("%w: %d columns", ErrInvalidRow, len(fields))
+	}
+	if err != nil {
+		lc.reject(&loader.ParseError{Offset: offset, Err: err})
+		return nil, false
+	}
+
+	country, location := strings.ToUpper(fields[columnCountry]), strings.ToUpper(fields[columnLocation])
+	if location == "" {
+		// country is listed before its locations with name prefixed by dot
+		if name := fields[columnName]; strings.HasPrefix(name, ".") && country != "" {
+			lc.countries[country] = titleCase(strings.TrimPrefix(name, "."))
+		}
+		return nil, false
+	}
+	switch fields[columnChange] {
+	case changeReference, changeRemoved:
+		return nil, false
+	}
+	if !strings.HasPrefix(fields[columnFunction], string(functionPort)) {
+		return nil, false
+	}
+
+	id := country + location
+	coordinates, err := parseCoordinates(fields[columnCoordinates])
+	if err != nil {
+		lc.reject(&loader.ParseError{Offset: offset, Key: id, Err: err})
+		return nil, false
+	}
+	p := &domain.Port{
+		ID:          id,
+		Name:        fields[columnName],
+		City:        fields[columnName],
+		Country:     lc.countries[country],
+		Alias:       domain.StringArray{},
+		Regions:     domain.StringArray{},
+		Coordinates: coordinates,
+		Province:    fields[columnSubdivision],
+		Unlocs:      domain.StringArray{id},
+	}
+	if alias := fields[columnNameWoDiacritics]; alias != "" && alias != p.Name {
+		p.Alias = append(p.Alias, alias)
+	}
+	return p, true
+}
+
+// reject stops loading with the error or collects it if invalid rows are skipped.
+func (lc *loaderCSV) reject(err *loader.ParseError) {
+	if lc.skipInvalid {
+		lc.skipped = append(lc.skipped, *err)
+		return
+	}
+	lc.err = err
+}
+
+// latin1 decodes ISO-8859-1 text, its bytes are the first 256 unicode code points.
+func latin1(b []byte) string {
+	var sb strings.Builder
+	sb.Grow(len(b))
+	for _, c := range b {
+		if c < utf8.RuneSelf {
+			sb.WriteByte(c)
+			continue
+		}
+		sb.WriteRune(rune(c))
+	}
+	return sb.String()
+}
+
+// splitRow splits line into fields, they are separated by commas and may be quoted with quotes doubled inside.
+func splitRow(line string) ([]string, error) {
+	fields := []string{}
+	for {
+		if !strings.HasPrefix(line, `"`) {
+			i := strings.IndexByte(line, ',')
+			if i < 0 {
+				return append(fields, line), nil
+			}
+			fields, line = append(fields, line[:i]), line[i+1:]
+			continue
+		}
+
+		var field strings.Builder
+		line = line[1:]
+		for {
+			i := strings.IndexByte(line, '"')
+			if i < 0 {
+				return nil, fmt.Errorf("%w: unterminated quoted field", ErrInvalidRow)
+			}
+			field.WriteString(line[:i])
+			line = line[i+1:]
+			if !strings.HasPrefix(line, `"`) {
+				break
+			}
+			field.WriteByte('"')
+			line = line[1:]
+		}
+		fields = append(fields, field.String())
+		if line == "" {
+			return fields, nil
+		}
+		if line[0] != ',' {
+			return nil, fmt.Errorf("%w: unexpected %q after quoted field", ErrInvalidRow, line[0])
+		}
+		line = line[1:]
+	}
+}
+
+// parseCoordinates parses "DDMMN DDDMMW" degrees and minutes, empty coordinates are zero location.
+func parseCoordinates(value string) (domain.Location, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return domain.Location{}, nil
+	}
+	parts := strings.Fields(value)
+	if len(parts) != 2 {
+		return domain.Location{}, fmt.Errorf("%w: %q", ErrInvalidCoordinates, value)
+	}
+	lat, err := parseDegrees(parts[0], 2, 'N', 'S')
+	if err != nil {
+		return domain.Location{}, fmt.Errorf("%w: %q", err, value)
+	}
+	lon, err := parseDegrees(parts[1], 3, 'E', 'W')
+	if err != nil {
+		return domain.Location{}, fmt.Errorf("%w: %q", err, value)
+	}
+	location := domain.Location{Latitude: lat, Longitude: lon}
+	if err := location.Validate(); err != nil {
+		return domain.Location{}, fmt.Errorf("%w: %q", err, value)
+	}
+	return location, nil
+}
+
+// parseDegrees parses degrees of given number of digits followed by 2 digits of minutes and hemisphere letter.
+func parseDegrees(value string, digits int, positive, negative byte) (float64, error) {
+	if len(value) != digits+3 {
+		return 0, ErrInvalidCoordinates
+	}
+	degrees, err := strconv.Atoi(value[:digits])
+	if err != nil || degrees < 0 {
+		return 0, ErrInvalidCoordinates
+	}
+	minutes, err := strconv.Atoi(value[digits : digits+2])
+	if err != nil || minutes < 0 || minutes >= 60 {
+		return 0, ErrInvalidCoordinates
+	}
+	result := float64(degrees) + float64(minutes)/60
+	switch value[digits+2] {
+	case positive:
+		return result, nil
+	case negative:
+		return -result, nil
+	}
+	return 0, ErrInvalidCoordinates
+}
+
+// titleCase turns upper case country names of code list to usual form, e.g. "ISLE OF MAN" to "Isle of Man".
+func titleCase(name string) string {
+	words := strings.Fields(strings.ToLower(name))
+	for i, word := range words {
+		if i > 0 && smallWords[strings.TrimFunc(word, unicode.IsPunct)] {
+			continue
+		}
+		if j := strings.IndexFunc(word, unicode.IsLetter); j >= 0 {
+			r, size := utf8.DecodeRuneInString(word[j:])
+			words[i] = word[:j] + string(unicode.ToUpper(r)) + word[j+size:]
+		}
+	}
+	return strings.Join(words, " ")
+}
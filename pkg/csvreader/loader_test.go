@@ -0,0 +1,227 @@
+package csvreader_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/sp4rd4/ports/pkg/csvreader"
+	"github.com/sp4rd4/ports/pkg/domain"
+	"github.com/sp4rd4/ports/pkg/domain/loader"
+	"github.com/stretchr/testify/assert"
+)
+
+var bufferSize = 512
+
+// codeList is excerpt of UN/LOCODE code list, "Göteborg" is encoded in ISO-8859-1.
+const codeList = `,"NL","",".NETHERLANDS",".NETHERLANDS","","","","","","",""
+,"NL","RTM","Rotterdam","Rotterdam","ZH","12345---","AI","0901","","5155N 00430E",""
+"+","NL","VLA","Vlaardingen","Vlaardingen","ZH","1-------","RQ","1901","","5154N 00420E",""
+,"NL","AMS","Amsterdam","Amsterdam","NH","--3-----","AI","0901","","",""
+"X","NL","OLD","Old Port","Old Port","","1-------","XX","1901","","",""
+"=","NL","","Rotterdam Port = Rotterdam","Rotterdam Port = Rotterdam","","","","","","",""
+,"SE","",".SWEDEN",".SWEDEN","","","","","","",""
+"|","SE","GOT","G` + "\xf6" + `teborg","Goteborg","O","1234----","AI","0901","","5742N 01157E",""
+,"BR","",".BRAZIL",".BRAZIL","","","","","","",""
+,"BR","SSZ","Santos","Santos","SP","1-------","AI","0901","","2356S 04619W","remarks, with ""quotes"""
+`
+
+var examplesLoad = []struct {
+	name   string
+	reader io.Reader
+	result []*domain.Port
+	err    bool
+}{
+	{
+		name:   "Empty reader",
+		reader: strings.NewReader(""),
+		result: nil,
+	},
+	{
+		name:   "Nil reader",
+		result: nil,
+		err:    true,
+	},
+	{
+		name:   "Incorrect csv",
+		reader: strings.NewReader(`,"NL","RTM","Rotterdam`),
+		result: nil,
+		err:    true,
+	},
+	{
+		name:   "Correct csv",
+		reader: strings.NewReader(codeList),
+		result: []*domain.Port{
+			{
+				ID:          "NLRTM",
+				Name:        "Rotterdam",
+				City:        "Rotterdam",
+				Country:     "Netherlands",
+				Alias:       domain.StringArray{},
+				Regions:     domain.StringArray{},
+				Coordinates: domain.Location{Latitude: 51 + 55.0/60, Longitude: 4 + 30.0/60},
+				Province:    "ZH",
+				Unlocs:      domain.StringArray{"NLRTM"},
+			},
+			{
+				ID:          "NLVLA",
+				Name:        "Vlaardingen",
+				City:        "Vlaardingen",
+				Country:     "Netherlands",
+				Alias:       domain.StringArray{},
+				Regions:     domain.StringArray{},
+				Coordinates: domain.Location{Latitude: 51 + 54.0/60, Longitude: 4 + 20.0/60},
+				Province:    "ZH",
+				Unlocs:      domain.StringArray{"NLVLA"},
+			},
+			{
+				ID:          "SEGOT",
+				Name:        "Göteborg",
+				City:        "Göteborg",
+				Country:     "Sweden",
+				Alias:       domain.StringArray{"Goteborg"},
+				Regions:     domain.StringArray{},
+				Coordinates: domain.Location{Latitude: 57 + 42.0/60, Longitude: 11 + 57.0/60},
+				Province:    "O",
+				Unlocs:      domain.StringArray{"SEGOT"},
+			},
+			{
+				ID:          "BRSSZ",
+				Name:        "Santos",
+				City:        "Santos",
+				Country:     "Brazil",
+				Alias:       domain.StringArray{},
+				Regions:     domain.StringArray{},
+				Coordinates: domain.Location{Latitude: -(23 + 56.0/60), Longitude: -(46 + 19.0/60)},
+				Province:    "SP",
+				Unlocs:      domain.StringArray{"BRSSZ"},
+			},
+		},
+	},
+}
+
+func TestLoad(t *testing.T) {
+	for _, ex := range examplesLoad {
+		ldr := csvreader.NewLoader(ex.reader, bufferSize, nil)
+		t.Run(ex.name, func(t *testing.T) {
+			var res []*domain.Port
+			c := ldr.Load()
+			for p := range c {
+				res = append(res, p)
+			}
+			assert.Equal(t, ex.result, res, "Chanel should return expected ports")
+			assert.Equal(t, ex.err, ldr.Err() != nil, "Should report error if csv is invalid")
+		})
+	}
+}
+
+const invalidRowCSV = `,"NL","RTM","Rotterdam","Rotterdam","ZH","1-------","AI","0901","","5155N 00430E",""
+,"NL","VLA","Vlaardingen","Vlaardingen","ZH","1-------","RQ","1901","","5194N 00420E",""
+,"NL","MSV","Maassluis","Maassluis","ZH","1-------","RQ","1901","","5155N 00415E",""
+`
+
+func TestLoadInvalidRow(t *testing.T) {
+	ldr := csvreader.NewLoader(strings.NewReader(invalidRowCSV), bufferSize, nil)
+	var res []*domain.Port
+	for p := range ldr.Load() {
+		res = append(res, p)
+	}
+	assert.Len(t, res, 1, "Should stop on invalid row")
+
+	var parseErr *loader.ParseError
+	if assert.True(t, errors.As(ldr.Err(), &parseErr), "Should return parse error") {
+		assert.Equal(t, "NLVLA", parseErr.Key, "Should report id of failed row")
+		assert.Equal(t, int64(strings.Index(invalidRowCSV, `,"NL","VLA"`)), parseErr.Offset,
+			"Should report offset of failed row")
+		assert.True(t, errors.Is(parseErr, csvreader.ErrInvalidCoordinates), "Should report invalid coordinates")
+	}
+}
+
+func TestLoadSkipInvalid(t *testing.T) {
+	ldr := csvreader.NewLoader(strings.NewReader(invalidRowCSV), 16, nil, csvreader.SkipInvalid())
+	var ids []string
+	for p := range ldr.Load() {
+		ids = append(ids, p.ID)
+	}
+	assert.Equal(t, []string{"NLRTM", "NLMSV"}, ids, "Should skip invalid row")
+	assert.Nil(t, ldr.Err(), "Should return no error")
+	if assert.Len(t, ldr.Skipped(), 1, "Should collect skipped row") {
+		assert.Equal(t, "NLVLA", ldr.Skipped()[0].Key, "Should report id of skipped row")
+	}
+}
+
+var examplesCoordinates = []struct {
+	name     string
+	value    string
+	expected domain.Location
+	err      error
+}{
+	{
+		name:     "North east",
+		value:    "2524N 05531E",
+		expected: domain.Location{Latitude: 25.4, Longitude: 55 + 31.0/60},
+	},
+	{
+		name:     "South west",
+		value:    "3342S 15812W",
+		expected: domain.Location{Latitude: -33.7, Longitude: -(158.2)},
+	},
+	{
+		name:  "Invalid hemisphere",
+		value: "2524E 05531N",
+		err:   csvreader.ErrInvalidCoordinates,
+	},
+	{
+		name:  "Invalid minutes",
+		value: "2560N 05531E",
+		err:   csvreader.ErrInvalidCoordinates,
+	},
+	{
+		name:  "Decimal degrees",
+		value: "25.4 55.5",
+		err:   csvreader.ErrInvalidCoordinates,
+	},
+	{
+		name:  "Latitude out of range",
+		value: "9500N 05531E",
+		err:   domain.ErrInvalidLatitude,
+	},
+	{
+		name:  "Longitude out of range",
+		value: "2524N 18100E",
+		err:   domain.ErrInvalidLongitude,
+	},
+}
+
+func TestLoadCoordinates(t *testing.T) {
+	for _, ex := range examplesCoordinates {
+		row := `,"AE","AJM","Ajman","Ajman","AJ","1-------","AI","0901","","` + ex.value + `",""`
+		ldr := csvreader.NewLoader(strings.NewReader(row), bufferSize, nil)
+		t.Run(ex.name, func(t *testing.T) {
+			var res []domain.Location
+			for p := range ldr.Load() {
+				res = append(res, p.Coordinates)
+			}
+			assert.True(t, errors.Is(ldr.Err(), ex.err), "Error should be same as expected")
+			if ex.err == nil && assert.Len(t, res, 1, "Should load port") {
+				assert.InDelta(t, ex.expected.Latitude, res[0].Latitude, 1e-9, "Should read latitude")
+				assert.InDelta(t, ex.expected.Longitude, res[0].Longitude, 1e-9, "Should read longitude")
+			} else {
+				assert.Empty(t, res, "Should reject port with invalid coordinates")
+			}
+		})
+	}
+}
+
+func TestLoadCountryName(t *testing.T) {
+	csv := `,"BO","",".BOLIVIA (PLURINATIONAL STATE OF)","","","","","","","",""
+,"BO","PSU","Puerto Suarez","Puerto Suarez","S","1-------","AI","0901","","",""
+`
+	ldr := csvreader.NewLoader(strings.NewReader(csv), bufferSize, nil)
+	var countries []string
+	for p := range ldr.Load() {
+		countries = append(countries, p.Country)
+	}
+	assert.Equal(t, []string{"Bolivia (Plurinational State of)"}, countries, "Should use country name of code list")
+}
@@ -0,0 +1,71 @@
+package decompress
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+)
+
+const errorTag = "decompress"
+
+// zstdMaxMemory limits memory used by zstd window, files compressed with default levels need much less.
+const zstdMaxMemory = 64 << 20
+
+var (
+	gzipMagic  = []byte{0x1f, 0x8b}
+	zstdMagic  = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	bzip2Magic = []byte("BZh")
+)
+
+// Extensions of compressed files, Ext strips them so format of the content can be told by the rest of the name.
+var extensions = []string{".gz", ".zst", ".zstd", ".bz2"}
+
+// NewReader returns reader decompressing r when it starts with gzip, zstd or bzip2 magic bytes,
+// otherwise r is read as is. Close releases decompressor, r is not closed.
+func NewReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	head, err := br.Peek(len(zstdMagic))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("[%v] detect compression: %w", errorTag, err)
+	}
+
+	switch {
+	case bytes.HasPrefix(head, gzipMagic):
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("[%v] gzip: %w", errorTag, err)
+		}
+		return zr, nil
+	case bytes.HasPrefix(head, zstdMagic):
+		zr, err := zstd.NewReader(br, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxMemory(zstdMaxMemory))
+		if err != nil {
+			return nil, fmt.Errorf("[%v] zstd: %w", errorTag, err)
+		}
+		return zr.IOReadCloser(), nil
+	// block size digit follows bzip2 magic, it makes text starting with "BZh" unlikely to be taken for it
+	case bytes.HasPrefix(head, bzip2Magic) && len(head) > len(bzip2Magic) &&
+		head[len(bzip2Magic)] >= '1' && head[len(bzip2Magic)] <= '9':
+		return ioutil.NopCloser(bzip2.NewReader(br)), nil
+	}
+	return ioutil.NopCloser(br), nil
+}
+
+// Ext returns extension of file name ignoring extension of compression, e.g. ".json" for "ports.json.gz".
+func Ext(name string) string {
+	ext := filepath.Ext(name)
+	for _, compressed := range extensions {
+		if strings.EqualFold(ext, compressed) {
+			return filepath.Ext(strings.TrimSuffix(name, ext))
+		}
+	}
+	return ext
+}
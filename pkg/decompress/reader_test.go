@@ -0,0 +1,80 @@
+package decompress_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/klauspost/compress/gzip"
+	"github.com/klauspost/compress/zstd"
+	"github.com/sp4rd4/ports/pkg/decompress"
+	"github.com/stretchr/testify/assert"
+)
+
+const content = `{"AEAJM":{"name":"Ajman"}}`
+
+// bzip2Content is content compressed by bzip2 tool, there is no bzip2 encoder in go.
+var bzip2Content = []byte{
+	0x42, 0x5a, 0x68, 0x39, 0x31, 0x41, 0x59, 0x26, 0x53, 0x59, 0x05, 0xdd,
+	0x60, 0x51, 0x00, 0x00, 0x0b, 0x1d, 0x80, 0x10, 0x00, 0x00, 0x10, 0x22,
+	0x12, 0x22, 0x13, 0x00, 0x0a, 0x20, 0x00, 0x31, 0x00, 0xd0, 0x00, 0xd3,
+	0x49, 0xa6, 0xd4, 0x3d, 0x22, 0xb7, 0x45, 0xee, 0x11, 0x43, 0x94, 0xc6,
+	0x9b, 0x17, 0x9e, 0x33, 0xf1, 0x77, 0x24, 0x53, 0x85, 0x09, 0x00, 0x5d,
+	0xd6, 0x05, 0x10,
+}
+
+func gzipContent(t *testing.T) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write([]byte(content))
+	assert.Nil(t, err, "Should compress with no error")
+	assert.Nil(t, zw.Close(), "Should compress with no error")
+	return buf.Bytes()
+}
+
+func zstdContent(t *testing.T) []byte {
+	zw, err := zstd.NewWriter(nil)
+	assert.Nil(t, err, "Should create encoder with no error")
+	defer zw.Close()
+	return zw.EncodeAll([]byte(content), nil)
+}
+
+func TestNewReader(t *testing.T) {
+	examples := []struct {
+		name     string
+		input    []byte
+		expected string
+	}{
+		{name: "Plain", input: []byte(content), expected: content},
+		{name: "Gzip", input: gzipContent(t), expected: content},
+		{name: "Zstd", input: zstdContent(t), expected: content},
+		{name: "Bzip2", input: bzip2Content, expected: content},
+		{name: "Text like bzip2 magic", input: []byte("BZh" + content), expected: "BZh" + content},
+		{name: "Shorter than magic", input: []byte("{}"), expected: "{}"},
+	}
+	for _, ex := range examples {
+		t.Run(ex.name, func(t *testing.T) {
+			r, err := decompress.NewReader(bytes.NewReader(ex.input))
+			if !assert.Nil(t, err, "Should detect compression with no error") {
+				return
+			}
+			defer r.Close()
+			data, err := ioutil.ReadAll(r)
+			assert.Nil(t, err, "Should read with no error")
+			assert.Equal(t, ex.expected, string(data), "Should decompress compressed input only")
+		})
+	}
+}
+
+func TestNewReaderCorrupt(t *testing.T) {
+	input := gzipContent(t)
+	_, err := decompress.NewReader(bytes.NewReader(input[:5]))
+	assert.NotNil(t, err, "Should fail on truncated gzip header")
+}
+
+func TestExt(t *testing.T) {
+	assert.Equal(t, ".json", decompress.Ext("ports.json.gz"), "Should skip compression extension")
+	assert.Equal(t, ".csv", decompress.Ext("code list.csv.ZST"), "Should skip compression extension in any case")
+	assert.Equal(t, ".ndjson", decompress.Ext("ports.ndjson"), "Should return extension of uncompressed file")
+	assert.Equal(t, "", decompress.Ext("ports.bz2"), "Should return no extension of compressed file without it")
+}
@@ -1,13 +1,17 @@
 package grpcserver
 
 import (
+	"context"
 	"net"
 
 	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	grpc_zap "github.com/grpc-ecosystem/go-grpc-middleware/logging/zap"
 	grpc_recovery "github.com/grpc-ecosystem/go-grpc-middleware/recovery"
+	"github.com/sp4rd4/ports/pkg/domain"
 	"github.com/sp4rd4/ports/pkg/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 )
 
 func (ps *Ports) Serve(lis net.Listener) error {
@@ -15,12 +19,53 @@ func (ps *Ports) Serve(lis net.Listener) error {
 		grpc.UnaryInterceptor(grpc_middleware.ChainUnaryServer(
 			grpc_zap.UnaryServerInterceptor(ps.logger),
 			grpc_recovery.UnaryServerInterceptor(),
+			sourceUnaryInterceptor,
+		)),
+		grpc.StreamInterceptor(grpc_middleware.ChainStreamServer(
+			grpc_zap.StreamServerInterceptor(ps.logger),
+			grpc_recovery.StreamServerInterceptor(),
+			sourceStreamInterceptor,
 		)),
 	)
 	proto.RegisterPortsServer(ps.grpcServer, ps)
 	return ps.grpcServer.Serve(lis)
 }
 
+// GracefulStop ends watch streams and waits for other requests to finish.
 func (ps *Ports) GracefulStop() {
-	ps.grpcServer.GracefulStop()
+	ps.stopOnce.Do(func() {
+		close(ps.stopping)
+	})
+	if ps.grpcServer != nil {
+		ps.grpcServer.GracefulStop()
+	}
+}
+
+// withSource tags changes made by request with address of the caller. Source forwarded by client is only
+// a hint appended to it, so callers can not record changes as made by someone else.
+func withSource(ctx context.Context) context.Context {
+	source := "grpc"
+	if p, ok := peer.FromContext(ctx); ok {
+		source += ":" + p.Addr.String()
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get(proto.SourceMetadataKey); len(v) > 0 && v[0] != "" {
+			source += "/" + v[0]
+		}
+	}
+	return domain.WithSource(ctx, source)
+}
+
+func sourceUnaryInterceptor(
+	ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (interface{}, error) {
+	return handler(withSource(ctx), req)
+}
+
+func sourceStreamInterceptor(
+	srv interface{}, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler,
+) error {
+	wrapped := grpc_middleware.WrapServerStream(stream)
+	wrapped.WrappedContext = withSource(stream.Context())
+	return handler(srv, wrapped)
 }
@@ -4,36 +4,68 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
 
 	ptypes "github.com/gogo/protobuf/types"
 	"github.com/sp4rd4/ports/pkg/domain"
 	"github.com/sp4rd4/ports/pkg/proto"
 	"github.com/sp4rd4/ports/pkg/service"
 	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
 const errorTag = "grpc"
 
 type PortService interface {
-	Save(port *domain.Port) error
-	Get(id string) (*domain.Port, error)
+	Save(ctx context.Context, port *domain.Port, expectedRevision int64) (*domain.Port, error)
+	Update(ctx context.Context, port *domain.Port, mask []string, expectedRevision int64) (*domain.Port, error)
+	Get(ctx context.Context, id string) (*domain.Port, error)
+	GetAsOf(ctx context.Context, id string, at time.Time) (*domain.Port, error)
+	GetByUnlocode(ctx context.Context, code string) (*domain.Port, error)
+	GetByAlias(ctx context.Context, name string) (*domain.Port, error)
+	Delete(ctx context.Context, id string, expectedRevision int64) error
+	List(ctx context.Context, filter domain.PortFilter, cursor string, limit int) (domain.Page, error)
+	Nearby(ctx context.Context, point domain.Location, radiusKm float64, limit int) ([]domain.NearbyPort, error)
+	SearchByName(ctx context.Context, query string, limit int) ([]domain.MatchedPort, error)
+	WithinBox(ctx context.Context, box domain.BoundingBox, fn func(*domain.Port) error) error
+	SaveBatch(ctx context.Context, ports []*domain.Port) (domain.SaveResult, error)
+	Import(ctx context.Context, ports <-chan *domain.Port, batchSize int) (domain.ImportSummary, error)
+	Watch(ctx context.Context, fromSequence int64, fn func(domain.PortEvent) error) error
+	History(ctx context.Context, id string) ([]domain.PortChange, error)
 }
 
 type Ports struct {
 	grpcServer *grpc.Server
 	service    PortService
 	logger     *zap.Logger
+	// stopping is closed on shutdown to end streams which would last forever otherwise.
+	stopping chan struct{}
+	stopOnce sync.Once
 }
 
+var errShuttingDown = errors.New("server is shutting down")
+
 func New(srvc PortService, logger *zap.Logger) *Ports {
-	return &Ports{service: srvc, logger: logger}
+	return &Ports{service: srvc, logger: logger, stopping: make(chan struct{})}
 }
 
 func (ps *Ports) Get(ctx context.Context, req *proto.PortRequest) (*proto.Port, error) {
-	port, err := ps.service.Get(req.GetId())
+	var (
+		port *domain.Port
+		err  error
+	)
+	if req.GetAsOf() != nil {
+		port, err = ps.service.GetAsOf(ctx, req.GetId(), *req.GetAsOf())
+	} else {
+		port, err = ps.service.Get(ctx, req.GetId())
+	}
 	if err != nil {
 		ps.logger.Error(fmt.Errorf("[%v] get: %w", errorTag, err).Error())
 	}
@@ -41,26 +73,324 @@ func (ps *Ports) Get(ctx context.Context, req *proto.PortRequest) (*proto.Port,
 	return proto.PortDomainToProto(port), convertErrToProto(err)
 }
 
-func (ps *Ports) Save(ctx context.Context, req *proto.Port) (*ptypes.Empty, error) {
-	err := ps.service.Save(proto.PortProtoToDomain(req))
+func (ps *Ports) Save(ctx context.Context, port *proto.Port) (*proto.Port, error) {
+	return ps.save(ctx, port, 0)
+}
+
+// SaveIfRevision saves port only if stored port has expected revision of the request.
+func (ps *Ports) SaveIfRevision(ctx context.Context, req *proto.SaveRequest) (*proto.Port, error) {
+	return ps.save(ctx, req.GetPort(), req.GetExpectedRevision())
+}
+
+func (ps *Ports) save(ctx context.Context, port *proto.Port, expectedRevision int64) (*proto.Port, error) {
+	stored, err := ps.service.Save(ctx, proto.PortProtoToDomain(port), expectedRevision)
 	if err != nil {
 		ps.logger.Error(fmt.Errorf("[%v] save: %w", errorTag, err).Error())
 	}
 
+	return proto.PortDomainToProto(stored), convertErrToProto(err)
+}
+
+func (ps *Ports) GetByUnlocode(ctx context.Context, req *proto.LookupRequest) (*proto.PortLookup, error) {
+	port, err := ps.service.GetByUnlocode(ctx, req.GetKey())
+	return ps.lookupResult("by unlocode", port, err)
+}
+
+func (ps *Ports) GetByAlias(ctx context.Context, req *proto.LookupRequest) (*proto.PortLookup, error) {
+	port, err := ps.service.GetByAlias(ctx, req.GetKey())
+	return ps.lookupResult("by alias", port, err)
+}
+
+// lookupResult returns candidates of ambiguous lookup instead of error, so client can choose one of them.
+func (ps *Ports) lookupResult(op string, port *domain.Port, err error) (*proto.PortLookup, error) {
+	var aerr *domain.AmbiguousError
+	if errors.As(err, &aerr) {
+		return &proto.PortLookup{Candidates: proto.PortsDomainToProto(aerr.Candidates)}, nil
+	}
+	if err != nil {
+		ps.logger.Error(fmt.Errorf("[%v] %s: %w", errorTag, op, err).Error())
+		return &proto.PortLookup{}, convertErrToProto(err)
+	}
+	return &proto.PortLookup{Port: proto.PortDomainToProto(port)}, nil
+}
+
+// UpdatePort writes only fields named by update mask, so fields missing in request do not wipe stored values.
+func (ps *Ports) UpdatePort(ctx context.Context, req *proto.UpdatePortRequest) (*proto.Port, error) {
+	port, err := ps.service.Update(
+		ctx, proto.PortProtoToDomain(req.GetPort()), req.GetUpdateMask().GetPaths(), req.GetExpectedRevision(),
+	)
+	if err != nil {
+		ps.logger.Error(fmt.Errorf("[%v] update: %w", errorTag, err).Error())
+	}
+
+	return proto.PortDomainToProto(port), convertErrToProto(err)
+}
+
+func (ps *Ports) List(ctx context.Context, req *proto.ListRequest) (*proto.PortPage, error) {
+	page, err := ps.service.List(ctx, proto.FilterProtoToDomain(req.GetFilter()), req.GetCursor(), int(req.GetLimit()))
+	if err != nil {
+		ps.logger.Error(fmt.Errorf("[%v] list: %w", errorTag, err).Error())
+	}
+
+	return proto.PageDomainToProto(page), convertErrToProto(err)
+}
+
+func (ps *Ports) Nearby(ctx context.Context, req *proto.NearbyRequest) (*proto.NearbyPorts, error) {
+	point := domain.Location{
+		Latitude:  req.GetPoint().GetLatitude(),
+		Longitude: req.GetPoint().GetLongitude(),
+	}
+	ports, err := ps.service.Nearby(ctx, point, req.GetRadiusKm(), int(req.GetLimit()))
+	if err != nil {
+		ps.logger.Error(fmt.Errorf("[%v] nearby: %w", errorTag, err).Error())
+	}
+
+	return proto.NearbyDomainToProto(ports), convertErrToProto(err)
+}
+
+func (ps *Ports) Search(ctx context.Context, req *proto.SearchRequest) (*proto.MatchedPorts, error) {
+	ports, err := ps.service.SearchByName(ctx, req.GetQuery(), int(req.GetLimit()))
+	if err != nil {
+		ps.logger.Error(fmt.Errorf("[%v] search: %w", errorTag, err).Error())
+	}
+
+	return proto.MatchedDomainToProto(ports), convertErrToProto(err)
+}
+
+func (ps *Ports) WithinBox(req *proto.BoundingBox, stream proto.Ports_WithinBoxServer) error {
+	err := ps.service.WithinBox(stream.Context(), proto.BoxProtoToDomain(req), func(port *domain.Port) error {
+		return stream.Send(proto.PortDomainToProto(port))
+	})
+	if err != nil {
+		ps.logger.Error(fmt.Errorf("[%v] within box: %w", errorTag, err).Error())
+	}
+	return convertErrToProto(err)
+}
+
+// Watch streams port changes until client goes away, which is the usual way for it to end.
+// On shutdown stream ends with Unavailable so client can resume watching from the last sequence.
+func (ps *Ports) Watch(req *proto.WatchRequest, stream proto.Ports_WatchServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+	go func() {
+		select {
+		case <-ps.stopping:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	err := ps.service.Watch(ctx, req.GetFromSequence(), func(e domain.PortEvent) error {
+		return stream.Send(proto.EventDomainToProto(e))
+	})
+	select {
+	case <-ps.stopping:
+		return status.Error(codes.Unavailable, errShuttingDown.Error())
+	default:
+	}
+	if err != nil && !errors.Is(err, context.Canceled) {
+		ps.logger.Error(fmt.Errorf("[%v] watch: %w", errorTag, err).Error())
+	}
+	return convertErrToProto(err)
+}
+
+func (ps *Ports) History(ctx context.Context, req *proto.PortRequest) (*proto.PortHistory, error) {
+	changes, err := ps.service.History(ctx, req.GetId())
+	if err != nil {
+		ps.logger.Error(fmt.Errorf("[%v] history: %w", errorTag, err).Error())
+	}
+
+	return proto.HistoryDomainToProto(changes), convertErrToProto(err)
+}
+
+func (ps *Ports) Delete(ctx context.Context, req *proto.PortRequest) (*ptypes.Empty, error) {
+	err := ps.service.Delete(ctx, req.GetId(), req.GetExpectedRevision())
+	if err != nil {
+		ps.logger.Error(fmt.Errorf("[%v] delete: %w", errorTag, err).Error())
+	}
+
 	return &ptypes.Empty{}, convertErrToProto(err)
 }
 
+func (ps *Ports) SaveBatch(ctx context.Context, req *proto.PortBatch) (*proto.BatchResult, error) {
+	res, err := ps.service.SaveBatch(ctx, proto.PortsProtoToDomain(req.GetPorts()))
+	if err != nil {
+		ps.logger.Error(fmt.Errorf("[%v] save batch: %w", errorTag, err).Error())
+	}
+	result := &proto.BatchResult{Inserted: int64(res.Inserted), Updated: int64(res.Updated)}
+
+	var batchErr *domain.BatchError
+	if errors.As(err, &batchErr) {
+		result.Failures = batchFailuresToProto(batchErr.Failures)
+		return result, nil
+	}
+
+	return result, convertErrToProto(err)
+}
+
+// ImportPorts passes streamed ports to import of port service and responds with its summary.
+// Ports are saved in batches of size sent in metadata by client, service default is used when it is missing.
+func (ps *Ports) ImportPorts(stream proto.Ports_ImportPortsServer) error {
+	batchSize, err := importBatchSize(stream.Context())
+	if err != nil {
+		return ps.importError(err)
+	}
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	var (
+		summary   domain.ImportSummary
+		importErr error
+	)
+	ports := make(chan *domain.Port)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		summary, importErr = ps.service.Import(ctx, ports, batchSize)
+	}()
+
+	for {
+		port, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			cancel()
+			close(ports)
+			<-done
+			ps.logger.Error(fmt.Errorf("[%v] import receive: %w", errorTag, err).Error())
+			return err
+		}
+
+		select {
+		case ports <- proto.PortProtoToDomain(port):
+		case <-done:
+			// import is aborted, rest of the stream is not read
+			close(ports)
+			return ps.importError(importErr)
+		}
+	}
+	close(ports)
+	<-done
+	if importErr != nil {
+		return ps.importError(importErr)
+	}
+	return stream.SendAndClose(importSummaryToProto(summary))
+}
+
+func importBatchSize(ctx context.Context) (int, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	v := md.Get(proto.BatchSizeMetadataKey)
+	if len(v) == 0 {
+		return service.DefaultImportBatchSize, nil
+	}
+	batchSize, err := strconv.Atoi(v[0])
+	if err != nil || batchSize <= 0 {
+		return 0, fmt.Errorf("[%v] batch size %q: %w", errorTag, v[0], service.ErrInvalidBatchSize)
+	}
+	return batchSize, nil
+}
+
+func (ps *Ports) importError(err error) error {
+	ps.logger.Error(fmt.Errorf("[%v] import: %w", errorTag, err).Error())
+	return convertErrToProto(err)
+}
+
+func importSummaryToProto(summary domain.ImportSummary) *proto.ImportSummary {
+	res := &proto.ImportSummary{
+		Inserted: int64(summary.Inserted),
+		Updated:  int64(summary.Updated),
+		Rejected: int64(summary.Rejected),
+		Total:    int64(summary.Total),
+	}
+	if len(summary.Failures) > 0 {
+		res.Failures = batchFailuresToProto(summary.Failures)
+	}
+	if len(summary.RejectedByCode) > 0 {
+		res.RejectedByCode = make(map[string]int64, len(summary.RejectedByCode))
+		for code, n := range summary.RejectedByCode {
+			res.RejectedByCode[code] = int64(n)
+		}
+	}
+	return res
+}
+
+func batchFailuresToProto(failures []domain.BatchFailure) []*proto.BatchFailure {
+	res := make([]*proto.BatchFailure, len(failures))
+	for i, f := range failures {
+		st := status.Convert(convertErrToProto(f.Err))
+		res[i] = &proto.BatchFailure{
+			Index:   int32(f.Index),
+			Id:      f.ID,
+			Code:    uint32(st.Code()),
+			Message: st.Message(),
+		}
+	}
+	return res
+}
+
 func convertErrToProto(err error) error {
+	var verr *domain.ValidationError
 	switch {
 	case err == nil:
 		return nil
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, context.Canceled.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, context.DeadlineExceeded.Error())
 	case errors.Is(err, domain.ErrNotFound):
 		return status.Error(codes.NotFound, domain.ErrNotFound.Error())
+	case errors.Is(err, domain.ErrRevisionMismatch):
+		return status.Error(codes.FailedPrecondition, domain.ErrRevisionMismatch.Error())
+	case errors.Is(err, domain.ErrEventsPruned):
+		return status.Error(codes.OutOfRange, domain.ErrEventsPruned.Error())
 	case errors.Is(err, service.ErrPortMissingID):
 		return status.Error(codes.InvalidArgument, service.ErrPortMissingID.Error())
 	case errors.Is(err, service.ErrInvalidInput):
 		return status.Error(codes.InvalidArgument, service.ErrInvalidInput.Error())
+	case errors.Is(err, service.ErrInvalidBatchSize):
+		return status.Error(codes.InvalidArgument, service.ErrInvalidBatchSize.Error())
+	case errors.Is(err, service.ErrInvalidLimit):
+		return status.Error(codes.InvalidArgument, service.ErrInvalidLimit.Error())
+	case errors.Is(err, service.ErrInvalidFilter):
+		return status.Error(codes.InvalidArgument, service.ErrInvalidFilter.Error())
+	case errors.Is(err, service.ErrInvalidPoint):
+		return status.Error(codes.InvalidArgument, service.ErrInvalidPoint.Error())
+	case errors.Is(err, service.ErrInvalidRadius):
+		return status.Error(codes.InvalidArgument, service.ErrInvalidRadius.Error())
+	case errors.Is(err, service.ErrInvalidBox):
+		return status.Error(codes.InvalidArgument, service.ErrInvalidBox.Error())
+	case errors.Is(err, service.ErrInvalidRevision):
+		return status.Error(codes.InvalidArgument, service.ErrInvalidRevision.Error())
+	case errors.Is(err, service.ErrInvalidSequence):
+		return status.Error(codes.InvalidArgument, service.ErrInvalidSequence.Error())
+	case errors.Is(err, service.ErrInvalidMask):
+		return status.Error(codes.InvalidArgument, service.ErrInvalidMask.Error())
+	case errors.Is(err, service.ErrInvalidQuery):
+		return status.Error(codes.InvalidArgument, service.ErrInvalidQuery.Error())
+	case errors.As(err, &verr):
+		return validationErrToProto(verr)
 	default:
 		return status.Error(codes.Internal, err.Error())
 	}
 }
+
+// validationErrToProto attaches field violations as google.rpc.BadRequest details.
+func validationErrToProto(verr *domain.ValidationError) error {
+	st := status.New(codes.InvalidArgument, verr.Error())
+	badRequest := &errdetails.BadRequest{
+		FieldViolations: make([]*errdetails.BadRequest_FieldViolation, len(verr.Violations)),
+	}
+	for i, v := range verr.Violations {
+		badRequest.FieldViolations[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		}
+	}
+	detailed, err := st.WithDetails(badRequest)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
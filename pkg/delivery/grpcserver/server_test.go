@@ -4,8 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/gogo/protobuf/types"
 	"github.com/sp4rd4/ports/pkg/delivery/grpcserver"
 	"github.com/sp4rd4/ports/pkg/domain"
 	"github.com/sp4rd4/ports/pkg/proto"
@@ -14,23 +20,227 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
 type mockService struct {
-	err  error
-	port *domain.Port
+	err      error
+	port     *domain.Port
+	batch    []*domain.Port
+	summary  domain.ImportSummary
+	chunk    int
+	deleted  string
+	revision int64
+	page     domain.Page
+	filter   domain.PortFilter
+	point    domain.Location
+	nearby   []domain.NearbyPort
+	box      domain.BoundingBox
+	events   []domain.PortEvent
+	from     int64
+	history  []domain.PortChange
+	asOf     time.Time
+	mask     []string
+	key      string
+	matched  []domain.MatchedPort
+	query    string
+	limit    int
 }
 
-func (ms *mockService) Get(id string) (*domain.Port, error) {
+func (ms *mockService) GetAsOf(_ context.Context, id string, at time.Time) (*domain.Port, error) {
+	ms.asOf = at
 	return ms.port, ms.err
 }
-func (ms *mockService) Save(p *domain.Port) error {
-	ms.port = p
+
+func (ms *mockService) History(_ context.Context, id string) ([]domain.PortChange, error) {
+	return ms.history, ms.err
+}
+
+// Watch sends events and waits for client to go away like storage does.
+func (ms *mockService) Watch(ctx context.Context, fromSequence int64, fn func(domain.PortEvent) error) error {
+	ms.from = fromSequence
+	if ms.err != nil {
+		return ms.err
+	}
+	for _, e := range ms.events {
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (ms *mockService) List(
+	_ context.Context, filter domain.PortFilter, cursor string, limit int,
+) (domain.Page, error) {
+	ms.filter = filter
+	return ms.page, ms.err
+}
+
+func (ms *mockService) Nearby(
+	_ context.Context, point domain.Location, radiusKm float64, limit int,
+) ([]domain.NearbyPort, error) {
+	ms.point = point
+	return ms.nearby, ms.err
+}
+
+func (ms *mockService) SearchByName(_ context.Context, query string, limit int) ([]domain.MatchedPort, error) {
+	ms.query, ms.limit = query, limit
+	return ms.matched, ms.err
+}
+
+func (ms *mockService) WithinBox(_ context.Context, box domain.BoundingBox, fn func(*domain.Port) error) error {
+	ms.box = box
+	if ms.err != nil {
+		return ms.err
+	}
+	for _, p := range ms.batch {
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ms *mockService) Get(_ context.Context, id string) (*domain.Port, error) {
+	return ms.port, ms.err
+}
+func (ms *mockService) Delete(_ context.Context, id string, expectedRevision int64) error {
+	ms.deleted = id
+	ms.revision = expectedRevision
 	return ms.err
 }
 
+func (ms *mockService) Save(_ context.Context, p *domain.Port, expectedRevision int64) (*domain.Port, error) {
+	ms.port = p
+	ms.revision = expectedRevision
+	if ms.err != nil {
+		return nil, ms.err
+	}
+	return &domain.Port{ID: p.ID, Revision: expectedRevision + 1}, nil
+}
+
+func (ms *mockService) GetByUnlocode(_ context.Context, code string) (*domain.Port, error) {
+	ms.key = code
+	return ms.port, ms.err
+}
+
+func (ms *mockService) GetByAlias(_ context.Context, name string) (*domain.Port, error) {
+	ms.key = name
+	return ms.port, ms.err
+}
+
+func (ms *mockService) Update(
+	_ context.Context, p *domain.Port, mask []string, expectedRevision int64,
+) (*domain.Port, error) {
+	ms.mask = mask
+	ms.revision = expectedRevision
+	if ms.err != nil {
+		return nil, ms.err
+	}
+	ms.port = p
+	return p, nil
+}
+
+func (ms *mockService) SaveBatch(_ context.Context, ps []*domain.Port) (domain.SaveResult, error) {
+	ms.batch = append(ms.batch, ps...)
+	return domain.SaveResult{Inserted: len(ps)}, ms.err
+}
+
+// Import reads all ports unless it fails with error other than *domain.BatchError, like the service does.
+func (ms *mockService) Import(
+	_ context.Context, ports <-chan *domain.Port, batchSize int,
+) (domain.ImportSummary, error) {
+	ms.chunk = batchSize
+	var batchErr *domain.BatchError
+	if ms.err != nil && !errors.As(ms.err, &batchErr) {
+		return domain.ImportSummary{}, ms.err
+	}
+	for p := range ports {
+		ms.batch = append(ms.batch, p)
+	}
+	return ms.summary, nil
+}
+
+type mockImportStream struct {
+	grpc.ServerStream
+	md      metadata.MD
+	ports   []*proto.Port
+	summary *proto.ImportSummary
+}
+
+func (ms *mockImportStream) Context() context.Context {
+	return metadata.NewIncomingContext(context.TODO(), ms.md)
+}
+
+func (ms *mockImportStream) Recv() (*proto.Port, error) {
+	if len(ms.ports) == 0 {
+		return nil, io.EOF
+	}
+	p := ms.ports[0]
+	ms.ports = ms.ports[1:]
+	return p, nil
+}
+
+func (ms *mockImportStream) SendAndClose(summary *proto.ImportSummary) error {
+	ms.summary = summary
+	return nil
+}
+
+type mockBoxStream struct {
+	grpc.ServerStream
+	errSend error
+	ports   []*proto.Port
+}
+
+func (ms *mockBoxStream) Context() context.Context {
+	return context.TODO()
+}
+
+func (ms *mockBoxStream) Send(p *proto.Port) error {
+	if ms.errSend != nil {
+		return ms.errSend
+	}
+	ms.ports = append(ms.ports, p)
+	return nil
+}
+
+// mockWatchStream cancels its context once expected number of events is sent.
+type mockWatchStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	cancel func()
+	want   int
+	events []*proto.PortEvent
+	sent   chan struct{}
+}
+
+func newMockWatchStream(want int) *mockWatchStream {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &mockWatchStream{ctx: ctx, cancel: cancel, want: want, sent: make(chan struct{}, 1)}
+}
+
+func (ms *mockWatchStream) Context() context.Context {
+	return ms.ctx
+}
+
+func (ms *mockWatchStream) Send(e *proto.PortEvent) error {
+	ms.events = append(ms.events, e)
+	if len(ms.events) == ms.want {
+		ms.cancel()
+	}
+	select {
+	case ms.sent <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
 var (
 	errFoo = errors.New("test")
 )
@@ -105,6 +315,22 @@ var examplesGet = []struct {
 		id:         "",
 		port:       nil,
 	},
+	{
+		name:       "Canceled",
+		errService: context.Canceled,
+		errLogged:  context.Canceled,
+		status:     codes.Canceled,
+		id:         "AEAJM",
+		port:       nil,
+	},
+	{
+		name:       "Deadline exceeded",
+		errService: context.DeadlineExceeded,
+		errLogged:  context.DeadlineExceeded,
+		status:     codes.DeadlineExceeded,
+		id:         "AEAJM",
+		port:       nil,
+	},
 }
 
 func (s *GRPCTestSuite) TestGet() {
@@ -131,6 +357,16 @@ func (s *GRPCTestSuite) TestGet() {
 	}
 }
 
+func (s *GRPCTestSuite) TestGetAsOf() {
+	asOf := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.mock.port = &domain.Port{ID: "AEAJM"}
+	s.mock.err = nil
+	port, err := s.server.Get(context.TODO(), &proto.PortRequest{Id: "AEAJM", AsOf: &asOf})
+	s.Nil(err, "Should get port with no error")
+	s.Equal("AEAJM", port.GetId(), "Should return expected port")
+	s.Equal(asOf, s.mock.asOf, "Should get port as of requested time")
+}
+
 var examplesSave = []struct {
 	name       string
 	id         string
@@ -138,6 +374,7 @@ var examplesSave = []struct {
 	errService error
 	errLogged  error
 	port       *domain.Port
+	revision   int64
 }{
 	{
 		name:       "No error",
@@ -169,6 +406,15 @@ var examplesSave = []struct {
 		status:     codes.Internal,
 		port:       &domain.Port{},
 	},
+	{
+		name:       "Revision mismatch",
+		errService: domain.ErrRevisionMismatch,
+		errLogged:  domain.ErrRevisionMismatch,
+		id:         "AEAJM",
+		status:     codes.FailedPrecondition,
+		port:       &domain.Port{ID: "AEAJM", Alias: domain.StringArray{}, Regions: domain.StringArray{}},
+		revision:   2,
+	},
 	{
 		name:       "Missing ID",
 		errService: service.ErrPortMissingID,
@@ -193,8 +439,22 @@ func (s *GRPCTestSuite) TestSave() {
 		s.mock.err = ex.errService
 		s.observed.TakeAll()
 		s.Run(ex.name, func() {
-			_, err := s.server.Save(context.TODO(), proto.PortDomainToProto(ex.port))
+			var (
+				stored *proto.Port
+				err    error
+			)
+			if ex.revision == 0 {
+				stored, err = s.server.Save(context.TODO(), proto.PortDomainToProto(ex.port))
+			} else {
+				stored, err = s.server.SaveIfRevision(context.TODO(), &proto.SaveRequest{
+					Port: proto.PortDomainToProto(ex.port), ExpectedRevision: ex.revision,
+				})
+			}
 			s.Equal(ex.port, s.mock.port, "Should save expected port")
+			s.Equal(ex.revision, s.mock.revision, "Should save port with expected revision")
+			if err == nil {
+				s.Equal(ex.revision+1, stored.Revision, "Should respond with stored port")
+			}
 
 			if err != nil {
 				st := status.Convert(err)
@@ -209,6 +469,629 @@ func (s *GRPCTestSuite) TestSave() {
 	}
 }
 
+func (s *GRPCTestSuite) TestSaveValidation() {
+	violations := []domain.FieldViolation{
+		{Field: "id", Description: "must be UN/LOCODE"},
+		{Field: "name", Description: "must not be empty"},
+	}
+	s.mock.err = &domain.ValidationError{Violations: violations}
+	_, err := s.server.Save(context.TODO(), &proto.Port{Id: "aeajm"})
+
+	st := status.Convert(err)
+	s.Equal(codes.InvalidArgument, st.Code(), "Should return invalid argument code")
+	if s.Len(st.Details(), 1, "Should attach error details") {
+		badRequest, ok := st.Details()[0].(*errdetails.BadRequest)
+		if s.True(ok, "Should attach bad request details") {
+			s.Equal(&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: "id", Description: "must be UN/LOCODE"},
+				{Field: "name", Description: "must not be empty"},
+			}}, badRequest, "Should list field violations")
+		}
+	}
+}
+
+var examplesLookup = []struct {
+	name       string
+	status     codes.Code
+	errService error
+	result     *proto.PortLookup
+}{
+	{
+		name:   "Found",
+		result: &proto.PortLookup{Port: &proto.Port{Id: "AEAJM", Name: "Ajman"}},
+	},
+	{
+		name: "Ambiguous",
+		errService: &domain.AmbiguousError{Candidates: []*domain.Port{
+			{ID: "AEAJM", Name: "Ajman"}, {ID: "AESHJ", Name: "Sharjah"},
+		}},
+		result: &proto.PortLookup{Candidates: []*proto.Port{
+			{Id: "AEAJM", Name: "Ajman"}, {Id: "AESHJ", Name: "Sharjah"},
+		}},
+	},
+	{
+		name:       "Not found",
+		errService: domain.ErrNotFound,
+		status:     codes.NotFound,
+	},
+}
+
+func (s *GRPCTestSuite) TestLookup() {
+	lookups := map[string]func(context.Context, *proto.LookupRequest) (*proto.PortLookup, error){
+		"by unlocode": s.server.GetByUnlocode,
+		"by alias":    s.server.GetByAlias,
+	}
+	for op, lookup := range lookups {
+		for _, ex := range examplesLookup {
+			s.mock.port = &domain.Port{ID: "AEAJM", Name: "Ajman"}
+			s.mock.err = ex.errService
+			s.mock.key = ""
+			s.observed.TakeAll()
+			s.Run(op+" "+ex.name, func() {
+				res, err := lookup(context.TODO(), &proto.LookupRequest{Key: "Ajman Port"})
+				s.Equal("Ajman Port", s.mock.key, "Should look port up by key")
+				s.Equal(ex.status, status.Code(err), "Should return expected error code")
+				if err != nil {
+					s.Equal(
+						1, s.observed.FilterMessage(fmt.Errorf("[grpc] %s: %w", op, ex.errService).Error()).Len(),
+						"Should contain appropriate log message",
+					)
+					return
+				}
+				s.Equal(ex.result.GetPort().GetId(), res.GetPort().GetId(), "Should return found port")
+				s.Equal(len(ex.result.GetCandidates()), len(res.GetCandidates()), "Should return candidates")
+				for i, c := range ex.result.GetCandidates() {
+					s.Equal(c.GetId(), res.GetCandidates()[i].GetId(), "Should return candidates in order")
+				}
+			})
+		}
+	}
+}
+
+var examplesUpdatePort = []struct {
+	name       string
+	status     codes.Code
+	errService error
+	mask       *types.FieldMask
+	revision   int64
+}{
+	{
+		name: "No error",
+		mask: &types.FieldMask{Paths: []string{"alias", "timezone"}},
+	},
+	{
+		name:     "Expected revision",
+		mask:     &types.FieldMask{Paths: []string{"timezone"}},
+		revision: 2,
+	},
+	{
+		name:       "Revision mismatch",
+		mask:       &types.FieldMask{Paths: []string{"timezone"}},
+		revision:   2,
+		errService: domain.ErrRevisionMismatch,
+		status:     codes.FailedPrecondition,
+	},
+	{
+		name:       "No mask",
+		errService: service.ErrInvalidMask,
+		status:     codes.InvalidArgument,
+	},
+	{
+		name:       "Not found",
+		mask:       &types.FieldMask{Paths: []string{"name"}},
+		errService: domain.ErrNotFound,
+		status:     codes.NotFound,
+	},
+}
+
+func (s *GRPCTestSuite) TestUpdatePort() {
+	req := &proto.Port{Id: "AEAJM", Alias: []string{"AJM"}, Timezone: "Asia/Muscat"}
+	for _, ex := range examplesUpdatePort {
+		s.mock.err = ex.errService
+		s.mock.mask = nil
+		s.observed.TakeAll()
+		s.Run(ex.name, func() {
+			port, err := s.server.UpdatePort(context.TODO(), &proto.UpdatePortRequest{
+				Port: req, UpdateMask: ex.mask, ExpectedRevision: ex.revision,
+			})
+			s.Equal(ex.mask.GetPaths(), s.mock.mask, "Should update fields of mask")
+			s.Equal(ex.revision, s.mock.revision, "Should update port with expected revision")
+			s.Equal(ex.status, status.Code(err), "Should return expected error code")
+			if err != nil {
+				s.Equal(
+					1, s.observed.FilterMessage(fmt.Errorf("[grpc] update: %w", ex.errService).Error()).Len(),
+					"Should contain appropriate log message",
+				)
+				return
+			}
+			s.Equal(req.GetId(), port.GetId(), "Should return updated port")
+			s.Equal(req.GetAlias(), port.GetAlias(), "Should return updated port")
+		})
+	}
+}
+
+var examplesList = []struct {
+	name       string
+	status     codes.Code
+	errService error
+	page       domain.Page
+}{
+	{
+		name: "No error",
+		page: domain.Page{Ports: []*domain.Port{{ID: "AEAJM"}, {ID: "ZAPLZ"}}, NextCursor: "ZAPLZ"},
+	},
+	{
+		name:       "Invalid limit",
+		errService: service.ErrInvalidLimit,
+		status:     codes.InvalidArgument,
+		page:       domain.Page{Ports: []*domain.Port{}},
+	},
+	{
+		name:       "Invalid filter",
+		errService: service.ErrInvalidFilter,
+		status:     codes.InvalidArgument,
+		page:       domain.Page{Ports: []*domain.Port{}},
+	},
+}
+
+func (s *GRPCTestSuite) TestList() {
+	for _, ex := range examplesList {
+		s.mock.page = ex.page
+		s.mock.err = ex.errService
+		s.Run(ex.name, func() {
+			filter := domain.PortFilter{Country: "Belgium", Region: "Europe"}
+			page, err := s.server.List(context.TODO(), &proto.ListRequest{
+				Cursor: "AEAJL",
+				Limit:  2,
+				Filter: proto.FilterDomainToProto(filter),
+			})
+			s.Equal(filter, s.mock.filter, "Should pass filter to service")
+			s.Equal(proto.PageDomainToProto(ex.page), page, "Should return expected page")
+			s.Equal(ex.status, status.Code(err), "Should return expected error code")
+		})
+	}
+}
+
+var examplesNearby = []struct {
+	name       string
+	status     codes.Code
+	errService error
+	nearby     []domain.NearbyPort
+}{
+	{
+		name:   "No error",
+		nearby: []domain.NearbyPort{{Port: domain.Port{ID: "BEANR"}, DistanceKm: 2.5}},
+	},
+	{
+		name:       "Invalid point",
+		errService: service.ErrInvalidPoint,
+		status:     codes.InvalidArgument,
+	},
+	{
+		name:       "Invalid radius",
+		errService: service.ErrInvalidRadius,
+		status:     codes.InvalidArgument,
+	},
+}
+
+func (s *GRPCTestSuite) TestNearby() {
+	for _, ex := range examplesNearby {
+		s.mock.nearby = ex.nearby
+		s.mock.err = ex.errService
+		s.Run(ex.name, func() {
+			ports, err := s.server.Nearby(context.TODO(), &proto.NearbyRequest{
+				Point:    &proto.Location{Latitude: 51.2, Longitude: 4.4},
+				RadiusKm: 25,
+			})
+			s.Equal(domain.Location{Latitude: 51.2, Longitude: 4.4}, s.mock.point, "Should pass point to service")
+			s.Equal(proto.NearbyDomainToProto(ex.nearby), ports, "Should return expected ports")
+			s.Equal(ex.status, status.Code(err), "Should return expected error code")
+		})
+	}
+}
+
+var examplesSearch = []struct {
+	name       string
+	status     codes.Code
+	errService error
+	matched    []domain.MatchedPort
+}{
+	{
+		name:    "No error",
+		matched: []domain.MatchedPort{{Port: domain.Port{ID: "NLRTM", Name: "Rotterdam"}, Score: 1.35}},
+	},
+	{
+		name:       "Invalid query",
+		errService: service.ErrInvalidQuery,
+		status:     codes.InvalidArgument,
+	},
+}
+
+func (s *GRPCTestSuite) TestSearch() {
+	for _, ex := range examplesSearch {
+		s.mock.matched = ex.matched
+		s.mock.err = ex.errService
+		s.Run(ex.name, func() {
+			ports, err := s.server.Search(context.TODO(), &proto.SearchRequest{Query: "rotter", Limit: 5})
+			s.Equal("rotter", s.mock.query, "Should pass query to service")
+			s.Equal(5, s.mock.limit, "Should pass limit to service")
+			s.Equal(proto.MatchedDomainToProto(ex.matched), ports, "Should return expected ports")
+			s.Equal(ex.status, status.Code(err), "Should return expected error code")
+		})
+	}
+}
+
+var examplesWithinBox = []struct {
+	name       string
+	status     codes.Code
+	errService error
+	errSend    error
+	sent       int
+}{
+	{
+		name: "No error",
+		sent: 2,
+	},
+	{
+		name:       "Invalid box",
+		errService: service.ErrInvalidBox,
+		status:     codes.InvalidArgument,
+	},
+	{
+		name:    "Send canceled",
+		errSend: context.Canceled,
+		status:  codes.Canceled,
+	},
+}
+
+func (s *GRPCTestSuite) TestWithinBox() {
+	box := domain.BoundingBox{MinLon: 2, MinLat: 50, MaxLon: 5, MaxLat: 52}
+	ports := []*domain.Port{{ID: "BEANR"}, {ID: "NLRTM"}}
+	for _, ex := range examplesWithinBox {
+		s.mock.batch = ports
+		s.mock.err = ex.errService
+		s.Run(ex.name, func() {
+			stream := &mockBoxStream{errSend: ex.errSend}
+			err := s.server.WithinBox(proto.BoxDomainToProto(box), stream)
+			s.Equal(box, s.mock.box, "Should pass bounding box to service")
+			s.Len(stream.ports, ex.sent, "Should send expected number of ports")
+			s.Equal(ex.status, status.Code(err), "Should return expected error code")
+		})
+	}
+}
+
+var examplesWatch = []struct {
+	name       string
+	status     codes.Code
+	errService error
+	events     []domain.PortEvent
+	sent       []*proto.PortEvent
+}{
+	{
+		name: "Client gone",
+		events: []domain.PortEvent{
+			{Sequence: 7, Op: domain.PortCreated, Port: &domain.Port{ID: "BEANR"}},
+			{Sequence: 8, Op: domain.PortDeleted, Port: &domain.Port{ID: "NLRTM"}},
+		},
+		sent: []*proto.PortEvent{
+			{Sequence: 7, Op: proto.PortEvent_CREATED, Port: proto.PortDomainToProto(&domain.Port{ID: "BEANR"})},
+			{Sequence: 8, Op: proto.PortEvent_DELETED, Port: proto.PortDomainToProto(&domain.Port{ID: "NLRTM"})},
+		},
+		status: codes.Canceled,
+	},
+	{
+		name:       "Invalid sequence",
+		errService: service.ErrInvalidSequence,
+		status:     codes.InvalidArgument,
+	},
+	{
+		name:       "Events pruned",
+		errService: domain.ErrEventsPruned,
+		status:     codes.OutOfRange,
+	},
+}
+
+func (s *GRPCTestSuite) TestWatch() {
+	for _, ex := range examplesWatch {
+		s.mock.err = ex.errService
+		s.mock.events = ex.events
+		s.Run(ex.name, func() {
+			stream := newMockWatchStream(len(ex.sent))
+			err := s.server.Watch(&proto.WatchRequest{FromSequence: 7}, stream)
+			s.Equal(int64(7), s.mock.from, "Should pass sequence to service")
+			s.Equal(ex.sent, stream.events, "Should send events")
+			s.Equal(ex.status, status.Code(err), "Should return expected error code")
+		})
+	}
+}
+
+func (s *GRPCTestSuite) TestWatchShutdown() {
+	s.mock.err = nil
+	s.mock.events = []domain.PortEvent{{Sequence: 1, Op: domain.PortCreated, Port: &domain.Port{ID: "BEANR"}}}
+	server := grpcserver.New(s.mock, s.logger)
+	stream := newMockWatchStream(0)
+	done := make(chan error)
+	go func() {
+		done <- server.Watch(&proto.WatchRequest{}, stream)
+	}()
+
+	<-stream.sent
+	server.GracefulStop()
+	s.Equal(codes.Unavailable, status.Code(<-done), "Should end watch with unavailable on shutdown")
+}
+
+var examplesDelete = []struct {
+	name       string
+	status     codes.Code
+	errService error
+	revision   int64
+}{
+	{
+		name:       "No error",
+		errService: nil,
+	},
+	{
+		name:     "Expected revision",
+		revision: 3,
+	},
+	{
+		name:       "Revision mismatch",
+		errService: domain.ErrRevisionMismatch,
+		status:     codes.FailedPrecondition,
+		revision:   3,
+	},
+	{
+		name:       "Not found",
+		errService: domain.ErrNotFound,
+		status:     codes.NotFound,
+	},
+	{
+		name:       "Missing ID",
+		errService: service.ErrPortMissingID,
+		status:     codes.InvalidArgument,
+	},
+}
+
+func (s *GRPCTestSuite) TestDelete() {
+	for _, ex := range examplesDelete {
+		s.mock.deleted = ""
+		s.mock.err = ex.errService
+		s.observed.TakeAll()
+		s.Run(ex.name, func() {
+			_, err := s.server.Delete(context.TODO(), &proto.PortRequest{Id: "AEAJM", ExpectedRevision: ex.revision})
+			s.Equal("AEAJM", s.mock.deleted, "Should delete expected port")
+			s.Equal(ex.revision, s.mock.revision, "Should delete port with expected revision")
+			s.Equal(ex.status, status.Code(err), "Should return expected error code")
+			if err != nil {
+				s.Equal(
+					1, s.observed.FilterMessage(fmt.Errorf("[grpc] delete: %w", ex.errService).Error()).Len(),
+					"Should contain appropriate log message",
+				)
+			}
+		})
+	}
+}
+
+var examplesHistory = []struct {
+	name       string
+	status     codes.Code
+	errService error
+	history    []domain.PortChange
+	result     *proto.PortHistory
+}{
+	{
+		name: "No error",
+		history: []domain.PortChange{
+			{Sequence: 1, Op: domain.PortCreated, Source: "file:ports.json", ChangedAt: time.Unix(0, 0).UTC()},
+			{
+				Sequence: 2, Op: domain.PortUpdated, Previous: &domain.Port{ID: "AEAJM", Name: "Ajman"},
+				Source: "grpc:10.0.0.1:5000", ChangedAt: time.Unix(60, 0).UTC(),
+			},
+		},
+		result: &proto.PortHistory{Changes: []*proto.PortChange{
+			{Sequence: 1, Op: proto.PortEvent_CREATED, Source: "file:ports.json", ChangedAt: time.Unix(0, 0).UTC()},
+			{
+				Sequence: 2, Op: proto.PortEvent_UPDATED,
+				Previous: proto.PortDomainToProto(&domain.Port{ID: "AEAJM", Name: "Ajman"}),
+				Source:   "grpc:10.0.0.1:5000", ChangedAt: time.Unix(60, 0).UTC(),
+			},
+		}},
+	},
+	{
+		name:       "Not found",
+		errService: domain.ErrNotFound,
+		status:     codes.NotFound,
+		result:     &proto.PortHistory{Changes: []*proto.PortChange{}},
+	},
+}
+
+func (s *GRPCTestSuite) TestHistory() {
+	for _, ex := range examplesHistory {
+		s.mock.err = ex.errService
+		s.mock.history = ex.history
+		s.observed.TakeAll()
+		s.Run(ex.name, func() {
+			res, err := s.server.History(context.TODO(), &proto.PortRequest{Id: "AEAJM"})
+			s.Equal(ex.status, status.Code(err), "Should return expected error code")
+			s.Equal(ex.result, res, "Should return converted history")
+			if err != nil {
+				s.Equal(
+					1, s.observed.FilterMessage(fmt.Errorf("[grpc] history: %w", ex.errService).Error()).Len(),
+					"Should contain appropriate log message",
+				)
+			}
+		})
+	}
+}
+
+var examplesSaveBatch = []struct {
+	name       string
+	status     codes.Code
+	errService error
+	result     *proto.BatchResult
+}{
+	{
+		name:       "No error",
+		errService: nil,
+		result:     &proto.BatchResult{Inserted: 2},
+	},
+	{
+		name: "Item errors",
+		errService: fmt.Errorf("wrapped: %w", &domain.BatchError{Failures: []domain.BatchFailure{
+			{Index: 0, Err: service.ErrPortMissingID},
+			{Index: 1, ID: "AEAJM", Err: errFoo},
+		}}),
+		result: &proto.BatchResult{Inserted: 2, Failures: []*proto.BatchFailure{
+			{Index: 0, Code: uint32(codes.InvalidArgument), Message: service.ErrPortMissingID.Error()},
+			{Index: 1, Id: "AEAJM", Code: uint32(codes.Internal), Message: errFoo.Error()},
+		}},
+	},
+	{
+		name:       "Test error",
+		errService: errFoo,
+		status:     codes.Internal,
+		result:     &proto.BatchResult{Inserted: 2},
+	},
+}
+
+func (s *GRPCTestSuite) TestSaveBatch() {
+	ports := []*domain.Port{{ID: "AEAJM", Name: "Ajman"}, {ID: "ZAPLZ", Name: "Port Elizabeth"}}
+	for _, ex := range examplesSaveBatch {
+		s.mock.batch = nil
+		s.mock.err = ex.errService
+		s.Run(ex.name, func() {
+			res, err := s.server.SaveBatch(context.TODO(), &proto.PortBatch{Ports: proto.PortsDomainToProto(ports)})
+			s.Equal(ports, s.mock.batch, "Should save expected ports")
+			s.Equal(ex.result, res, "Should return expected result")
+			s.Equal(ex.status, status.Code(err), "Should return expected error code")
+		})
+	}
+}
+
+var examplesImportPorts = []struct {
+	name           string
+	count          int
+	batchSize      string
+	chunk          int
+	status         codes.Code
+	errService     error
+	serviceSummary domain.ImportSummary
+	summary        *proto.ImportSummary
+}{
+	{
+		name:           "Several chunks",
+		count:          1200,
+		batchSize:      "100",
+		chunk:          100,
+		serviceSummary: domain.ImportSummary{Total: 1200, Inserted: 1200},
+		summary:        &proto.ImportSummary{Total: 1200, Inserted: 1200},
+	},
+	{
+		name:    "Empty stream",
+		count:   0,
+		chunk:   service.DefaultImportBatchSize,
+		summary: &proto.ImportSummary{},
+	},
+	{
+		name:      "Invalid batch size",
+		count:     10,
+		batchSize: "0",
+		status:    codes.InvalidArgument,
+	},
+	{
+		name:      "Malformed batch size",
+		count:     10,
+		batchSize: "many",
+		status:    codes.InvalidArgument,
+	},
+	{
+		name:  "Rejected ports",
+		count: 10,
+		chunk: service.DefaultImportBatchSize,
+		serviceSummary: domain.ImportSummary{
+			Total: 10, Inserted: 9, Rejected: 1,
+			Failures:       []domain.BatchFailure{{Index: 3, ID: "3", Err: service.ErrPortMissingID}},
+			RejectedByCode: map[string]int{codes.InvalidArgument.String(): 1},
+		},
+		summary: &proto.ImportSummary{
+			Total: 10, Inserted: 9, Rejected: 1,
+			Failures: []*proto.BatchFailure{
+				{Index: 3, Id: "3", Code: uint32(codes.InvalidArgument), Message: service.ErrPortMissingID.Error()},
+			},
+			RejectedByCode: map[string]int64{codes.InvalidArgument.String(): 1},
+		},
+	},
+	{
+		name:       "Test error",
+		count:      10,
+		errService: errFoo,
+		status:     codes.Internal,
+	},
+}
+
+func (s *GRPCTestSuite) TestImportPorts() {
+	for _, ex := range examplesImportPorts {
+		s.mock.batch = nil
+		s.mock.chunk = 0
+		s.mock.err = ex.errService
+		s.mock.summary = ex.serviceSummary
+		ports := make([]*domain.Port, ex.count)
+		for i := range ports {
+			ports[i] = &domain.Port{ID: strconv.Itoa(i)}
+		}
+		stream := &mockImportStream{ports: proto.PortsDomainToProto(ports)}
+		if ex.batchSize != "" {
+			stream.md = metadata.Pairs(proto.BatchSizeMetadataKey, ex.batchSize)
+		}
+		s.Run(ex.name, func() {
+			err := s.server.ImportPorts(stream)
+			s.Equal(ex.status, status.Code(err), "Should return expected error code")
+			s.Equal(ex.summary, stream.summary, "Should return expected summary")
+			if err == nil {
+				s.Equal(len(ports), len(s.mock.batch), "Should save all ports")
+				s.Equal(ex.chunk, s.mock.chunk, "Should import in batches of size sent by client")
+			}
+		})
+	}
+}
+
+// sourceService reports source of saved ports.
+type sourceService struct {
+	*mockService
+	sources chan string
+}
+
+func (ss sourceService) Save(ctx context.Context, p *domain.Port, _ int64) (*domain.Port, error) {
+	ss.sources <- domain.SourceFromContext(ctx)
+	return p, nil
+}
+
+func (s *GRPCTestSuite) TestSource() {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().Nil(err, "Should listen with no error")
+	ss := sourceService{mockService: &mockService{}, sources: make(chan string, 1)}
+	server := grpcserver.New(ss, s.logger)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	defer server.GracefulStop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, lis.Addr().String(), grpc.WithInsecure(), grpc.WithBlock())
+	s.Require().Nil(err, "Should connect with no error")
+	defer conn.Close()
+
+	ctx = metadata.AppendToOutgoingContext(ctx, proto.SourceMetadataKey, "file:ports.json")
+	_, err = proto.NewPortsClient(conn).Save(ctx, &proto.Port{Id: "AEAJM"})
+	s.Require().Nil(err, "Should save port with no error")
+
+	source := <-ss.sources
+	s.NotEqual("file:ports.json", source, "Should not record forwarded source verbatim")
+	s.True(strings.HasPrefix(source, "grpc:127.0.0.1:"), "Should record address of the caller")
+	s.True(strings.HasSuffix(source, "/file:ports.json"), "Should keep forwarded source as a hint")
+}
+
 func TestGRPCTestSuite(t *testing.T) {
 	suite.Run(t, new(GRPCTestSuite))
 }
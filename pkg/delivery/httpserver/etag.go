@@ -0,0 +1,46 @@
+package httpserver
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/sp4rd4/ports/pkg/domain"
+)
+
+// etag is strong entity tag of port revision.
+func etag(revision int64) string {
+	return `"` + strconv.FormatInt(revision, 10) + `"`
+}
+
+// ifMatch returns revision required by If-Match header, 0 if there is none or it is "*".
+// Entity tags which are not port revisions never match.
+func ifMatch(r *http.Request) (int64, error) {
+	v := strings.TrimSpace(r.Header.Get("If-Match"))
+	if v == "" || v == "*" {
+		return 0, nil
+	}
+	if len(v) < 2 || v[0] != '"' || v[len(v)-1] != '"' {
+		return 0, fmt.Errorf("[%v] if-match: %w", errorTag, domain.ErrRevisionMismatch)
+	}
+	revision, err := strconv.ParseInt(v[1:len(v)-1], 10, 64)
+	if err != nil || revision <= 0 {
+		return 0, fmt.Errorf("[%v] if-match: %w", errorTag, domain.ErrRevisionMismatch)
+	}
+	return revision, nil
+}
+
+// noneMatch reports if If-None-Match header lists tag, comparison is weak as required for GET.
+func noneMatch(r *http.Request, tag string) bool {
+	v := r.Header.Get("If-None-Match")
+	if strings.TrimSpace(v) == "*" {
+		return true
+	}
+	for _, t := range strings.Split(v, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(t), "W/") == tag {
+			return true
+		}
+	}
+	return false
+}
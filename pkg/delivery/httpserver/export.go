@@ -0,0 +1,59 @@
+package httpserver
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/middleware"
+	"github.com/sp4rd4/ports/pkg/ndjson"
+	"github.com/sp4rd4/ports/pkg/service"
+	"go.uber.org/zap"
+)
+
+const ndjsonContentType = "application/x-ndjson"
+
+// ndjsonWriter sends response status with the first written ports so errors occurred before them
+// can still be rendered properly.
+type ndjsonWriter struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+func (nw *ndjsonWriter) start() {
+	nw.started = true
+	nw.w.Header().Add("Content-Type", ndjsonContentType)
+	nw.w.WriteHeader(http.StatusOK)
+}
+
+func (nw *ndjsonWriter) Write(p []byte) (int, error) {
+	if !nw.started {
+		nw.start()
+	}
+	return nw.w.Write(p)
+}
+
+// Export streams all ports as newline delimited json, the format PORTS_FILE can be loaded from.
+func (pc *Ports) Export(w http.ResponseWriter, r *http.Request) {
+	reqID := middleware.GetReqID(r.Context())
+	rLog := pc.logger.With(zap.String("reqId", reqID))
+
+	nw := &ndjsonWriter{w: w}
+	_, err := ndjson.NewExporter(pc.service, service.MaxListLimit).Export(r.Context(), nw)
+
+	switch {
+	case err == nil:
+		if !nw.started {
+			nw.start()
+		}
+		return
+	case nw.started:
+		// status is already sent, truncated stream is the only way to signal failure
+		rLog.Error(fmt.Errorf("[%v] export stream: %w", errorTag, err).Error())
+		return
+	default:
+		err = pc.renderError(err, w, rLog)
+	}
+	if err != nil {
+		rLog.Error(fmt.Errorf("[%v] render error: %w", errorTag, err).Error())
+	}
+}
@@ -0,0 +1,72 @@
+package httpserver_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/sp4rd4/ports/pkg/delivery/httpserver"
+	"github.com/sp4rd4/ports/pkg/domain"
+	"github.com/sp4rd4/ports/pkg/service"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+var examplesExport = []struct {
+	name       string
+	status     int
+	errService error
+	ports      []*domain.Port
+	body       string
+}{
+	{
+		name:   "No error",
+		status: http.StatusOK,
+		ports: []*domain.Port{
+			{ID: "BEANR", Name: "Antwerp", Coordinates: domain.Location{Latitude: 51.2, Longitude: 4.4}},
+			{ID: "NLRTM", Name: "Rotterdam", Revision: 2},
+		},
+		body: `{"id":"BEANR","name":"Antwerp","city":"","country":"","alias":null,"regions":null,` +
+			`"coordinates":{"lat":51.2,"lon":4.4},"province":"","timezone":"","unlocs":null,"code":"","revision":0}` +
+			"\n" +
+			`{"id":"NLRTM","name":"Rotterdam","city":"","country":"","alias":null,"regions":null,` +
+			`"coordinates":{"lat":0,"lon":0},"province":"","timezone":"","unlocs":null,"code":"","revision":2}` +
+			"\n",
+	},
+	{
+		name:   "Empty",
+		status: http.StatusOK,
+	},
+	{
+		name:       "Deadline exceeded",
+		status:     http.StatusGatewayTimeout,
+		errService: context.DeadlineExceeded,
+	},
+}
+
+func TestExport(t *testing.T) {
+	ms := &mockService{}
+	handler := httpserver.New(ms, zap.NewNop())
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.New(t, server.URL)
+
+	for _, ex := range examplesExport {
+		ms.page = domain.Page{Ports: ex.ports}
+		ms.err = ex.errService
+
+		t.Run(ex.name, func(t *testing.T) {
+			expct := e.GET("/ports/export").Expect().Status(ex.status)
+			assert.Equal(t, service.MaxListLimit, ms.limit, "Should list ports by max pages")
+			if ex.status == http.StatusOK {
+				expct.ContentType("application/x-ndjson")
+				expct.Body().Equal(ex.body)
+			} else {
+				expct.JSON().Object().ValueEqual("message", http.StatusText(ex.status))
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package httpserver
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/middleware"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/sp4rd4/ports/pkg/domain"
+	"go.uber.org/zap"
+)
+
+const (
+	geoJSONContentType = "application/geo+json"
+
+	featureCollectionHead = `{"type":"FeatureCollection","features":[`
+	featureCollectionTail = `]}`
+)
+
+type feature struct {
+	Type       string            `json:"type"`
+	ID         string            `json:"id"`
+	Geometry   point             `json:"geometry"`
+	Properties featureProperties `json:"properties"`
+}
+
+// point coordinates follow GeoJSON order: longitude first.
+type point struct {
+	Type        string     `json:"type"`
+	Coordinates [2]float64 `json:"coordinates"`
+}
+
+type featureProperties struct {
+	Name     string             `json:"name"`
+	City     string             `json:"city"`
+	Country  string             `json:"country"`
+	Alias    domain.StringArray `json:"alias"`
+	Regions  domain.StringArray `json:"regions"`
+	Province string             `json:"province"`
+	Timezone string             `json:"timezone"`
+	Unlocs   domain.StringArray `json:"unlocs"`
+	Code     string             `json:"code"`
+}
+
+func newFeature(port *domain.Port) feature {
+	return feature{
+		Type: "Feature",
+		ID:   port.ID,
+		Geometry: point{
+			Type:        "Point",
+			Coordinates: [2]float64{port.Coordinates.Longitude, port.Coordinates.Latitude},
+		},
+		Properties: featureProperties{
+			Name:     port.Name,
+			City:     port.City,
+			Country:  port.Country,
+			Alias:    port.Alias,
+			Regions:  port.Regions,
+			Province: port.Province,
+			Timezone: port.Timezone,
+			Unlocs:   port.Unlocs,
+			Code:     port.Code,
+		},
+	}
+}
+
+// featureWriter streams FeatureCollection, response status is sent with the first feature
+// so errors occurred before it can still be rendered properly.
+type featureWriter struct {
+	w       http.ResponseWriter
+	json    jsoniter.API
+	started bool
+}
+
+func (fw *featureWriter) start() error {
+	fw.started = true
+	fw.w.Header().Add("Content-Type", geoJSONContentType)
+	fw.w.WriteHeader(http.StatusOK)
+	if _, err := fw.w.Write([]byte(featureCollectionHead)); err != nil {
+		return fmt.Errorf("[%v] render: %w", errorTag, err)
+	}
+	return nil
+}
+
+func (fw *featureWriter) write(port *domain.Port) error {
+	sep := []byte(",")
+	if !fw.started {
+		if err := fw.start(); err != nil {
+			return err
+		}
+		sep = nil
+	}
+	resp, err := fw.json.Marshal(newFeature(port))
+	if err != nil {
+		return fmt.Errorf("[%v] marshal: %w", errorTag, err)
+	}
+	if _, err = fw.w.Write(append(sep, resp...)); err != nil {
+		return fmt.Errorf("[%v] render: %w", errorTag, err)
+	}
+	return nil
+}
+
+func (fw *featureWriter) close() error {
+	if !fw.started {
+		if err := fw.start(); err != nil {
+			return err
+		}
+	}
+	if _, err := fw.w.Write([]byte(featureCollectionTail)); err != nil {
+		return fmt.Errorf("[%v] render: %w", errorTag, err)
+	}
+	return nil
+}
+
+func (pc *Ports) GeoJSON(w http.ResponseWriter, r *http.Request) {
+	reqID := middleware.GetReqID(r.Context())
+	rLog := pc.logger.With(zap.String("reqId", reqID))
+
+	fw := &featureWriter{w: w, json: pc.json}
+	box, err := queryBox(r, "bbox")
+	if err == nil {
+		err = pc.service.WithinBox(r.Context(), box, fw.write)
+	}
+
+	switch {
+	case err == nil:
+		err = fw.close()
+	case fw.started:
+		// status is already sent, truncated collection is the only way to signal failure
+		rLog.Error(fmt.Errorf("[%v] geojson stream: %w", errorTag, err).Error())
+		return
+	default:
+		err = pc.renderError(err, w, rLog)
+	}
+	if err != nil {
+		rLog.Error(fmt.Errorf("[%v] render error: %w", errorTag, err).Error())
+	}
+}
+
+// queryBox parses bounding box query parameter in minLon,minLat,maxLon,maxLat format.
+func queryBox(r *http.Request, name string) (domain.BoundingBox, error) {
+	parts := strings.Split(r.URL.Query().Get(name), ",")
+	if len(parts) != 4 {
+		return domain.BoundingBox{}, fmt.Errorf("[%v] %v: %w", errorTag, name, errInvalidQuery)
+	}
+	var values [4]float64
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			return domain.BoundingBox{}, fmt.Errorf("[%v] %v: %w", errorTag, name, errInvalidQuery)
+		}
+		values[i] = v
+	}
+	return domain.BoundingBox{MinLon: values[0], MinLat: values[1], MaxLon: values[2], MaxLat: values[3]}, nil
+}
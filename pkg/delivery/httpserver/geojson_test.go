@@ -0,0 +1,111 @@
+package httpserver_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/sp4rd4/ports/pkg/delivery/httpserver"
+	"github.com/sp4rd4/ports/pkg/domain"
+	"github.com/sp4rd4/ports/pkg/service"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+var examplesGeoJSON = []struct {
+	name       string
+	query      string
+	status     int
+	errService error
+	errStream  error
+	box        domain.BoundingBox
+	ports      []*domain.Port
+	body       string
+}{
+	{
+		name:   "No error",
+		query:  "bbox=2,50,5,52",
+		status: http.StatusOK,
+		box:    domain.BoundingBox{MinLon: 2, MinLat: 50, MaxLon: 5, MaxLat: 52},
+		ports: []*domain.Port{
+			{
+				ID:          "BEANR",
+				Name:        "Antwerp",
+				Country:     "Belgium",
+				Coordinates: domain.Location{Latitude: 51.2, Longitude: 4.4},
+				Unlocs:      domain.StringArray{"BEANR"},
+			},
+			{ID: "NLRTM", Name: "Rotterdam", Coordinates: domain.Location{Latitude: 51.9, Longitude: 4.5}},
+		},
+		body: `{"type":"FeatureCollection","features":[` +
+			`{"type":"Feature","id":"BEANR","geometry":{"type":"Point","coordinates":[4.4,51.2]},` +
+			`"properties":{"name":"Antwerp","city":"","country":"Belgium","alias":null,"regions":null,` +
+			`"province":"","timezone":"","unlocs":["BEANR"],"code":""}},` +
+			`{"type":"Feature","id":"NLRTM","geometry":{"type":"Point","coordinates":[4.5,51.9]},` +
+			`"properties":{"name":"Rotterdam","city":"","country":"","alias":null,"regions":null,` +
+			`"province":"","timezone":"","unlocs":null,"code":""}}]}`,
+	},
+	{
+		name:   "Empty",
+		query:  "bbox=170,-20,-170,-10",
+		status: http.StatusOK,
+		box:    domain.BoundingBox{MinLon: 170, MinLat: -20, MaxLon: -170, MaxLat: -10},
+		body:   `{"type":"FeatureCollection","features":[]}`,
+	},
+	{
+		name:   "Missing bbox",
+		status: http.StatusBadRequest,
+	},
+	{
+		name:   "Invalid bbox",
+		query:  "bbox=2,50,5",
+		status: http.StatusBadRequest,
+	},
+	{
+		name:       "Box rejected",
+		query:      "bbox=2,52,5,50",
+		status:     http.StatusBadRequest,
+		errService: service.ErrInvalidBox,
+		box:        domain.BoundingBox{MinLon: 2, MinLat: 52, MaxLon: 5, MaxLat: 50},
+	},
+	{
+		name:      "Stream error",
+		query:     "bbox=2,50,5,52",
+		status:    http.StatusOK,
+		errStream: errFoo,
+		box:       domain.BoundingBox{MinLon: 2, MinLat: 50, MaxLon: 5, MaxLat: 52},
+		ports:     []*domain.Port{{ID: "BEANR"}},
+		body: `{"type":"FeatureCollection","features":[` +
+			`{"type":"Feature","id":"BEANR","geometry":{"type":"Point","coordinates":[0,0]},` +
+			`"properties":{"name":"","city":"","country":"","alias":null,"regions":null,` +
+			`"province":"","timezone":"","unlocs":null,"code":""}}`,
+	},
+}
+
+func TestGeoJSON(t *testing.T) {
+	ms := &mockService{}
+	handler := httpserver.New(ms, zap.NewNop())
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.New(t, server.URL)
+
+	for _, ex := range examplesGeoJSON {
+		ms.err = ex.errService
+		ms.errStream = ex.errStream
+		ms.inBox = ex.ports
+		ms.box = domain.BoundingBox{}
+
+		t.Run(ex.name, func(t *testing.T) {
+			expct := e.GET("/ports/geojson").WithQueryString(ex.query).Expect().Status(ex.status)
+			assert.Equal(t, ex.box, ms.box, "Should pass bounding box to service")
+			if ex.status == http.StatusOK {
+				expct.ContentType("application/geo+json")
+				expct.Body().Equal(ex.body)
+			} else {
+				expct.JSON().Object().ValueEqual("message", http.StatusText(ex.status))
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package httpserver
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/sp4rd4/ports/pkg/domain"
+	"go.uber.org/zap"
+)
+
+// ByUnlocode gets port by any of its UN/LOCODEs.
+func (pc *Ports) ByUnlocode(w http.ResponseWriter, r *http.Request) {
+	code := pathParam(r, "code")
+	rLog := pc.logger.With(zap.String("reqId", middleware.GetReqID(r.Context())), zap.String("unlocode", code))
+	port, err := pc.service.GetByUnlocode(r.Context(), code)
+	pc.renderLookup(w, port, err, rLog)
+}
+
+// ByAlias gets port by alternative name, several candidates are listed with 300 if name is ambiguous.
+func (pc *Ports) ByAlias(w http.ResponseWriter, r *http.Request) {
+	name := pathParam(r, "name")
+	rLog := pc.logger.With(zap.String("reqId", middleware.GetReqID(r.Context())), zap.String("alias", name))
+	port, err := pc.service.GetByAlias(r.Context(), name)
+	pc.renderLookup(w, port, err, rLog)
+}
+
+func (pc *Ports) renderLookup(w http.ResponseWriter, port *domain.Port, err error, rLog *zap.Logger) {
+	if err == nil {
+		w.Header().Set("ETag", etag(port.Revision))
+		err = pc.renderData(w, http.StatusOK, port)
+	} else {
+		err = pc.renderError(err, w, rLog)
+	}
+	if err != nil {
+		rLog.Error(fmt.Errorf("[%v] render error: %w", errorTag, err).Error())
+	}
+}
+
+// pathParam returns unescaped path parameter, chi matches escaped path if it has encoded slashes.
+func pathParam(r *http.Request, name string) string {
+	v := chi.URLParam(r, name)
+	if r.URL.RawPath == "" {
+		return v
+	}
+	if unescaped, err := url.PathUnescape(v); err == nil {
+		return unescaped
+	}
+	return v
+}
@@ -5,16 +5,36 @@ import (
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
+	"github.com/sp4rd4/ports/pkg/domain"
 	l "github.com/treastech/logger"
 )
 
 func (pc *Ports) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	r := chi.NewRouter()
 
-	r.Use(middleware.Recoverer, middleware.RequestID, l.Logger(pc.logger))
+	r.Use(middleware.Recoverer, middleware.RequestID, source, l.Logger(pc.logger))
 	r.Route("/ports", func(r chi.Router) {
+		r.Get("/", pc.List)
+		r.Get("/nearby", pc.Nearby)
+		r.Get("/search", pc.Search)
+		r.Get("/geojson", pc.GeoJSON)
+		r.Get("/export", pc.Export)
+		r.Get("/by-unlocode/{code}", pc.ByUnlocode)
+		r.Get("/by-alias/{name}", pc.ByAlias)
 		r.Get("/{portID}", pc.Get)
+		r.Get("/{portID}/history", pc.History)
+		r.Put("/{portID}", pc.Put)
+		r.Patch("/{portID}", pc.Patch)
+		r.Delete("/{portID}", pc.Delete)
 	})
 
 	r.ServeHTTP(w, req)
 }
+
+// source tags changes made by request with its id.
+func source(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := domain.WithSource(r.Context(), "http:"+middleware.GetReqID(r.Context()))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -1,9 +1,13 @@
 package httpserver
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
@@ -13,67 +17,316 @@ import (
 	"go.uber.org/zap"
 )
 
-const errorTag = "http"
+const (
+	errorTag = "http"
 
-//nolint
-var json = jsoniter.ConfigDefault
+	// StatusClientClosedRequest is a non-standard status code used when client
+	// closes connection before response is ready.
+	StatusClientClosedRequest = 499
+)
+
+var errInvalidQuery = errors.New("invalid query parameter")
 
 type PortService interface {
-	Get(id string) (*domain.Port, error)
+	Get(ctx context.Context, id string) (*domain.Port, error)
+	GetAsOf(ctx context.Context, id string, at time.Time) (*domain.Port, error)
+	GetByUnlocode(ctx context.Context, code string) (*domain.Port, error)
+	GetByAlias(ctx context.Context, name string) (*domain.Port, error)
+	Save(ctx context.Context, port *domain.Port, expectedRevision int64) (*domain.Port, error)
+	Delete(ctx context.Context, id string, expectedRevision int64) error
+	List(ctx context.Context, filter domain.PortFilter, cursor string, limit int) (domain.Page, error)
+	Nearby(ctx context.Context, point domain.Location, radiusKm float64, limit int) ([]domain.NearbyPort, error)
+	SearchByName(ctx context.Context, query string, limit int) ([]domain.MatchedPort, error)
+	WithinBox(ctx context.Context, box domain.BoundingBox, fn func(*domain.Port) error) error
+	History(ctx context.Context, id string) ([]domain.PortChange, error)
 }
 
 type Ports struct {
 	service PortService
 	logger  *zap.Logger
+	order   domain.CoordinateOrder
+	json    jsoniter.API
 }
 
-func New(srvc PortService, logger *zap.Logger) *Ports {
-	return &Ports{
+type Option func(*Ports)
+
+// Coordinates sets order of coordinates arrays in responses and request bodies, default is domain.LonLat.
+func Coordinates(order domain.CoordinateOrder) Option {
+	return func(pc *Ports) {
+		pc.order = order
+	}
+}
+
+func New(srvc PortService, logger *zap.Logger, opts ...Option) *Ports {
+	pc := &Ports{
 		service: srvc,
 		logger:  logger,
+		order:   domain.LonLat,
+	}
+	for _, opt := range opts {
+		opt(pc)
 	}
+	// same settings as jsoniter.ConfigDefault, which can not be extended without affecting other users
+	pc.json = jsoniter.Config{EscapeHTML: true}.Froze()
+	pc.json.RegisterExtension(pc.order.Extension())
+	return pc
 }
 
 func (pc *Ports) Get(w http.ResponseWriter, r *http.Request) {
 	reqID := middleware.GetReqID(r.Context())
 	portID := chi.URLParam(r, "portID")
 	rLog := pc.logger.With(zap.String("reqId", reqID), zap.String("portId", portID))
-	port, err := pc.service.Get(portID)
+
+	var port *domain.Port
+	asOf, err := queryTime(r, "as_of")
+	switch {
+	case err != nil:
+	case asOf.IsZero():
+		port, err = pc.service.Get(r.Context(), portID)
+	default:
+		port, err = pc.service.GetAsOf(r.Context(), portID, asOf)
+	}
+
+	if err == nil {
+		tag := etag(port.Revision)
+		w.Header().Set("ETag", tag)
+		if noneMatch(r, tag) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		err = pc.renderData(w, http.StatusOK, port)
+	} else {
+		err = pc.renderError(err, w, rLog)
+	}
+	if err != nil {
+		rLog.Error(fmt.Errorf("[%v] render error: %w", errorTag, err).Error())
+	}
+}
+
+func (pc *Ports) History(w http.ResponseWriter, r *http.Request) {
+	reqID := middleware.GetReqID(r.Context())
+	portID := chi.URLParam(r, "portID")
+	rLog := pc.logger.With(zap.String("reqId", reqID), zap.String("portId", portID))
+	changes, err := pc.service.History(r.Context(), portID)
+
+	if err == nil {
+		err = pc.renderData(w, http.StatusOK, changes)
+	} else {
+		err = pc.renderError(err, w, rLog)
+	}
+	if err != nil {
+		rLog.Error(fmt.Errorf("[%v] render error: %w", errorTag, err).Error())
+	}
+}
+
+func (pc *Ports) List(w http.ResponseWriter, r *http.Request) {
+	reqID := middleware.GetReqID(r.Context())
+	rLog := pc.logger.With(zap.String("reqId", reqID))
+
+	query := r.URL.Query()
+	filter := domain.PortFilter{
+		Country:  query.Get("country"),
+		Province: query.Get("province"),
+		City:     query.Get("city"),
+		Region:   query.Get("region"),
+		Timezone: query.Get("timezone"),
+	}
+
+	var page domain.Page
+	limit, err := queryInt(r, "limit")
+	if err == nil {
+		page, err = pc.service.List(r.Context(), filter, query.Get("cursor"), limit)
+	}
+
+	if err == nil {
+		err = pc.renderData(w, http.StatusOK, page)
+	} else {
+		err = pc.renderError(err, w, rLog)
+	}
+	if err != nil {
+		rLog.Error(fmt.Errorf("[%v] render error: %w", errorTag, err).Error())
+	}
+}
+
+func (pc *Ports) Nearby(w http.ResponseWriter, r *http.Request) {
+	reqID := middleware.GetReqID(r.Context())
+	rLog := pc.logger.With(zap.String("reqId", reqID))
+
+	var ports []domain.NearbyPort
+	point, radiusKm, limit, err := nearbyQuery(r)
+	if err == nil {
+		ports, err = pc.service.Nearby(r.Context(), point, radiusKm, limit)
+	}
 
 	if err == nil {
-		err = renderData(w, http.StatusOK, port)
+		err = pc.renderData(w, http.StatusOK, ports)
 	} else {
-		err = renderError(err, w, rLog)
+		err = pc.renderError(err, w, rLog)
 	}
 	if err != nil {
 		rLog.Error(fmt.Errorf("[%v] render error: %w", errorTag, err).Error())
 	}
 }
 
+// nearbyQuery parses lat, lon, radius_km and limit query parameters, only lat and lon are required.
+func nearbyQuery(r *http.Request) (point domain.Location, radiusKm float64, limit int, err error) {
+	if point.Latitude, err = queryFloat(r, "lat", true); err != nil {
+		return
+	}
+	if point.Longitude, err = queryFloat(r, "lon", true); err != nil {
+		return
+	}
+	if radiusKm, err = queryFloat(r, "radius_km", false); err != nil {
+		return
+	}
+	limit, err = queryInt(r, "limit")
+	return
+}
+
+// queryFloat parses float query parameter, missing optional one is zero.
+func queryFloat(r *http.Request, name string, required bool) (float64, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" && !required {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("[%v] %v: %w", errorTag, name, errInvalidQuery)
+	}
+	return f, nil
+}
+
+// Search finds ports by similar name, city or alias for typeahead, best matches first.
+func (pc *Ports) Search(w http.ResponseWriter, r *http.Request) {
+	reqID := middleware.GetReqID(r.Context())
+	rLog := pc.logger.With(zap.String("reqId", reqID))
+
+	var ports []domain.MatchedPort
+	limit, err := queryInt(r, "limit")
+	if err == nil {
+		ports, err = pc.service.SearchByName(r.Context(), r.URL.Query().Get("q"), limit)
+	}
+
+	if err == nil {
+		err = pc.renderData(w, http.StatusOK, ports)
+	} else {
+		err = pc.renderError(err, w, rLog)
+	}
+	if err != nil {
+		rLog.Error(fmt.Errorf("[%v] render error: %w", errorTag, err).Error())
+	}
+}
+
+// queryInt parses optional integer query parameter, missing one is zero.
+func queryInt(r *http.Request, name string) (int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("[%v] %v: %w", errorTag, name, errInvalidQuery)
+	}
+	return i, nil
+}
+
+// queryTime parses optional RFC 3339 time query parameter, missing one is zero.
+func queryTime(r *http.Request, name string) (time.Time, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("[%v] %v: %w", errorTag, name, errInvalidQuery)
+	}
+	return t, nil
+}
+
+func (pc *Ports) Delete(w http.ResponseWriter, r *http.Request) {
+	reqID := middleware.GetReqID(r.Context())
+	portID := chi.URLParam(r, "portID")
+	rLog := pc.logger.With(zap.String("reqId", reqID), zap.String("portId", portID))
+	revision, err := ifMatch(r)
+	if err == nil {
+		err = pc.service.Delete(r.Context(), portID, revision)
+	}
+
+	if err == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	err = pc.renderError(err, w, rLog)
+	if err != nil {
+		rLog.Error(fmt.Errorf("[%v] render error: %w", errorTag, err).Error())
+	}
+}
+
 type message struct {
 	M string `json:"message"`
 }
 
-func renderError(err error, w http.ResponseWriter, logger *zap.Logger) error {
+type candidatesMessage struct {
+	M          string         `json:"message"`
+	Candidates []*domain.Port `json:"candidates"`
+}
+
+type violationsMessage struct {
+	M          string                  `json:"message"`
+	Violations []domain.FieldViolation `json:"violations"`
+}
+
+func (pc *Ports) renderError(err error, w http.ResponseWriter, logger *zap.Logger) error {
+	var (
+		verr *domain.ValidationError
+		aerr *domain.AmbiguousError
+	)
 	switch {
+	case errors.Is(err, context.Canceled):
+		err = pc.renderData(w, StatusClientClosedRequest, message{M: "Client Closed Request"})
+	case errors.Is(err, context.DeadlineExceeded):
+		err = pc.renderData(w, http.StatusGatewayTimeout, message{M: http.StatusText(http.StatusGatewayTimeout)})
 	case errors.Is(err, domain.ErrNotFound):
-		err = renderData(w, http.StatusNotFound, message{M: http.StatusText(http.StatusNotFound)})
-	case errors.Is(err, service.ErrPortMissingID):
-		err = renderData(w, http.StatusBadRequest, message{M: http.StatusText(http.StatusBadRequest)})
+		err = pc.renderData(w, http.StatusNotFound, message{M: http.StatusText(http.StatusNotFound)})
+	case errors.Is(err, domain.ErrRevisionMismatch):
+		err = pc.renderData(w, http.StatusPreconditionFailed, message{M: http.StatusText(http.StatusPreconditionFailed)})
+	case errors.Is(err, service.ErrPortMissingID), errors.Is(err, service.ErrInvalidLimit),
+		errors.Is(err, service.ErrInvalidFilter), errors.Is(err, service.ErrInvalidPoint),
+		errors.Is(err, service.ErrInvalidRadius), errors.Is(err, service.ErrInvalidBox),
+		errors.Is(err, errInvalidQuery), errors.Is(err, errInvalidBody), errors.Is(err, service.ErrInvalidInput),
+		errors.Is(err, service.ErrInvalidQuery):
+		err = pc.renderData(w, http.StatusBadRequest, message{M: http.StatusText(http.StatusBadRequest)})
+	case errors.Is(err, errUnsupportedMediaType):
+		err = pc.renderData(w, http.StatusUnsupportedMediaType, message{
+			M: http.StatusText(http.StatusUnsupportedMediaType),
+		})
+	case errors.As(err, &aerr):
+		err = pc.renderData(w, http.StatusMultipleChoices, candidatesMessage{
+			M:          http.StatusText(http.StatusMultipleChoices),
+			Candidates: aerr.Candidates,
+		})
+	case errors.As(err, &verr):
+		err = pc.renderData(w, http.StatusUnprocessableEntity, violationsMessage{
+			M:          http.StatusText(http.StatusUnprocessableEntity),
+			Violations: verr.Violations,
+		})
 	default:
 		logger.Error(fmt.Errorf("[%v]: %w", errorTag, err).Error())
-		err = renderData(w, http.StatusInternalServerError, message{M: http.StatusText(http.StatusInternalServerError)})
+		err = pc.renderData(w, http.StatusInternalServerError, message{M: http.StatusText(http.StatusInternalServerError)})
 	}
 	return err
 }
 
-func renderData(w http.ResponseWriter, code int, data interface{}) error {
+func (pc *Ports) renderData(w http.ResponseWriter, code int, data interface{}) error {
 	w.Header().Add("Content-Type", "application/json")
 	w.WriteHeader(code)
-	resp, err := json.Marshal(data)
+	resp, err := pc.json.Marshal(data)
 	if err != nil {
 		return fmt.Errorf("[%v] marshal: %w", errorTag, err)
 	}
 	_, err = w.Write(resp)
-	return fmt.Errorf("[%v] render: %w", errorTag, err)
+	if err != nil {
+		return fmt.Errorf("[%v] render: %w", errorTag, err)
+	}
+	return nil
 }
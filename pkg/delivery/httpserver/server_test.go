@@ -1,27 +1,136 @@
 package httpserver_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gavv/httpexpect/v2"
+	jsoniter "github.com/json-iterator/go"
 	"github.com/sp4rd4/ports/pkg/delivery/httpserver"
 	"github.com/sp4rd4/ports/pkg/domain"
+	"github.com/sp4rd4/ports/pkg/service"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
 )
 
+// rendered returns v decoded back from JSON as server renders it with default coordinates order.
+func rendered(v interface{}) interface{} {
+	api := jsoniter.Config{EscapeHTML: true}.Froze()
+	api.RegisterExtension(domain.LonLat.Extension())
+	b, err := api.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	var res interface{}
+	if err = api.Unmarshal(b, &res); err != nil {
+		panic(err)
+	}
+	return res
+}
+
 type mockService struct {
-	err  error
-	port *domain.Port
+	err       error
+	errSave   error
+	port      *domain.Port
+	saved     *domain.Port
+	stored    *domain.Port
+	deleted   string
+	revision  int64
+	source    string
+	page      domain.Page
+	cursor    string
+	limit     int
+	filter    domain.PortFilter
+	point     domain.Location
+	radius    float64
+	nearby    []domain.NearbyPort
+	box       domain.BoundingBox
+	inBox     []*domain.Port
+	errStream error
+	history   []domain.PortChange
+	asOf      time.Time
+	key       string
+	query     string
+	matched   []domain.MatchedPort
+}
+
+func (ms *mockService) GetAsOf(_ context.Context, id string, at time.Time) (*domain.Port, error) {
+	ms.asOf = at
+	return ms.port, ms.err
 }
 
-func (ms *mockService) Get(id string) (*domain.Port, error) {
+func (ms *mockService) GetByUnlocode(_ context.Context, code string) (*domain.Port, error) {
+	ms.key = code
+	return ms.port, ms.err
+}
+
+func (ms *mockService) GetByAlias(_ context.Context, name string) (*domain.Port, error) {
+	ms.key = name
+	return ms.port, ms.err
+}
+
+func (ms *mockService) List(
+	_ context.Context, filter domain.PortFilter, cursor string, limit int,
+) (domain.Page, error) {
+	ms.filter, ms.cursor, ms.limit = filter, cursor, limit
+	return ms.page, ms.err
+}
+
+func (ms *mockService) Nearby(
+	_ context.Context, point domain.Location, radiusKm float64, limit int,
+) ([]domain.NearbyPort, error) {
+	ms.point, ms.radius, ms.limit = point, radiusKm, limit
+	return ms.nearby, ms.err
+}
+
+func (ms *mockService) SearchByName(_ context.Context, query string, limit int) ([]domain.MatchedPort, error) {
+	ms.query, ms.limit = query, limit
+	return ms.matched, ms.err
+}
+
+func (ms *mockService) WithinBox(_ context.Context, box domain.BoundingBox, fn func(*domain.Port) error) error {
+	ms.box = box
+	if ms.err != nil {
+		return ms.err
+	}
+	for _, p := range ms.inBox {
+		if err := fn(p); err != nil {
+			return err
+		}
+	}
+	return ms.errStream
+}
+
+func (ms *mockService) Delete(ctx context.Context, id string, expectedRevision int64) error {
+	ms.deleted = id
+	ms.revision = expectedRevision
+	ms.source = domain.SourceFromContext(ctx)
+	return ms.err
+}
+
+func (ms *mockService) Save(ctx context.Context, p *domain.Port, expectedRevision int64) (*domain.Port, error) {
+	ms.saved = p
+	ms.revision = expectedRevision
+	ms.source = domain.SourceFromContext(ctx)
+	if ms.errSave != nil {
+		return nil, ms.errSave
+	}
+	return ms.stored, nil
+}
+
+func (ms *mockService) History(_ context.Context, id string) ([]domain.PortChange, error) {
+	return ms.history, ms.err
+}
+
+func (ms *mockService) Get(_ context.Context, id string) (*domain.Port, error) {
 	return ms.port, ms.err
 }
 
@@ -76,6 +185,22 @@ var examplesGet = []struct {
 		id:         "AEAJM",
 		port:       nil,
 	},
+	{
+		name:       "Deadline exceeded",
+		errService: context.DeadlineExceeded,
+		errLogged:  nil,
+		status:     http.StatusGatewayTimeout,
+		id:         "AEAJM",
+		port:       nil,
+	},
+	{
+		name:       "Invalid port",
+		errService: &domain.ValidationError{Violations: []domain.FieldViolation{{Field: "id", Description: "invalid"}}},
+		errLogged:  nil,
+		status:     http.StatusUnprocessableEntity,
+		id:         "aeajm",
+		port:       nil,
+	},
 }
 
 func TestGet(t *testing.T) {
@@ -106,7 +231,847 @@ func TestGet(t *testing.T) {
 			}
 
 			if ex.port != nil {
-				expct.JSON().Object().Equal(ex.port)
+				expct.JSON().Object().Equal(rendered(ex.port))
+			}
+			var verr *domain.ValidationError
+			if errors.As(ex.errService, &verr) {
+				expct.JSON().Object().ValueEqual("violations", verr.Violations)
+			}
+		})
+	}
+}
+
+var examplesGetAsOf = []struct {
+	name   string
+	query  string
+	status int
+	asOf   time.Time
+}{
+	{
+		name:   "As of",
+		query:  "as_of=2026-01-01T00:00:00Z",
+		status: http.StatusOK,
+		asOf:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+	},
+	{
+		name:   "Current",
+		query:  "",
+		status: http.StatusOK,
+	},
+	{
+		name:   "Invalid as of",
+		query:  "as_of=yesterday",
+		status: http.StatusBadRequest,
+	},
+}
+
+func TestGetAsOf(t *testing.T) {
+	ms := &mockService{port: &domain.Port{ID: "AEAJM"}}
+	server := httptest.NewServer(httpserver.New(ms, zap.NewNop()))
+	defer server.Close()
+
+	e := httpexpect.New(t, server.URL)
+
+	for _, ex := range examplesGetAsOf {
+		ms.asOf = time.Time{}
+		t.Run(ex.name, func(t *testing.T) {
+			e.GET("/ports/AEAJM").WithQueryString(ex.query).Expect().Status(ex.status)
+			assert.True(t, ex.asOf.Equal(ms.asOf), "Should get port as of requested time")
+		})
+	}
+}
+
+func TestDeleteInvalidIfMatch(t *testing.T) {
+	ms := &mockService{}
+	server := httptest.NewServer(httpserver.New(ms, zap.NewNop()))
+	defer server.Close()
+
+	e := httpexpect.New(t, server.URL)
+	for _, tag := range []string{`W/"3"`, "3", `"foo"`} {
+		e.DELETE("/ports/AEAJM").WithHeader("If-Match", tag).Expect().Status(http.StatusPreconditionFailed)
+	}
+	assert.Equal(t, "", ms.deleted, "Should not delete port if entity tag can not match")
+}
+
+var examplesETag = []struct {
+	name        string
+	ifNoneMatch string
+	status      int
+}{
+	{
+		name:   "No condition",
+		status: http.StatusOK,
+	},
+	{
+		name:        "Not modified",
+		ifNoneMatch: `"1", "2"`,
+		status:      http.StatusNotModified,
+	},
+	{
+		name:        "Not modified weak",
+		ifNoneMatch: `W/"2"`,
+		status:      http.StatusNotModified,
+	},
+	{
+		name:        "Not modified any",
+		ifNoneMatch: "*",
+		status:      http.StatusNotModified,
+	},
+	{
+		name:        "Modified",
+		ifNoneMatch: `"1"`,
+		status:      http.StatusOK,
+	},
+}
+
+func TestGetETag(t *testing.T) {
+	ms := &mockService{port: &domain.Port{ID: "AEAJM", Revision: 2}}
+	server := httptest.NewServer(httpserver.New(ms, zap.NewNop()))
+	defer server.Close()
+
+	e := httpexpect.New(t, server.URL)
+
+	for _, ex := range examplesETag {
+		t.Run(ex.name, func(t *testing.T) {
+			req := e.GET("/ports/AEAJM")
+			if ex.ifNoneMatch != "" {
+				req = req.WithHeader("If-None-Match", ex.ifNoneMatch)
+			}
+			resp := req.Expect().Status(ex.status)
+			resp.Header("ETag").Equal(`"2"`)
+			if ex.status == http.StatusNotModified {
+				resp.Body().Empty()
+			}
+		})
+	}
+}
+
+var examplesCoordinates = []struct {
+	name     string
+	order    domain.CoordinateOrder
+	expected interface{}
+}{
+	{
+		name:     "Default",
+		expected: []interface{}{55.5, 25.4},
+	},
+	{
+		name:     "Lat lon",
+		order:    domain.LatLon,
+		expected: []interface{}{25.4, 55.5},
+	},
+	{
+		name:     "Lon lat",
+		order:    domain.LonLat,
+		expected: []interface{}{55.5, 25.4},
+	},
+}
+
+func TestGetCoordinates(t *testing.T) {
+	ms := &mockService{port: &domain.Port{ID: "AEAJM", Coordinates: domain.Location{Latitude: 25.4, Longitude: 55.5}}}
+
+	for _, ex := range examplesCoordinates {
+		t.Run(ex.name, func(t *testing.T) {
+			var opts []httpserver.Option
+			if ex.order != "" {
+				opts = append(opts, httpserver.Coordinates(ex.order))
+			}
+			server := httptest.NewServer(httpserver.New(ms, zap.NewNop(), opts...))
+			defer server.Close()
+
+			e := httpexpect.New(t, server.URL)
+			e.GET("/ports/AEAJM").Expect().Status(http.StatusOK).
+				JSON().Object().ValueEqual("coordinates", ex.expected)
+		})
+	}
+}
+
+var examplesDelete = []struct {
+	name       string
+	status     int
+	errService error
+	ifMatch    string
+	revision   int64
+}{
+	{
+		name:       "No error",
+		errService: nil,
+		status:     http.StatusNoContent,
+	},
+	{
+		name:     "If match",
+		ifMatch:  `"3"`,
+		status:   http.StatusNoContent,
+		revision: 3,
+	},
+	{
+		name:     "If match any",
+		ifMatch:  "*",
+		status:   http.StatusNoContent,
+		revision: 0,
+	},
+	{
+		name:       "Revision mismatch",
+		ifMatch:    `"3"`,
+		errService: domain.ErrRevisionMismatch,
+		status:     http.StatusPreconditionFailed,
+		revision:   3,
+	},
+	{
+		name:       "No port",
+		errService: domain.ErrNotFound,
+		status:     http.StatusNotFound,
+	},
+	{
+		name:       "Test error",
+		errService: errFoo,
+		status:     http.StatusInternalServerError,
+	},
+}
+
+func TestDelete(t *testing.T) {
+	ms := &mockService{}
+	handler := httpserver.New(ms, zap.NewNop())
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.New(t, server.URL)
+
+	for _, ex := range examplesDelete {
+		ms.err = ex.errService
+		ms.deleted = ""
+
+		t.Run(ex.name, func(t *testing.T) {
+			req := e.DELETE("/ports/AEAJM")
+			if ex.ifMatch != "" {
+				req = req.WithHeader("If-Match", ex.ifMatch)
+			}
+			expct := req.Expect().Status(ex.status)
+			assert.Equal(t, "AEAJM", ms.deleted, "Should delete requested port")
+			assert.Equal(t, ex.revision, ms.revision, "Should delete port with revision of If-Match")
+			assert.True(t, strings.HasPrefix(ms.source, "http:") && len(ms.source) > len("http:"),
+				"Should tag change with request id")
+			if ex.errService != nil {
+				expct.JSON().Object().ValueEqual("message", http.StatusText(ex.status))
+			} else {
+				expct.NoContent()
+			}
+		})
+	}
+}
+
+var storedPort = &domain.Port{
+	ID:          "AEAJM",
+	Name:        "Ajman",
+	Country:     "United Arab Emirates",
+	Alias:       domain.StringArray{"Ajman Port"},
+	Coordinates: domain.Location{Latitude: 25.4052165, Longitude: 55.5136433},
+	Timezone:    "Asia/Dubai",
+	Unlocs:      domain.StringArray{"AEAJM"},
+	Revision:    2,
+}
+
+// savedPort is storedPort as it is returned by save, it differs from the one returned by get.
+var savedPort = &domain.Port{
+	ID:          "AEAJM",
+	Name:        "Ajman",
+	Country:     "United Arab Emirates",
+	Coordinates: domain.Location{Latitude: 25.4052165, Longitude: 55.5136433},
+	Timezone:    "Asia/Dubai",
+	Revision:    3,
+}
+
+var examplesPut = []struct {
+	name        string
+	body        string
+	contentType string
+	ifMatch     string
+	errSave     error
+	stored      *domain.Port
+	status      int
+	saved       *domain.Port
+	revision    int64
+}{
+	{
+		name:   "No error",
+		body:   `{"name": "Ajman", "coordinates": {"lat": 25.4052165, "lon": 55.5136433}, "timezone": "Asia/Dubai"}`,
+		status: http.StatusOK,
+		saved: &domain.Port{
+			ID: "AEAJM", Name: "Ajman", Timezone: "Asia/Dubai",
+			Coordinates: domain.Location{Latitude: 25.4052165, Longitude: 55.5136433},
+		},
+	},
+	{
+		name:   "Created",
+		body:   `{"name": "Ajman"}`,
+		stored: &domain.Port{ID: "AEAJM", Name: "Ajman", Revision: 1},
+		status: http.StatusCreated,
+		saved:  &domain.Port{ID: "AEAJM", Name: "Ajman"},
+	},
+	{
+		name:     "If match",
+		body:     `{"id": "AEAJM", "name": "Ajman"}`,
+		ifMatch:  `"2"`,
+		status:   http.StatusOK,
+		saved:    &domain.Port{ID: "AEAJM", Name: "Ajman"},
+		revision: 2,
+	},
+	{
+		name:     "Revision mismatch",
+		body:     `{"name": "Ajman"}`,
+		ifMatch:  `"1"`,
+		errSave:  domain.ErrRevisionMismatch,
+		status:   http.StatusPreconditionFailed,
+		saved:    &domain.Port{ID: "AEAJM", Name: "Ajman"},
+		revision: 1,
+	},
+	{
+		name:    "Invalid port",
+		body:    `{"name": ""}`,
+		errSave: &domain.ValidationError{Violations: []domain.FieldViolation{{Field: "name"}}},
+		status:  http.StatusUnprocessableEntity,
+		saved:   &domain.Port{ID: "AEAJM"},
+	},
+	{
+		name:   "Other id",
+		body:   `{"id": "ZAPLZ", "name": "Port Elizabeth"}`,
+		status: http.StatusBadRequest,
+	},
+	{
+		name:   "Invalid json",
+		body:   `{"name": `,
+		status: http.StatusBadRequest,
+	},
+	{
+		name:   "Coordinates array",
+		body:   `{"name": "Ajman", "coordinates": [55.5136433, 25.4052165]}`,
+		status: http.StatusOK,
+		saved: &domain.Port{
+			ID: "AEAJM", Name: "Ajman", Coordinates: domain.Location{Latitude: 25.4052165, Longitude: 55.5136433},
+		},
+	},
+	{
+		name:        "Merge patch",
+		body:        `{"name": "Ajman"}`,
+		contentType: "application/merge-patch+json",
+		status:      http.StatusUnsupportedMediaType,
+	},
+}
+
+func TestPut(t *testing.T) {
+	ms := &mockService{port: storedPort}
+	handler := httpserver.New(ms, zap.NewNop())
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.New(t, server.URL)
+
+	for _, ex := range examplesPut {
+		ms.errSave, ms.stored = ex.errSave, ex.stored
+		if ms.stored == nil {
+			ms.stored = savedPort
+		}
+		ms.saved, ms.revision = nil, 0
+
+		t.Run(ex.name, func(t *testing.T) {
+			contentType := ex.contentType
+			if contentType == "" {
+				contentType = "application/json"
+			}
+			req := e.PUT("/ports/AEAJM").WithHeader("Content-Type", contentType).WithText(ex.body)
+			if ex.ifMatch != "" {
+				req = req.WithHeader("If-Match", ex.ifMatch)
+			}
+			expct := req.Expect().Status(ex.status)
+			assert.Equal(t, ex.saved, ms.saved, "Should save port of request body")
+			assert.Equal(t, ex.revision, ms.revision, "Should save port with revision of If-Match")
+			if ex.status != http.StatusOK && ex.status != http.StatusCreated {
+				expct.JSON().Object().ValueEqual("message", http.StatusText(ex.status))
+				return
+			}
+			assert.True(t, strings.HasPrefix(ms.source, "http:"), "Should tag change with request id")
+			expct.Header("ETag").Equal(fmt.Sprintf(`"%d"`, ms.stored.Revision))
+			expct.JSON().Object().Equal(rendered(ms.stored))
+		})
+	}
+}
+
+var examplesPatch = []struct {
+	name       string
+	body       string
+	ifMatch    string
+	errService error
+	errSave    error
+	status     int
+	saved      *domain.Port
+}{
+	{
+		name:   "Change timezone",
+		body:   `{"timezone": "Asia/Muscat", "revision": 7}`,
+		status: http.StatusOK,
+		saved: &domain.Port{
+			ID: "AEAJM", Name: "Ajman", Country: "United Arab Emirates", Alias: domain.StringArray{"Ajman Port"},
+			Coordinates: domain.Location{Latitude: 25.4052165, Longitude: 55.5136433},
+			Timezone:    "Asia/Muscat", Unlocs: domain.StringArray{"AEAJM"}, Revision: 7,
+		},
+	},
+	{
+		name:    "Remove alias and move",
+		body:    `{"alias": null, "coordinates": [55.5136433, 25.4]}`,
+		ifMatch: `"2"`,
+		status:  http.StatusOK,
+		saved: &domain.Port{
+			ID: "AEAJM", Name: "Ajman", Country: "United Arab Emirates",
+			Coordinates: domain.Location{Latitude: 25.4, Longitude: 55.5136433},
+			Timezone:    "Asia/Dubai", Unlocs: domain.StringArray{"AEAJM"}, Revision: 2,
+		},
+	},
+	{
+		name:    "Changed since read",
+		body:    `{"name": "Ajman"}`,
+		errSave: domain.ErrRevisionMismatch,
+		status:  http.StatusPreconditionFailed,
+		saved: &domain.Port{
+			ID: "AEAJM", Name: "Ajman", Country: "United Arab Emirates", Alias: domain.StringArray{"Ajman Port"},
+			Coordinates: domain.Location{Latitude: 25.4052165, Longitude: 55.5136433},
+			Timezone:    "Asia/Dubai", Unlocs: domain.StringArray{"AEAJM"}, Revision: 2,
+		},
+	},
+	{
+		name:    "If match other revision",
+		body:    `{"name": "Ajman"}`,
+		ifMatch: `"1"`,
+		status:  http.StatusPreconditionFailed,
+	},
+	{
+		name:       "Not found",
+		body:       `{"name": "Ajman"}`,
+		errService: domain.ErrNotFound,
+		status:     http.StatusNotFound,
+	},
+	{
+		name:   "Change id",
+		body:   `{"id": "ZAPLZ"}`,
+		status: http.StatusBadRequest,
+	},
+	{
+		name:   "Not an object",
+		body:   `["name"]`,
+		status: http.StatusBadRequest,
+	},
+	{
+		name:   "Invalid value",
+		body:   `{"alias": "Ajman Port"}`,
+		status: http.StatusBadRequest,
+	},
+}
+
+func TestPatch(t *testing.T) {
+	ms := &mockService{port: storedPort, stored: savedPort}
+	handler := httpserver.New(ms, zap.NewNop())
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.New(t, server.URL)
+
+	for _, ex := range examplesPatch {
+		ms.err, ms.errSave = ex.errService, ex.errSave
+		ms.saved, ms.revision = nil, 0
+
+		t.Run(ex.name, func(t *testing.T) {
+			req := e.PATCH("/ports/AEAJM").WithHeader("Content-Type", "application/merge-patch+json").WithText(ex.body)
+			if ex.ifMatch != "" {
+				req = req.WithHeader("If-Match", ex.ifMatch)
+			}
+			expct := req.Expect().Status(ex.status)
+			assert.Equal(t, ex.saved, ms.saved, "Should save patched port")
+			if ex.saved != nil {
+				assert.Equal(t, int64(2), ms.revision, "Should save port only if it was not changed since read")
+			}
+			if ex.status != http.StatusOK {
+				expct.JSON().Object().ValueEqual("message", http.StatusText(ex.status))
+				return
+			}
+			expct.Header("ETag").Equal(`"3"`)
+			expct.JSON().Object().Equal(rendered(savedPort))
+		})
+	}
+	assert.Equal(t, "Asia/Dubai", storedPort.Timezone, "Should not modify port read from service")
+}
+
+func TestPatchCoordinateOrder(t *testing.T) {
+	ms := &mockService{port: storedPort, stored: savedPort}
+	server := httptest.NewServer(httpserver.New(ms, zap.NewNop(), httpserver.Coordinates(domain.LatLon)))
+	defer server.Close()
+
+	httpexpect.New(t, server.URL).PATCH("/ports/AEAJM").WithJSON(map[string]interface{}{
+		"coordinates": []float64{25.4, 55.5},
+	}).Expect().Status(http.StatusOK)
+	if assert.NotNil(t, ms.saved, "Should save patched port") {
+		assert.Equal(t, domain.Location{Latitude: 25.4, Longitude: 55.5}, ms.saved.Coordinates,
+			"Should read coordinates in configured order")
+	}
+}
+
+var examplesLookup = []struct {
+	name       string
+	path       string
+	key        string
+	errService error
+	status     int
+}{
+	{
+		name:   "By unlocode",
+		path:   "/ports/by-unlocode/{key}",
+		key:    "AEQIW",
+		status: http.StatusOK,
+	},
+	{
+		name:       "Unknown unlocode",
+		path:       "/ports/by-unlocode/{key}",
+		key:        "ZAPLZ",
+		errService: domain.ErrNotFound,
+		status:     http.StatusNotFound,
+	},
+	{
+		name:   "By alias",
+		path:   "/ports/by-alias/{key}",
+		key:    "Ajman Port",
+		status: http.StatusOK,
+	},
+	{
+		name: "Ambiguous alias",
+		path: "/ports/by-alias/{key}",
+		key:  "Ajman Port",
+		errService: &domain.AmbiguousError{Candidates: []*domain.Port{
+			{ID: "AEAJM", Name: "Ajman"}, {ID: "AESHJ", Name: "Sharjah"},
+		}},
+		status: http.StatusMultipleChoices,
+	},
+	{
+		name:       "Empty alias",
+		path:       "/ports/by-alias/{key}",
+		key:        " ",
+		errService: service.ErrInvalidInput,
+		status:     http.StatusBadRequest,
+	},
+}
+
+func TestLookup(t *testing.T) {
+	ms := &mockService{}
+	handler := httpserver.New(ms, zap.NewNop())
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.New(t, server.URL)
+
+	for _, ex := range examplesLookup {
+		ms.port = &domain.Port{ID: "AEAJM", Name: "Ajman", Revision: 3}
+		ms.err = ex.errService
+		ms.key = ""
+
+		t.Run(ex.name, func(t *testing.T) {
+			expct := e.GET(ex.path, ex.key).Expect().Status(ex.status)
+			assert.Equal(t, ex.key, ms.key, "Should look port up by path parameter")
+			var aerr *domain.AmbiguousError
+			switch {
+			case errors.As(ex.errService, &aerr):
+				obj := expct.JSON().Object()
+				obj.ValueEqual("message", http.StatusText(ex.status))
+				obj.ValueEqual("candidates", rendered(aerr.Candidates))
+			case ex.errService != nil:
+				expct.JSON().Object().ValueEqual("message", http.StatusText(ex.status))
+			default:
+				expct.Header("ETag").Equal(`"3"`)
+				expct.JSON().Object().Equal(rendered(ms.port))
+			}
+		})
+	}
+}
+
+func TestLookupEscapedSlash(t *testing.T) {
+	ms := &mockService{port: &domain.Port{ID: "AERKT"}}
+	server := httptest.NewServer(httpserver.New(ms, zap.NewNop()))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/ports/by-alias/Ras%2FKhaimah")
+	if assert.Nil(t, err, "Should get response with no error") {
+		resp.Body.Close()
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "Should find port by alias with slash")
+	}
+	assert.Equal(t, "Ras/Khaimah", ms.key, "Should unescape path parameter")
+}
+
+var examplesSearch = []struct {
+	name       string
+	query      string
+	status     int
+	errService error
+	q          string
+	limit      int
+	matched    []domain.MatchedPort
+}{
+	{
+		name:    "No error",
+		query:   "q=rotter&limit=10",
+		status:  http.StatusOK,
+		q:       "rotter",
+		limit:   10,
+		matched: []domain.MatchedPort{{Port: domain.Port{ID: "NLRTM", Name: "Rotterdam"}, Score: 1.35}},
+	},
+	{
+		name:       "Missing query",
+		query:      "limit=10",
+		status:     http.StatusBadRequest,
+		errService: service.ErrInvalidQuery,
+		limit:      10,
+	},
+	{
+		name:   "Invalid limit",
+		query:  "q=rotter&limit=ten",
+		status: http.StatusBadRequest,
+	},
+}
+
+func TestSearch(t *testing.T) {
+	ms := &mockService{}
+	handler := httpserver.New(ms, zap.NewNop())
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.New(t, server.URL)
+
+	for _, ex := range examplesSearch {
+		ms.err = ex.errService
+		ms.matched = ex.matched
+		ms.query, ms.limit = "", 0
+
+		t.Run(ex.name, func(t *testing.T) {
+			expct := e.GET("/ports/search").WithQueryString(ex.query).Expect().Status(ex.status)
+			assert.Equal(t, ex.q, ms.query, "Should pass query to service")
+			assert.Equal(t, ex.limit, ms.limit, "Should pass limit to service")
+			if ex.status == http.StatusOK {
+				expct.JSON().Array().Equal(rendered(ex.matched))
+			} else {
+				expct.JSON().Object().ValueEqual("message", http.StatusText(ex.status))
+			}
+		})
+	}
+}
+
+var examplesHistory = []struct {
+	name       string
+	status     int
+	errService error
+	history    []domain.PortChange
+}{
+	{
+		name:   "No error",
+		status: http.StatusOK,
+		history: []domain.PortChange{
+			{Sequence: 1, Op: domain.PortCreated, Source: "file:ports.json", ChangedAt: time.Unix(0, 0).UTC()},
+			{
+				Sequence: 2, Op: domain.PortDeleted, Previous: &domain.Port{ID: "AEAJM", Name: "Ajman"},
+				Source: "http:req-1", ChangedAt: time.Unix(60, 0).UTC(),
+			},
+		},
+	},
+	{
+		name:       "No port",
+		errService: domain.ErrNotFound,
+		status:     http.StatusNotFound,
+	},
+	{
+		name:       "Test error",
+		errService: errFoo,
+		status:     http.StatusInternalServerError,
+	},
+}
+
+func TestHistory(t *testing.T) {
+	ms := &mockService{}
+	handler := httpserver.New(ms, zap.NewNop())
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.New(t, server.URL)
+
+	for _, ex := range examplesHistory {
+		ms.err = ex.errService
+		ms.history = ex.history
+
+		t.Run(ex.name, func(t *testing.T) {
+			expct := e.GET("/ports/AEAJM/history").Expect().Status(ex.status)
+			if ex.errService != nil {
+				expct.JSON().Object().ValueEqual("message", http.StatusText(ex.status))
+				return
+			}
+			changes := expct.JSON().Array()
+			changes.Length().Equal(2)
+			changes.Element(0).Object().
+				ValueEqual("sequence", 1).
+				ValueEqual("op", "created").
+				ValueEqual("source", "file:ports.json").
+				ValueEqual("changed_at", "1970-01-01T00:00:00Z").
+				Value("previous").Null()
+			changes.Element(1).Object().Value("previous").Object().ValueEqual("name", "Ajman")
+		})
+	}
+}
+
+var examplesList = []struct {
+	name       string
+	query      string
+	status     int
+	errService error
+	cursor     string
+	limit      int
+	filter     domain.PortFilter
+	page       domain.Page
+}{
+	{
+		name:   "No error",
+		query:  "limit=2&cursor=AEAJM",
+		status: http.StatusOK,
+		cursor: "AEAJM",
+		limit:  2,
+		page:   domain.Page{Ports: []*domain.Port{{ID: "AEAJN"}, {ID: "ZAPLZ"}}, NextCursor: "ZAPLZ"},
+	},
+	{
+		name:   "No query",
+		query:  "",
+		status: http.StatusOK,
+		page:   domain.Page{Ports: []*domain.Port{}},
+	},
+	{
+		name:   "Invalid limit",
+		query:  "limit=foo",
+		status: http.StatusBadRequest,
+	},
+	{
+		name:       "Limit rejected",
+		query:      "limit=100000",
+		status:     http.StatusBadRequest,
+		errService: service.ErrInvalidLimit,
+		limit:      100000,
+	},
+	{
+		name:   "Filter",
+		query:  "country=Belgium&province=Antwerp&city=Antwerp&region=Europe&timezone=Europe/Brussels",
+		status: http.StatusOK,
+		filter: domain.PortFilter{
+			Country: "Belgium", Province: "Antwerp", City: "Antwerp", Region: "Europe", Timezone: "Europe/Brussels",
+		},
+		page: domain.Page{Ports: []*domain.Port{{ID: "BEANR"}}},
+	},
+	{
+		name:       "Invalid filter",
+		query:      "city=x",
+		status:     http.StatusBadRequest,
+		errService: service.ErrInvalidFilter,
+		filter:     domain.PortFilter{City: "x"},
+	},
+}
+
+func TestList(t *testing.T) {
+	ms := &mockService{}
+	handler := httpserver.New(ms, zap.NewNop())
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.New(t, server.URL)
+
+	for _, ex := range examplesList {
+		ms.err = ex.errService
+		ms.page = ex.page
+		ms.filter, ms.cursor, ms.limit = domain.PortFilter{}, "", 0
+
+		t.Run(ex.name, func(t *testing.T) {
+			expct := e.GET("/ports").WithQueryString(ex.query).Expect().Status(ex.status)
+			assert.Equal(t, ex.cursor, ms.cursor, "Should pass cursor to service")
+			assert.Equal(t, ex.limit, ms.limit, "Should pass limit to service")
+			assert.Equal(t, ex.filter, ms.filter, "Should pass filter to service")
+			if ex.status == http.StatusOK {
+				expct.JSON().Object().Equal(rendered(ex.page))
+			} else {
+				expct.JSON().Object().ValueEqual("message", http.StatusText(ex.status))
+			}
+		})
+	}
+}
+
+var examplesNearby = []struct {
+	name       string
+	query      string
+	status     int
+	errService error
+	point      domain.Location
+	radius     float64
+	limit      int
+	nearby     []domain.NearbyPort
+}{
+	{
+		name:   "No error",
+		query:  "lat=51.2&lon=4.4&radius_km=25&limit=3",
+		status: http.StatusOK,
+		point:  domain.Location{Latitude: 51.2, Longitude: 4.4},
+		radius: 25,
+		limit:  3,
+		nearby: []domain.NearbyPort{{Port: domain.Port{ID: "BEANR"}, DistanceKm: 2.5}},
+	},
+	{
+		name:   "Only point",
+		query:  "lat=-33.9&lon=18.4",
+		status: http.StatusOK,
+		point:  domain.Location{Latitude: -33.9, Longitude: 18.4},
+		nearby: []domain.NearbyPort{},
+	},
+	{
+		name:   "Missing lon",
+		query:  "lat=51.2",
+		status: http.StatusBadRequest,
+	},
+	{
+		name:   "Invalid radius",
+		query:  "lat=51.2&lon=4.4&radius_km=far",
+		status: http.StatusBadRequest,
+	},
+	{
+		name:   "NaN lat",
+		query:  "lat=NaN&lon=4.4",
+		status: http.StatusBadRequest,
+	},
+	{
+		name:       "Point rejected",
+		query:      "lat=95&lon=4.4",
+		status:     http.StatusBadRequest,
+		errService: service.ErrInvalidPoint,
+		point:      domain.Location{Latitude: 95, Longitude: 4.4},
+	},
+}
+
+func TestNearby(t *testing.T) {
+	ms := &mockService{}
+	handler := httpserver.New(ms, zap.NewNop())
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.New(t, server.URL)
+
+	for _, ex := range examplesNearby {
+		ms.err = ex.errService
+		ms.nearby = ex.nearby
+		ms.point, ms.radius, ms.limit = domain.Location{}, 0, 0
+
+		t.Run(ex.name, func(t *testing.T) {
+			expct := e.GET("/ports/nearby").WithQueryString(ex.query).Expect().Status(ex.status)
+			assert.Equal(t, ex.point, ms.point, "Should pass point to service")
+			assert.Equal(t, ex.radius, ms.radius, "Should pass radius to service")
+			assert.Equal(t, ex.limit, ms.limit, "Should pass limit to service")
+			if ex.status == http.StatusOK {
+				expct.JSON().Array().Equal(rendered(ex.nearby))
+			} else {
+				expct.JSON().Object().ValueEqual("message", http.StatusText(ex.status))
 			}
 		})
 	}
@@ -0,0 +1,166 @@
+package httpserver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/sp4rd4/ports/pkg/domain"
+	"go.uber.org/zap"
+)
+
+const maxBodyBytes = 1 << 20
+
+var (
+	errInvalidBody          = errors.New("invalid request body")
+	errUnsupportedMediaType = errors.New("unsupported media type")
+)
+
+// Put replaces port with the one from request body, id of the path is used if body has none.
+// It responds with 201 if port was created.
+func (pc *Ports) Put(w http.ResponseWriter, r *http.Request) {
+	reqID := middleware.GetReqID(r.Context())
+	portID := chi.URLParam(r, "portID")
+	rLog := pc.logger.With(zap.String("reqId", reqID), zap.String("portId", portID))
+
+	var body []byte
+	port := &domain.Port{}
+	revision, err := ifMatch(r)
+	if err == nil {
+		body, err = readBody(w, r)
+	}
+	if err == nil {
+		err = pc.json.Unmarshal(body, port)
+		if err != nil {
+			err = fmt.Errorf("[%v] put: %v: %w", errorTag, err, errInvalidBody)
+		}
+	}
+	var stored *domain.Port
+	if err == nil {
+		stored, err = pc.save(r, portID, port, revision)
+	}
+	status := http.StatusOK
+	if err == nil && stored.Revision == 1 {
+		status = http.StatusCreated
+	}
+	pc.renderSaved(w, status, stored, err, rLog)
+}
+
+// Patch applies JSON Merge Patch of request body to stored port. Port is saved only if it was not
+// changed since it was read, so concurrent writes are not lost.
+func (pc *Ports) Patch(w http.ResponseWriter, r *http.Request) {
+	reqID := middleware.GetReqID(r.Context())
+	portID := chi.URLParam(r, "portID")
+	rLog := pc.logger.With(zap.String("reqId", reqID), zap.String("portId", portID))
+
+	var (
+		body    []byte
+		current *domain.Port
+	)
+	revision, err := ifMatch(r)
+	if err == nil {
+		body, err = readBody(w, r)
+	}
+	if err == nil {
+		current, err = pc.service.Get(r.Context(), portID)
+	}
+	if err == nil && revision != 0 && revision != current.Revision {
+		err = fmt.Errorf("[%v] patch: %w", errorTag, domain.ErrRevisionMismatch)
+	}
+	port := &domain.Port{}
+	if err == nil {
+		err = pc.applyPatch(current, body, port)
+	}
+	var stored *domain.Port
+	if err == nil {
+		stored, err = pc.save(r, portID, port, current.Revision)
+	}
+	pc.renderSaved(w, http.StatusOK, stored, err, rLog)
+}
+
+// readBody reads JSON body, PATCH also accepts application/merge-patch+json.
+func readBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		mediaType, _, err := mime.ParseMediaType(ct)
+		if err != nil || !(mediaType == "application/json" ||
+			r.Method == http.MethodPatch && mediaType == "application/merge-patch+json") {
+			return nil, fmt.Errorf("[%v] read body: %s: %w", errorTag, ct, errUnsupportedMediaType)
+		}
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		return nil, fmt.Errorf("[%v] read body: %v: %w", errorTag, err, errInvalidBody)
+	}
+	return body, nil
+}
+
+// applyPatch merges patch into port rendered the same way as it is returned by Get and decodes result into dst.
+// Generic documents are handled by encoding/json, ports by configured codec to keep coordinates order.
+func (pc *Ports) applyPatch(port *domain.Port, body []byte, dst *domain.Port) error {
+	patch := map[string]interface{}{}
+	if err := json.Unmarshal(body, &patch); err != nil {
+		return fmt.Errorf("[%v] patch: %v: %w", errorTag, err, errInvalidBody)
+	}
+	b, err := pc.json.Marshal(port)
+	if err != nil {
+		return fmt.Errorf("[%v] patch: %w", errorTag, err)
+	}
+	doc := map[string]interface{}{}
+	if err = json.Unmarshal(b, &doc); err != nil {
+		return fmt.Errorf("[%v] patch: %w", errorTag, err)
+	}
+	if b, err = json.Marshal(mergePatch(doc, patch)); err != nil {
+		return fmt.Errorf("[%v] patch: %w", errorTag, err)
+	}
+	if err = pc.json.Unmarshal(b, dst); err != nil {
+		return fmt.Errorf("[%v] patch: %v: %w", errorTag, err, errInvalidBody)
+	}
+	return nil
+}
+
+// mergePatch applies JSON Merge Patch (RFC 7396), null removes member and objects are merged recursively.
+func mergePatch(target, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = map[string]interface{}{}
+	}
+	for k, v := range p {
+		if v == nil {
+			delete(t, k)
+			continue
+		}
+		t[k] = mergePatch(t[k], v)
+	}
+	return t
+}
+
+// save stores port under id of the path, id of port can not be changed by body.
+func (pc *Ports) save(r *http.Request, portID string, port *domain.Port, revision int64) (*domain.Port, error) {
+	if port.ID != "" && port.ID != portID {
+		return nil, fmt.Errorf("[%v] save: id %q does not match path: %w", errorTag, port.ID, errInvalidBody)
+	}
+	port.ID = portID
+	return pc.service.Save(r.Context(), port, revision)
+}
+
+// renderSaved responds with port as it was stored by the request, with revision assigned by repository.
+func (pc *Ports) renderSaved(w http.ResponseWriter, status int, port *domain.Port, err error, rLog *zap.Logger) {
+	if err == nil {
+		w.Header().Set("ETag", etag(port.Revision))
+		err = pc.renderData(w, status, port)
+	} else {
+		err = pc.renderError(err, w, rLog)
+	}
+	if err != nil {
+		rLog.Error(fmt.Errorf("[%v] render error: %w", errorTag, err).Error())
+	}
+}
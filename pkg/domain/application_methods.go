@@ -16,19 +16,23 @@ var (
 	ErrUnmarshal     = errors.New("byte unmarshal failed")
 )
 
-// MarshalJSON implements custom marshal.
+// MarshalJSON implements custom marshal, location is an object so order of coordinates is explicit.
 func (l Location) MarshalJSON() ([]byte, error) {
-	return json.Marshal([2]float64{l.Latitude, l.Longitude})
+	return json.Marshal(locationObject{Lat: l.Latitude, Lon: l.Longitude})
 }
 
-// UnmarshalJSON implements custom unmarshal.
+// UnmarshalJSON implements custom unmarshal, arrays are rejected as their order is ambiguous,
+// use CoordinateOrder.Extension to read them.
 func (l *Location) UnmarshalJSON(b []byte) error {
-	ll := [2]float64{}
+	if len(b) > 0 && b[0] == '[' {
+		return ErrAmbiguousCoordinate
+	}
+	ll := locationObject{}
 	err := json.Unmarshal(b, &ll)
 	if err != nil {
 		return err
 	}
-	l.Latitude, l.Longitude = ll[0], ll[1]
+	l.Latitude, l.Longitude = ll.Lat, ll.Lon
 	return nil
 }
 
@@ -62,3 +66,22 @@ func (a *StringArray) Scan(src interface{}) error {
 func (a StringArray) Value() (driver.Value, error) {
 	return pq.StringArray(a).Value()
 }
+
+// Clone returns deep copy of port, slices are not shared with the original.
+func (p *Port) Clone() *Port {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	c.Alias = p.Alias.clone()
+	c.Regions = p.Regions.clone()
+	c.Unlocs = p.Unlocs.clone()
+	return &c
+}
+
+func (a StringArray) clone() StringArray {
+	if a == nil {
+		return nil
+	}
+	return append(StringArray{}, a...)
+}
@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"unsafe"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/modern-go/reflect2"
+)
+
+// CoordinateOrder is an order of latitude and longitude in coordinates array.
+type CoordinateOrder string
+
+const (
+	LatLon CoordinateOrder = "latlon"
+	// LonLat is GeoJSON order, source ports files use it.
+	LonLat CoordinateOrder = "lonlat"
+)
+
+type locationObject struct {
+	Lat float64 `json:"lat"`
+	Lon float64 `json:"lon"`
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler so order can be read from config.
+func (o *CoordinateOrder) UnmarshalText(text []byte) error {
+	switch order := CoordinateOrder(text); order {
+	case LatLon, LonLat:
+		*o = order
+		return nil
+	default:
+		return fmt.Errorf("%q: %w", text, ErrCoordinateOrder)
+	}
+}
+
+// Array returns location coordinates in order o.
+func (o CoordinateOrder) Array(l Location) [2]float64 {
+	if o == LonLat {
+		return [2]float64{l.Longitude, l.Latitude}
+	}
+	return [2]float64{l.Latitude, l.Longitude}
+}
+
+// Location reads coordinates array in order o.
+func (o CoordinateOrder) Location(a [2]float64) Location {
+	if o == LonLat {
+		return Location{Latitude: a[1], Longitude: a[0]}
+	}
+	return Location{Latitude: a[0], Longitude: a[1]}
+}
+
+// Extension makes jsoniter encode Location as array in order o and decode such arrays,
+// empty array is decoded as zero location and object form is still accepted.
+func (o CoordinateOrder) Extension() jsoniter.Extension {
+	return &coordinatesExtension{codec: locationCodec{order: o}}
+}
+
+// Unmarshal reads coordinates array in order o or object into l the same way as Extension does.
+func (o CoordinateOrder) Unmarshal(b []byte, l *Location) error {
+	iter := json.BorrowIterator(b)
+	defer json.ReturnIterator(iter)
+	locationCodec{order: o}.Decode(unsafe.Pointer(l), iter)
+	if iter.Error != nil && !errors.Is(iter.Error, io.EOF) {
+		return fmt.Errorf("%w: %v", ErrInvalidCoordinates, iter.Error)
+	}
+	return nil
+}
+
+// Validate checks coordinates are within valid ranges.
+func (l Location) Validate() error {
+	if !l.validLatitude() {
+		return ErrInvalidLatitude
+	}
+	if !l.validLongitude() {
+		return ErrInvalidLongitude
+	}
+	return nil
+}
+
+func (l Location) validLatitude() bool {
+	return l.Latitude >= -90 && l.Latitude <= 90
+}
+
+func (l Location) validLongitude() bool {
+	return l.Longitude >= -180 && l.Longitude <= 180
+}
+
+var locationType = reflect.TypeOf(Location{})
+
+type coordinatesExtension struct {
+	jsoniter.DummyExtension
+	codec locationCodec
+}
+
+func (e *coordinatesExtension) CreateEncoder(typ reflect2.Type) jsoniter.ValEncoder {
+	if typ.Type1() == locationType {
+		return e.codec
+	}
+	return nil
+}
+
+func (e *coordinatesExtension) CreateDecoder(typ reflect2.Type) jsoniter.ValDecoder {
+	if typ.Type1() == locationType {
+		return e.codec
+	}
+	return nil
+}
+
+type locationCodec struct {
+	order CoordinateOrder
+}
+
+func (c locationCodec) IsEmpty(unsafe.Pointer) bool {
+	return false
+}
+
+func (c locationCodec) Encode(ptr unsafe.Pointer, stream *jsoniter.Stream) {
+	stream.WriteVal(c.order.Array(*(*Location)(ptr)))
+}
+
+func (c locationCodec) Decode(ptr unsafe.Pointer, iter *jsoniter.Iterator) {
+	l := (*Location)(ptr)
+	switch iter.WhatIsNext() {
+	case jsoniter.ArrayValue:
+		var (
+			a [2]float64
+			n int
+		)
+		iter.ReadArrayCB(func(iter *jsoniter.Iterator) bool {
+			if n < len(a) {
+				a[n] = iter.ReadFloat64()
+			} else {
+				iter.Skip()
+			}
+			n++
+			return true
+		})
+		switch n {
+		case 0:
+			*l = Location{}
+		case len(a):
+			*l = c.order.Location(a)
+		default:
+			iter.ReportError("decode coordinates", "expected array of latitude and longitude")
+		}
+	case jsoniter.ObjectValue:
+		ll := locationObject{}
+		iter.ReadVal(&ll)
+		l.Latitude, l.Longitude = ll.Lat, ll.Lon
+	case jsoniter.NilValue:
+		iter.Skip()
+	default:
+		iter.ReportError("decode coordinates", "expected array or object")
+	}
+}
@@ -1,7 +1,95 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
 
 var (
 	ErrNotFound = errors.New("not found")
+	// ErrRevisionMismatch is returned by conditional writes when port was changed or deleted meanwhile.
+	ErrRevisionMismatch = errors.New("revision mismatch")
+	// ErrEventsPruned is returned by Watch resuming from events which are not kept anymore,
+	// watcher missed some changes and should read ports again.
+	ErrEventsPruned = errors.New("events are pruned")
+	// ErrUnknownField is returned for field names which are not in PortFields.
+	ErrUnknownField = errors.New("unknown port field")
+
+	ErrInvalidLatitude     = errors.New("latitude is out of range")
+	ErrInvalidLongitude    = errors.New("longitude is out of range")
+	ErrCoordinateOrder     = errors.New("unknown coordinate order")
+	ErrAmbiguousCoordinate = errors.New("coordinates array requires explicit order")
+	ErrInvalidCoordinates  = errors.New("coordinates should be array of latitude and longitude or object")
 )
+
+// BatchFailure describes single port of a batch that was not saved.
+type BatchFailure struct {
+	Index int
+	ID    string
+	Err   error
+}
+
+// BatchError is returned by batch operations when some of the items failed,
+// items not listed in Failures were saved successfully.
+type BatchError struct {
+	Failures []BatchFailure
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%d batch items failed", len(e.Failures))
+}
+
+// Add records failure of item at index.
+func (e *BatchError) Add(index int, id string, err error) {
+	e.Failures = append(e.Failures, BatchFailure{Index: index, ID: id, Err: err})
+}
+
+// ErrOrNil returns nil if there are no failures recorded.
+func (e *BatchError) ErrOrNil() error {
+	if e == nil || len(e.Failures) == 0 {
+		return nil
+	}
+	return e
+}
+
+// AmbiguousError is returned by lookups matching several ports, none of them is picked.
+type AmbiguousError struct {
+	Candidates []*Port
+}
+
+func (e *AmbiguousError) Error() string {
+	return fmt.Sprintf("%d ports match", len(e.Candidates))
+}
+
+// FieldViolation describes why value of a single port field is invalid.
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// ValidationError is returned when port fields are invalid, it lists all violations found.
+type ValidationError struct {
+	Violations []FieldViolation
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		fields[i] = v.Field + ": " + v.Description
+	}
+	return "invalid port: " + strings.Join(fields, ", ")
+}
+
+// Add records violation of field.
+func (e *ValidationError) Add(field, description string) {
+	e.Violations = append(e.Violations, FieldViolation{Field: field, Description: description})
+}
+
+// ErrOrNil returns nil if there are no violations recorded.
+func (e *ValidationError) ErrOrNil() error {
+	if e == nil || len(e.Violations) == 0 {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,70 @@
+package domain
+
+import "fmt"
+
+// PortFields are names of port fields which can be written separately, they are the same as json and
+// column names. Id and revision can not be changed.
+var PortFields = []string{
+	"name", "city", "country", "alias", "regions", "coordinates", "province", "timezone", "unlocs", "code",
+}
+
+// CopyFields sets fields of p named by fields to values of src.
+func (p *Port) CopyFields(src *Port, fields []string) error {
+	for _, field := range fields {
+		switch dst := p.field(field).(type) {
+		case *string:
+			*dst = *src.field(field).(*string)
+		case *StringArray:
+			*dst = src.field(field).(*StringArray).clone()
+		case *Location:
+			*dst = *src.field(field).(*Location)
+		default:
+			return fmt.Errorf("%q: %w", field, ErrUnknownField)
+		}
+	}
+	return nil
+}
+
+// FieldValues returns values of p fields named by fields.
+func (p *Port) FieldValues(fields []string) ([]interface{}, error) {
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		switch v := p.field(field).(type) {
+		case *string:
+			values[i] = *v
+		case *StringArray:
+			values[i] = *v
+		case *Location:
+			values[i] = *v
+		default:
+			return nil, fmt.Errorf("%q: %w", field, ErrUnknownField)
+		}
+	}
+	return values, nil
+}
+
+func (p *Port) field(name string) interface{} {
+	switch name {
+	case "name":
+		return &p.Name
+	case "city":
+		return &p.City
+	case "country":
+		return &p.Country
+	case "alias":
+		return &p.Alias
+	case "regions":
+		return &p.Regions
+	case "coordinates":
+		return &p.Coordinates
+	case "province":
+		return &p.Province
+	case "timezone":
+		return &p.Timezone
+	case "unlocs":
+		return &p.Unlocs
+	case "code":
+		return &p.Code
+	}
+	return nil
+}
@@ -1,7 +1,31 @@
 package loader
 
-import "github.com/sp4rd4/ports/pkg/domain"
+import (
+	"fmt"
+
+	"github.com/sp4rd4/ports/pkg/domain"
+)
 
 type Ports interface {
 	Load() <-chan *domain.Port
+	// Err returns error which stopped loading, it is valid after channel returned by Load is closed.
+	Err() error
+	// Skipped returns records skipped because of errors, it is valid after channel returned by Load is closed.
+	Skipped() []ParseError
+}
+
+// ParseError describes record loader failed to parse, Offset is position in the input in bytes
+// and Key is id of the record being parsed if it is known.
+type ParseError struct {
+	Offset int64
+	Key    string
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("parse record %q at byte %d: %v", e.Key, e.Offset, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
 }
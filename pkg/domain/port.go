@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"context"
+	"time"
+)
+
 type StringArray []string
 
 type Port struct {
@@ -14,14 +19,134 @@ type Port struct {
 	Timezone    string      `json:"timezone" db:"timezone"`
 	Unlocs      StringArray `json:"unlocs" db:"unlocs"`
 	Code        string      `json:"code" db:"code"`
+	// Revision is set by repository, it starts from 1 and grows with every update of the port.
+	Revision int64 `json:"revision" db:"revision"`
 }
 
+// MaxLookupCandidates limits ports returned by lookups which can match several of them.
+const MaxLookupCandidates = 20
+
 type Location struct {
 	Latitude  float64
 	Longitude float64
 }
 
+// PortRepository keeps ports by id. Writes with expectedRevision other than 0 fail
+// with ErrRevisionMismatch unless stored port has that revision.
 type PortRepository interface {
-	Save(port *Port) error
-	Get(id string) (*Port, error)
+	// Save upserts port and returns stored one, its Revision is 1 if port was created by the save.
+	Save(ctx context.Context, port *Port, expectedRevision int64) (*Port, error)
+	Get(ctx context.Context, id string) (*Port, error)
+	// ByUnlocode returns ports with id or one of unlocs equal to code, ByAlias ports having alias name.
+	// Up to MaxLookupCandidates ports are returned ordered by id, port having the key as id goes first.
+	// None are returned if nothing matches.
+	ByUnlocode(ctx context.Context, code string) ([]*Port, error)
+	ByAlias(ctx context.Context, name string) ([]*Port, error)
+	// GetAsOf returns port as it was at the moment, ErrNotFound if it did not exist then.
+	GetAsOf(ctx context.Context, id string, at time.Time) (*Port, error)
+	// Update writes only fields of port named by fields, which are PortFields, and returns stored port.
+	// It fails with ErrNotFound if there is no port with such id, expectedRevision other than 0 makes it fail
+	// with ErrRevisionMismatch unless stored port has that revision.
+	Update(ctx context.Context, port *Port, fields []string, expectedRevision int64) (*Port, error)
+	Delete(ctx context.Context, id string, expectedRevision int64) error
+	// List returns up to limit ports matching filter ordered by id starting after cursor, limit should be positive.
+	List(ctx context.Context, filter PortFilter, cursor string, limit int) (Page, error)
+	// Nearby returns up to limit ports within radius from point sorted by distance.
+	Nearby(ctx context.Context, point Location, radiusKm float64, limit int) ([]NearbyPort, error)
+	// SearchByName returns up to limit ports with name, city or alias similar to query, best matches first.
+	SearchByName(ctx context.Context, query string, limit int) ([]MatchedPort, error)
+	// WithinBox calls fn for every port inside box ordered by id, iteration stops on first fn error.
+	WithinBox(ctx context.Context, box BoundingBox, fn func(*Port) error) error
+	// SaveBatch saves ports in bulk unconditionally, per item failures are reported with *BatchError.
+	SaveBatch(ctx context.Context, ports []*Port) (SaveResult, error)
+	// Watch calls fn for every change starting from fromSequence ordered by sequence, 0 means changes
+	// made after the call. It blocks until ctx is done or fn returns error, ErrEventsPruned is returned
+	// if changes from fromSequence are not kept anymore.
+	Watch(ctx context.Context, fromSequence int64, fn func(PortEvent) error) error
+	// History returns changes of port ordered by sequence, ErrNotFound if port was never saved.
+	History(ctx context.Context, id string) ([]PortChange, error)
+}
+
+type EventOp string
+
+const (
+	PortCreated EventOp = "created"
+	PortUpdated EventOp = "updated"
+	PortDeleted EventOp = "deleted"
+)
+
+// PortEvent is a change of port, Sequence grows with every change of any port unlike Port.Revision
+// which counts changes of one port. Port is the last version of port for deleted ones.
+type PortEvent struct {
+	Sequence int64
+	Op       EventOp
+	Port     *Port
+}
+
+// PortChange is a history entry of port, Previous is the port before change, nil for created ones.
+// Source tells who made the change, see WithSource.
+type PortChange struct {
+	Sequence  int64     `json:"sequence"`
+	Op        EventOp   `json:"op"`
+	Previous  *Port     `json:"previous"`
+	Source    string    `json:"source"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// PortFilter matches ports with all set fields equal to port ones, Region matches
+// ports having it among their regions. Empty filter matches every port.
+type PortFilter struct {
+	Country  string
+	Province string
+	City     string
+	Region   string
+	Timezone string
+}
+
+// BoundingBox is a map viewport, MinLon greater than MaxLon means box crosses antimeridian.
+type BoundingBox struct {
+	MinLon float64
+	MinLat float64
+	MaxLon float64
+	MaxLat float64
+}
+
+// NearbyPort is a port with great-circle distance to requested point.
+type NearbyPort struct {
+	Port
+	DistanceKm float64 `json:"distance_km" db:"distance_km"`
+}
+
+// MatchedPort is a port found by name search with its rank, higher score is a better match.
+type MatchedPort struct {
+	Port
+	Score float64 `json:"score" db:"score"`
+}
+
+// Page is a part of ports ordered by id, NextCursor is empty for the last page.
+type Page struct {
+	Ports      []*Port `json:"items"`
+	NextCursor string  `json:"next_cursor"`
+}
+
+// PortImporter saves stream of ports in one long lived operation, batchSize ports are saved at once.
+type PortImporter interface {
+	Import(ctx context.Context, ports <-chan *Port, batchSize int) (ImportSummary, error)
+}
+
+// SaveResult counts ports created and updated by batch save.
+type SaveResult struct {
+	Inserted int
+	Updated  int
+}
+
+// ImportSummary reports outcome of import, failures are indexed by position in the stream.
+// Failures may be truncated, RejectedByCode counts all rejected ports by error code.
+type ImportSummary struct {
+	Inserted       int
+	Updated        int
+	Rejected       int
+	Total          int
+	Failures       []BatchFailure
+	RejectedByCode map[string]int
 }
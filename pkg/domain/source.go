@@ -0,0 +1,17 @@
+package domain
+
+import "context"
+
+type sourceKey struct{}
+
+// WithSource returns ctx carrying source of changes made with it, like loader file name or request id.
+// Source is recorded in history of changed ports.
+func WithSource(ctx context.Context, source string) context.Context {
+	return context.WithValue(ctx, sourceKey{}, source)
+}
+
+// SourceFromContext returns source set by WithSource, empty if there is none.
+func SourceFromContext(ctx context.Context) string {
+	source, _ := ctx.Value(sourceKey{}).(string)
+	return source
+}
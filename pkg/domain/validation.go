@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// unlocodePattern is UN/LOCODE: ISO 3166 country code followed by 3 letters or digits 2-9.
+var unlocodePattern = regexp.MustCompile(`^[A-Z]{2}[A-Z2-9]{3}$`)
+
+// timezones caches known timezone names as lookup reads zoneinfo files, invalid names
+// are not cached so arbitrary input can not grow it.
+var timezones sync.Map
+
+// Validate checks port fields and returns *ValidationError listing all invalid ones.
+func (p *Port) Validate() error {
+	verr := &ValidationError{}
+	if !unlocodePattern.MatchString(p.ID) {
+		verr.Add("id", "must be UN/LOCODE")
+	}
+	if strings.TrimSpace(p.Name) == "" {
+		verr.Add("name", "must not be empty")
+	}
+	if !p.Coordinates.validLatitude() {
+		verr.Add("coordinates.lat", "must be within [-90, 90]")
+	}
+	if !p.Coordinates.validLongitude() {
+		verr.Add("coordinates.lon", "must be within [-180, 180]")
+	}
+	if p.Timezone != "" && !validTimezone(p.Timezone) {
+		verr.Add("timezone", "must be IANA time zone name")
+	}
+	for i, code := range p.Unlocs {
+		if !unlocodePattern.MatchString(code) {
+			verr.Add("unlocs["+strconv.Itoa(i)+"]", "must be UN/LOCODE")
+		}
+	}
+	seen := make(map[string]int, len(p.Alias))
+	for i, alias := range p.Alias {
+		if j, ok := seen[alias]; ok {
+			verr.Add("alias["+strconv.Itoa(i)+"]", "duplicates alias["+strconv.Itoa(j)+"]")
+			continue
+		}
+		seen[alias] = i
+	}
+	return verr.ErrOrNil()
+}
+
+func validTimezone(name string) bool {
+	if _, ok := timezones.Load(name); ok {
+		return true
+	}
+	// Local is accepted by time.LoadLocation but it is not a IANA name
+	if _, err := time.LoadLocation(name); err != nil || name == "Local" {
+		return false
+	}
+	timezones.Store(name, struct{}{})
+	return true
+}
@@ -1,76 +1,181 @@
 package jsonreader
 
 import (
+	"bufio"
+	"encoding/json"
+	"errors"
 	"io"
+	"strings"
 
-	jsoniter "github.com/json-iterator/go"
 	"github.com/sp4rd4/ports/pkg/domain"
 	"github.com/sp4rd4/ports/pkg/domain/loader"
 )
 
+var (
+	ErrNoInput   = errors.New("no input")
+	ErrNotObject = errors.New("ports should be json object keyed by port id")
+)
+
 type loaderJSON struct {
-	reader     io.Reader
-	bufferSize int
-	cancel     <-chan struct{}
+	reader      io.Reader
+	bufferSize  int
+	cancel      <-chan struct{}
+	skipInvalid bool
+	order       domain.CoordinateOrder
+	err         error
+	skipped     []loader.ParseError
+}
+
+type Option func(*loaderJSON)
+
+// SkipInvalid makes loader skip records which could not be parsed as port and collect
+// their errors instead of stopping, malformed json still stops loading.
+func SkipInvalid() Option {
+	return func(lj *loaderJSON) {
+		lj.skipInvalid = true
+	}
 }
 
-func NewLoader(reader io.Reader, bufferSize int, cancel <-chan struct{}) loader.Ports {
-	return loaderJSON{
+// Coordinates sets order of latitude and longitude in coordinates arrays, default is domain.LonLat.
+func Coordinates(order domain.CoordinateOrder) Option {
+	return func(lj *loaderJSON) {
+		lj.order = order
+	}
+}
+
+func NewLoader(reader io.Reader, bufferSize int, cancel <-chan struct{}, opts ...Option) loader.Ports {
+	lj := &loaderJSON{
 		reader:     reader,
 		bufferSize: bufferSize,
 		cancel:     cancel,
+		order:      domain.LonLat,
 	}
+	for _, opt := range opts {
+		opt(lj)
+	}
+	return lj
 }
 
-func (lj loaderJSON) Load() <-chan *domain.Port {
-	iter := jsoniter.Parse(jsoniter.ConfigFastest, lj.reader, lj.bufferSize)
+func (lj *loaderJSON) Load() <-chan *domain.Port {
 	data := make(chan *domain.Port)
 
-	if lj.cancel == nil {
-		go lj.iterate(iter, data)
-	} else {
-		go lj.iterateCancellable(iter, data)
-	}
+	go lj.iterate(data)
 
 	return data
 }
 
-func (lj loaderJSON) iterate(iter *jsoniter.Iterator, data chan *domain.Port) {
-	defer close(data)
+func (lj *loaderJSON) Err() error {
+	return lj.err
+}
 
-	for field := iter.ReadObject(); field != ""; field = iter.ReadObject() {
-		if iter.Error != nil {
-			return
-		}
-		p := &domain.Port{}
-		iter.ReadVal(p)
-		if iter.Error != nil {
-			return
-		}
-		p.ID = field
-		data <- p
-	}
+func (lj *loaderJSON) Skipped() []loader.ParseError {
+	return lj.skipped
 }
 
-func (lj loaderJSON) iterateCancellable(iter *jsoniter.Iterator, data chan *domain.Port) {
+// iterate reads object of ports record by record, only value of one record is kept in memory.
+// Offset of parse errors is position of the record value, or of the decoder if it failed outside of records.
+func (lj *loaderJSON) iterate(data chan *domain.Port) {
 	defer close(data)
 
-	for field := iter.ReadObject(); field != ""; field = iter.ReadObject() {
-		if iter.Error != nil {
+	if lj.reader == nil {
+		lj.err = &loader.ParseError{Err: ErrNoInput}
+		return
+	}
+	dec := json.NewDecoder(bufio.NewReaderSize(lj.reader, lj.bufferSize))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		if err == nil {
+			err = ErrNotObject
+		}
+		lj.fail(dec, "", err)
+		return
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			lj.fail(dec, "", err)
 			return
 		}
+		field, _ := tok.(string)
+
+		p, ok := lj.readPort(dec, field)
+		if !ok {
+			if lj.err != nil {
+				return
+			}
+			continue
+		}
+
 		select {
 		case <-lj.cancel:
 			return
-		default:
+		case data <- p:
 		}
+	}
+	if _, err := dec.Token(); err != nil {
+		lj.fail(dec, "", err)
+	}
+}
 
-		p := &domain.Port{}
-		iter.ReadVal(p)
-		if iter.Error != nil {
-			return
-		}
+// readPort decodes record value, ok is false if port was skipped or loading failed.
+func (lj *loaderJSON) readPort(dec *json.Decoder, field string) (*domain.Port, bool) {
+	// peek makes decoder buffer separator before the value, so offset points to the value itself
+	dec.More()
+	offset := offset(dec)
+
+	p := &domain.Port{}
+	err := dec.Decode(&record{Port: p, Coordinates: coordinates{order: lj.order, location: &p.Coordinates}})
+	var typeErr *json.UnmarshalTypeError
+	if err != nil && !errors.As(err, &typeErr) && !errors.Is(err, domain.ErrInvalidCoordinates) {
+		lj.fail(dec, field, err)
+		return nil, false
+	}
+	if err == nil {
+		err = p.Coordinates.Validate()
+	}
+	if err == nil {
 		p.ID = field
-		data <- p
+		return p, true
+	}
+
+	parseErr := &loader.ParseError{Offset: offset, Key: field, Err: err}
+	if lj.skipInvalid {
+		lj.skipped = append(lj.skipped, *parseErr)
+		return nil, false
 	}
+	lj.err = parseErr
+	return nil, false
+}
+
+// fail stops loading on malformed json, which can not be skipped in any mode.
+func (lj *loaderJSON) fail(dec *json.Decoder, field string, err error) {
+	lj.err = &loader.ParseError{Offset: offset(dec), Key: field, Err: err}
+}
+
+// offset returns position of the decoder, whitespace and separators it has buffered are skipped
+// so that it points to the value which is read next or could not be read.
+func offset(dec *json.Decoder) int64 {
+	var skipped int64
+	if buffered, ok := dec.Buffered().(io.ByteReader); ok {
+		b, err := buffered.ReadByte()
+		for ; err == nil && strings.IndexByte(" \t\r\n:,", b) >= 0; b, err = buffered.ReadByte() {
+			skipped++
+		}
+	}
+	return dec.InputOffset() + skipped
+}
+
+// record decodes port value of the file, coordinates arrays are read in loader order.
+type record struct {
+	*domain.Port
+	Coordinates coordinates `json:"coordinates"`
+}
+
+type coordinates struct {
+	order    domain.CoordinateOrder
+	location *domain.Location
+}
+
+func (c *coordinates) UnmarshalJSON(b []byte) error {
+	return c.order.Unmarshal(b, c.location)
 }
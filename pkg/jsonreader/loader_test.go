@@ -1,11 +1,13 @@
 package jsonreader_test
 
 import (
+	"errors"
 	"io"
 	"strings"
 	"testing"
 
 	"github.com/sp4rd4/ports/pkg/domain"
+	"github.com/sp4rd4/ports/pkg/domain/loader"
 	"github.com/sp4rd4/ports/pkg/jsonreader"
 	"github.com/stretchr/testify/assert"
 )
@@ -16,21 +18,25 @@ var examplesLoad = []struct {
 	name   string
 	reader io.Reader
 	result []*domain.Port
+	err    bool
 }{
 	{
 		name:   "Empty reader",
 		reader: strings.NewReader(""),
 		result: nil,
+		err:    true,
 	},
 	{
 		name:   "Nil reader",
 		result: nil,
+		err:    true,
 	},
 	{
 		name: "Incorrect json",
 		//nolint
 		reader: strings.NewReader(`{"name":"Pretoria","coordinates":[28.22,-25.7],"city":"Pretoria","province":"Gauteng","country":"South Africa","alias":[],"regions":[],"timezone":"Africa/Johannesburg","unlocs":["ZAPRY"]}`),
 		result: nil,
+		err:    true,
 	},
 	{
 		name: "Correct json",
@@ -45,8 +51,8 @@ var examplesLoad = []struct {
 				Alias:   domain.StringArray{},
 				Regions: domain.StringArray{},
 				Coordinates: domain.Location{
-					Latitude:  55.5136433,
-					Longitude: 25.4052165,
+					Latitude:  25.4052165,
+					Longitude: 55.5136433,
 				},
 				Province: "Ajman",
 				Timezone: "Asia/Dubai",
@@ -61,8 +67,8 @@ var examplesLoad = []struct {
 				Alias:   domain.StringArray{},
 				Regions: domain.StringArray{},
 				Coordinates: domain.Location{
-					Latitude:  25.5207358,
-					Longitude: -33.7139247,
+					Latitude:  -33.7139247,
+					Longitude: 25.5207358,
 				},
 				Province: "Eastern Cape",
 				Timezone: "Africa/Johannesburg",
@@ -75,14 +81,153 @@ var examplesLoad = []struct {
 
 func TestLoad(t *testing.T) {
 	for _, ex := range examplesLoad {
-		loader := jsonreader.NewLoader(ex.reader, bufferSize, nil)
+		ldr := jsonreader.NewLoader(ex.reader, bufferSize, nil)
 		t.Run(ex.name, func(t *testing.T) {
 			var res []*domain.Port
-			c := loader.Load()
+			c := ldr.Load()
 			for p := range c {
 				res = append(res, p)
 			}
 			assert.ElementsMatch(t, res, ex.result, "Chanel should return expected ports")
+			assert.Equal(t, ex.err, ldr.Err() != nil, "Should report error if json is invalid")
+		})
+	}
+}
+
+//nolint:lll
+const truncatedJSON = `{"AEAJM":{"name":"Ajman","city":"Ajman"},"ZAPLZ":{"name":"Port Elizabeth","ci`
+
+//nolint:lll
+const invalidRecordJSON = `{"AEAJM":{"name":"Ajman","city":"Ajman"},"ZAPLZ":{"name":1},"ZAPRY":{"name":"Pretoria"}}`
+
+func TestLoadTruncated(t *testing.T) {
+	ldr := jsonreader.NewLoader(strings.NewReader(truncatedJSON), bufferSize, nil)
+	var res []*domain.Port
+	for p := range ldr.Load() {
+		res = append(res, p)
+	}
+	assert.Equal(t, []*domain.Port{{ID: "AEAJM", Name: "Ajman", City: "Ajman"}}, res, "Should return ports before error")
+
+	var parseErr *loader.ParseError
+	if assert.True(t, errors.As(ldr.Err(), &parseErr), "Should return parse error") {
+		assert.Equal(t, "ZAPLZ", parseErr.Key, "Should report key of failed record")
+		assert.Equal(t, int64(strings.Index(truncatedJSON, `{"name":"Port`)), parseErr.Offset,
+			"Should report offset of failed record")
+	}
+}
+
+func TestLoadInvalidRecord(t *testing.T) {
+	ldr := jsonreader.NewLoader(strings.NewReader(invalidRecordJSON), bufferSize, nil)
+	var res []*domain.Port
+	for p := range ldr.Load() {
+		res = append(res, p)
+	}
+	assert.Len(t, res, 1, "Should stop on invalid record")
+
+	var parseErr *loader.ParseError
+	if assert.True(t, errors.As(ldr.Err(), &parseErr), "Should return parse error") {
+		assert.Equal(t, "ZAPLZ", parseErr.Key, "Should report key of failed record")
+		assert.Equal(t, int64(strings.Index(invalidRecordJSON, `{"name":1}`)), parseErr.Offset,
+			"Should report offset of failed record")
+	}
+}
+
+const indentedJSON = `{
+  "AEAJM": {"name": "Ajman"},
+  "ZAPLZ":
+    {"name": 1}
+}`
+
+func TestLoadOffset(t *testing.T) {
+	expected := int64(strings.Index(indentedJSON, `{"name": 1}`))
+	for _, opts := range [][]jsonreader.Option{nil, {jsonreader.SkipInvalid()}} {
+		ldr := jsonreader.NewLoader(strings.NewReader(indentedJSON), 16, nil, opts...)
+		for range ldr.Load() {
+		}
+
+		offset := int64(-1)
+		var parseErr *loader.ParseError
+		if errors.As(ldr.Err(), &parseErr) {
+			offset = parseErr.Offset
+		} else if len(ldr.Skipped()) == 1 {
+			offset = ldr.Skipped()[0].Offset
+		}
+		assert.Equal(t, expected, offset, "Should report offset of record value in any mode")
+	}
+}
+
+func TestLoadSkipInvalid(t *testing.T) {
+	ldr := jsonreader.NewLoader(strings.NewReader(invalidRecordJSON), 16, nil, jsonreader.SkipInvalid())
+	var res []*domain.Port
+	for p := range ldr.Load() {
+		res = append(res, p)
+	}
+	assert.Equal(t, []*domain.Port{
+		{ID: "AEAJM", Name: "Ajman", City: "Ajman"},
+		{ID: "ZAPRY", Name: "Pretoria"},
+	}, res, "Should skip invalid record")
+	assert.Nil(t, ldr.Err(), "Should return no error")
+	if assert.Len(t, ldr.Skipped(), 1, "Should collect skipped record") {
+		assert.Equal(t, "ZAPLZ", ldr.Skipped()[0].Key, "Should report key of skipped record")
+		assert.Equal(t, int64(strings.Index(invalidRecordJSON, `{"name":1}`)), ldr.Skipped()[0].Offset,
+			"Should report offset of skipped record")
+	}
+}
+
+var examplesCoordinates = []struct {
+	name     string
+	json     string
+	opts     []jsonreader.Option
+	expected domain.Location
+	err      error
+}{
+	{
+		name:     "Default order",
+		json:     `{"AEAJM":{"coordinates":[55.5136433,25.4052165]}}`,
+		expected: domain.Location{Latitude: 25.4052165, Longitude: 55.5136433},
+	},
+	{
+		name:     "Lat lon order",
+		json:     `{"AEAJM":{"coordinates":[25.4052165,55.5136433]}}`,
+		opts:     []jsonreader.Option{jsonreader.Coordinates(domain.LatLon)},
+		expected: domain.Location{Latitude: 25.4052165, Longitude: 55.5136433},
+	},
+	{
+		name:     "Object",
+		json:     `{"AEAJM":{"coordinates":{"lat":25.4052165,"lon":55.5136433}}}`,
+		expected: domain.Location{Latitude: 25.4052165, Longitude: 55.5136433},
+	},
+	{
+		name: "Latitude out of range",
+		json: `{"AEAJM":{"coordinates":[25.4052165,95.5136433]}}`,
+		err:  domain.ErrInvalidLatitude,
+	},
+	{
+		name: "Longitude out of range",
+		json: `{"AEAJM":{"coordinates":[255.5136433,25.4052165]}}`,
+		err:  domain.ErrInvalidLongitude,
+	},
+	{
+		name: "Not coordinates",
+		json: `{"AEAJM":{"coordinates":"25.4052165,55.5136433"}}`,
+		err:  domain.ErrInvalidCoordinates,
+	},
+}
+
+func TestLoadCoordinates(t *testing.T) {
+	for _, ex := range examplesCoordinates {
+		ldr := jsonreader.NewLoader(strings.NewReader(ex.json), bufferSize, nil, ex.opts...)
+		t.Run(ex.name, func(t *testing.T) {
+			var res []domain.Location
+			for p := range ldr.Load() {
+				res = append(res, p.Coordinates)
+			}
+			assert.True(t, errors.Is(ldr.Err(), ex.err), "Error should be same as expected")
+			if ex.err == nil {
+				assert.Equal(t, []domain.Location{ex.expected}, res, "Should read coordinates in configured order")
+			} else {
+				assert.Empty(t, res, "Should reject port with invalid coordinates")
+			}
 		})
 	}
 }
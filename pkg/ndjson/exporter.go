@@ -0,0 +1,68 @@
+package ndjson
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/sp4rd4/ports/pkg/domain"
+)
+
+type PortLister interface {
+	List(ctx context.Context, filter domain.PortFilter, cursor string, limit int) (domain.Page, error)
+}
+
+// Exporter writes ports in format read by loader, coordinates are written as {"lat","lon"} objects
+// so they are read the same regardless of loader coordinates order.
+type Exporter struct {
+	lister   PortLister
+	pageSize int
+	api      jsoniter.API
+}
+
+func NewExporter(lister PortLister, pageSize int) *Exporter {
+	return &Exporter{
+		lister:   lister,
+		pageSize: pageSize,
+		api:      jsoniter.Config{EscapeHTML: false}.Froze(),
+	}
+}
+
+// Export writes all ports one per line and returns number of written ones. Ports are listed page by page,
+// each page is written at once, so ports changed during export may be written as of different moments.
+func (e *Exporter) Export(ctx context.Context, w io.Writer) (int, error) {
+	var (
+		cursor  string
+		written int
+		buf     bytes.Buffer
+	)
+	for {
+		page, err := e.lister.List(ctx, domain.PortFilter{}, cursor, e.pageSize)
+		if err != nil {
+			return written, fmt.Errorf("[%v] export: %w", errorTag, err)
+		}
+
+		buf.Reset()
+		for _, p := range page.Ports {
+			line, err := e.api.Marshal(p)
+			if err != nil {
+				return written, fmt.Errorf("[%v] export: port %v: %w", errorTag, p.ID, err)
+			}
+			buf.Write(line)
+			buf.WriteByte('\n')
+		}
+		if buf.Len() > 0 {
+			if _, err = w.Write(buf.Bytes()); err != nil {
+				return written, fmt.Errorf("[%v] export: %w", errorTag, err)
+			}
+		}
+		written += len(page.Ports)
+
+		if page.NextCursor == "" {
+			return written, nil
+		}
+		cursor = page.NextCursor
+	}
+}
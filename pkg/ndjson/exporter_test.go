@@ -0,0 +1,72 @@
+package ndjson_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sp4rd4/ports/pkg/domain"
+	"github.com/sp4rd4/ports/pkg/ndjson"
+	"github.com/sp4rd4/ports/pkg/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+var errList = errors.New("list failed")
+
+type failingLister struct {
+	page  domain.Page
+	calls int
+}
+
+func (fl *failingLister) List(context.Context, domain.PortFilter, string, int) (domain.Page, error) {
+	fl.calls++
+	if fl.calls > 1 {
+		return domain.Page{}, errList
+	}
+	return fl.page, nil
+}
+
+func TestExport(t *testing.T) {
+	ports := []*domain.Port{
+		{
+			ID:          "AEAJM",
+			Name:        "Ajman",
+			Alias:       domain.StringArray{},
+			Coordinates: domain.Location{Latitude: 25.4052165, Longitude: 55.5136433},
+			Unlocs:      domain.StringArray{"AEAJM"},
+		},
+		{ID: "ZAPLZ", Name: "Port Elizabeth", Coordinates: domain.Location{Latitude: -33.7139247, Longitude: 25.5207358}},
+		{ID: "ZAPRY", Name: "Pretoria"},
+	}
+	storage := memory.New()
+	_, err := storage.SaveBatch(context.TODO(), ports)
+	assert.Nil(t, err, "Should save ports with no error")
+
+	var buf bytes.Buffer
+	written, err := ndjson.NewExporter(storage, 2).Export(context.TODO(), &buf)
+	assert.Nil(t, err, "Should export with no error")
+	assert.Equal(t, len(ports), written, "Should report number of exported ports")
+	assert.Equal(t, len(ports), strings.Count(buf.String(), "\n"), "Should write port per line")
+
+	ldr := ndjson.NewLoader(&buf, bufferSize, nil, ndjson.Coordinates(domain.LatLon))
+	var loaded []*domain.Port
+	for p := range ldr.Load() {
+		loaded = append(loaded, p)
+	}
+	assert.Nil(t, ldr.Err(), "Should load exported ports with no error")
+	stored, err := storage.List(context.TODO(), domain.PortFilter{}, "", len(ports))
+	assert.Nil(t, err, "Should list ports with no error")
+	assert.Equal(t, stored.Ports, loaded, "Should load exported ports back")
+}
+
+func TestExportError(t *testing.T) {
+	lister := &failingLister{page: domain.Page{Ports: []*domain.Port{{ID: "AEAJM"}}, NextCursor: "AEAJM"}}
+
+	var buf bytes.Buffer
+	written, err := ndjson.NewExporter(lister, 1).Export(context.TODO(), &buf)
+	assert.True(t, errors.Is(err, errList), "Should return list error")
+	assert.Equal(t, 1, written, "Should report ports written before error")
+	assert.Equal(t, 1, strings.Count(buf.String(), "\n"), "Should write ports before error")
+}
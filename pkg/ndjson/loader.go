@@ -0,0 +1,142 @@
+package ndjson
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/sp4rd4/ports/pkg/domain"
+	"github.com/sp4rd4/ports/pkg/domain/loader"
+)
+
+const errorTag = "ndjson"
+
+var (
+	ErrNoInput   = errors.New("no input")
+	ErrMissingID = errors.New("missing id")
+)
+
+type loaderNDJSON struct {
+	reader      io.Reader
+	bufferSize  int
+	cancel      <-chan struct{}
+	skipInvalid bool
+	order       domain.CoordinateOrder
+	api         jsoniter.API
+	err         error
+	skipped     []loader.ParseError
+}
+
+type Option func(*loaderNDJSON)
+
+// SkipInvalid makes loader skip lines which could not be parsed as port and collect
+// their errors instead of stopping, loading goes on from the next line.
+func SkipInvalid() Option {
+	return func(ln *loaderNDJSON) {
+		ln.skipInvalid = true
+	}
+}
+
+// Coordinates sets order of latitude and longitude in coordinates arrays, default is domain.LonLat.
+func Coordinates(order domain.CoordinateOrder) Option {
+	return func(ln *loaderNDJSON) {
+		ln.order = order
+	}
+}
+
+// NewLoader returns loader of newline delimited json, every line is a port object with its id.
+func NewLoader(reader io.Reader, bufferSize int, cancel <-chan struct{}, opts ...Option) loader.Ports {
+	ln := &loaderNDJSON{
+		reader:     reader,
+		bufferSize: bufferSize,
+		cancel:     cancel,
+		order:      domain.LonLat,
+	}
+	for _, opt := range opts {
+		opt(ln)
+	}
+	ln.api = jsoniter.Config{EscapeHTML: false}.Froze()
+	ln.api.RegisterExtension(ln.order.Extension())
+	return ln
+}
+
+func (ln *loaderNDJSON) Load() <-chan *domain.Port {
+	data := make(chan *domain.Port)
+
+	go ln.iterate(data)
+
+	return data
+}
+
+func (ln *loaderNDJSON) Err() error {
+	return ln.err
+}
+
+func (ln *loaderNDJSON) Skipped() []loader.ParseError {
+	return ln.skipped
+}
+
+func (ln *loaderNDJSON) iterate(data chan *domain.Port) {
+	defer close(data)
+
+	if ln.reader == nil {
+		ln.err = &loader.ParseError{Err: ErrNoInput}
+		return
+	}
+	reader := bufio.NewReaderSize(ln.reader, ln.bufferSize)
+	var offset int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			p, ok := ln.readPort(line, offset)
+			offset += int64(len(line))
+			if ln.err != nil {
+				return
+			}
+			if ok {
+				select {
+				case <-ln.cancel:
+					return
+				case data <- p:
+				}
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			ln.err = &loader.ParseError{Offset: offset, Err: err}
+			return
+		}
+	}
+}
+
+// readPort parses line, ok is false if line is blank, was skipped or loading failed.
+func (ln *loaderNDJSON) readPort(line []byte, offset int64) (*domain.Port, bool) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return nil, false
+	}
+
+	p := &domain.Port{}
+	err := ln.api.Unmarshal(line, p)
+	if err == nil && p.ID == "" {
+		err = ErrMissingID
+	}
+	if err == nil {
+		err = p.Coordinates.Validate()
+	}
+	if err == nil {
+		return p, true
+	}
+
+	parseErr := &loader.ParseError{Offset: offset, Key: p.ID, Err: err}
+	if ln.skipInvalid {
+		ln.skipped = append(ln.skipped, *parseErr)
+		return nil, false
+	}
+	ln.err = parseErr
+	return nil, false
+}
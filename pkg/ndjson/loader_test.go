@@ -0,0 +1,175 @@
+package ndjson_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/sp4rd4/ports/pkg/domain"
+	"github.com/sp4rd4/ports/pkg/domain/loader"
+	"github.com/sp4rd4/ports/pkg/ndjson"
+	"github.com/stretchr/testify/assert"
+)
+
+var bufferSize = 512
+
+var examplesLoad = []struct {
+	name   string
+	reader io.Reader
+	result []*domain.Port
+	err    bool
+}{
+	{
+		name:   "Empty reader",
+		reader: strings.NewReader(""),
+		result: nil,
+	},
+	{
+		name:   "Nil reader",
+		result: nil,
+		err:    true,
+	},
+	{
+		name:   "Single json object",
+		reader: strings.NewReader(`{"AEAJM":{"name":"Ajman","city":"Ajman"}}`),
+		result: nil,
+		err:    true,
+	},
+	{
+		name: "Correct ndjson",
+		//nolint
+		reader: strings.NewReader(`{"id":"AEAJM","name":"Ajman","city":"Ajman","country":"United Arab Emirates","alias":[],"regions":[],"coordinates":[55.5136433,25.4052165],"province":"Ajman","timezone":"Asia/Dubai","unlocs":["AEAJM"],"code":"52000"}
+
+{"id":"ZAPLZ","name":"Port Elizabeth","coordinates":{"lat":-33.7139247,"lon":25.5207358}}`),
+		result: []*domain.Port{
+			{
+				ID:      "AEAJM",
+				Name:    "Ajman",
+				City:    "Ajman",
+				Country: "United Arab Emirates",
+				Alias:   domain.StringArray{},
+				Regions: domain.StringArray{},
+				Coordinates: domain.Location{
+					Latitude:  25.4052165,
+					Longitude: 55.5136433,
+				},
+				Province: "Ajman",
+				Timezone: "Asia/Dubai",
+				Unlocs:   domain.StringArray{"AEAJM"},
+				Code:     "52000",
+			},
+			{
+				ID:   "ZAPLZ",
+				Name: "Port Elizabeth",
+				Coordinates: domain.Location{
+					Latitude:  -33.7139247,
+					Longitude: 25.5207358,
+				},
+			},
+		},
+	},
+}
+
+func TestLoad(t *testing.T) {
+	for _, ex := range examplesLoad {
+		ldr := ndjson.NewLoader(ex.reader, bufferSize, nil)
+		t.Run(ex.name, func(t *testing.T) {
+			var res []*domain.Port
+			c := ldr.Load()
+			for p := range c {
+				res = append(res, p)
+			}
+			assert.Equal(t, ex.result, res, "Chanel should return expected ports")
+			assert.Equal(t, ex.err, ldr.Err() != nil, "Should report error if ndjson is invalid")
+		})
+	}
+}
+
+const corruptNDJSON = `{"id":"AEAJM","name":"Ajman"}
+{"id":"ZAPLZ","name":"Port Eliz
+{"name":"Pretoria"}
+{"id":"ZAPRY","name":"Pretoria"}
+`
+
+func TestLoadCorrupt(t *testing.T) {
+	ldr := ndjson.NewLoader(strings.NewReader(corruptNDJSON), bufferSize, nil)
+	var res []*domain.Port
+	for p := range ldr.Load() {
+		res = append(res, p)
+	}
+	assert.Equal(t, []*domain.Port{{ID: "AEAJM", Name: "Ajman"}}, res, "Should return ports before error")
+
+	var parseErr *loader.ParseError
+	if assert.True(t, errors.As(ldr.Err(), &parseErr), "Should return parse error") {
+		assert.Equal(t, int64(strings.Index(corruptNDJSON, `{"id":"ZAPLZ"`)), parseErr.Offset,
+			"Should report offset of failed line")
+	}
+}
+
+func TestLoadSkipInvalid(t *testing.T) {
+	ldr := ndjson.NewLoader(strings.NewReader(corruptNDJSON), 16, nil, ndjson.SkipInvalid())
+	var res []*domain.Port
+	for p := range ldr.Load() {
+		res = append(res, p)
+	}
+	assert.Equal(t, []*domain.Port{
+		{ID: "AEAJM", Name: "Ajman"},
+		{ID: "ZAPRY", Name: "Pretoria"},
+	}, res, "Should recover on the next line")
+	assert.Nil(t, ldr.Err(), "Should return no error")
+	if assert.Len(t, ldr.Skipped(), 2, "Should collect skipped lines") {
+		assert.Equal(t, int64(strings.Index(corruptNDJSON, `{"id":"ZAPLZ"`)), ldr.Skipped()[0].Offset,
+			"Should report offset of skipped line")
+		assert.True(t, errors.Is(&ldr.Skipped()[1], ndjson.ErrMissingID), "Should reject port without id")
+	}
+}
+
+var examplesCoordinates = []struct {
+	name     string
+	json     string
+	opts     []ndjson.Option
+	expected domain.Location
+	err      error
+}{
+	{
+		name:     "Default order",
+		json:     `{"id":"AEAJM","coordinates":[55.5136433,25.4052165]}`,
+		expected: domain.Location{Latitude: 25.4052165, Longitude: 55.5136433},
+	},
+	{
+		name:     "Lat lon order",
+		json:     `{"id":"AEAJM","coordinates":[25.4052165,55.5136433]}`,
+		opts:     []ndjson.Option{ndjson.Coordinates(domain.LatLon)},
+		expected: domain.Location{Latitude: 25.4052165, Longitude: 55.5136433},
+	},
+	{
+		name:     "Object",
+		json:     `{"id":"AEAJM","coordinates":{"lat":25.4052165,"lon":55.5136433}}`,
+		opts:     []ndjson.Option{ndjson.Coordinates(domain.LatLon)},
+		expected: domain.Location{Latitude: 25.4052165, Longitude: 55.5136433},
+	},
+	{
+		name: "Latitude out of range",
+		json: `{"id":"AEAJM","coordinates":[25.4052165,95.5136433]}`,
+		err:  domain.ErrInvalidLatitude,
+	},
+}
+
+func TestLoadCoordinates(t *testing.T) {
+	for _, ex := range examplesCoordinates {
+		ldr := ndjson.NewLoader(strings.NewReader(ex.json), bufferSize, nil, ex.opts...)
+		t.Run(ex.name, func(t *testing.T) {
+			var res []domain.Location
+			for p := range ldr.Load() {
+				res = append(res, p.Coordinates)
+			}
+			assert.True(t, errors.Is(ldr.Err(), ex.err), "Error should be same as expected")
+			if ex.err == nil {
+				assert.Equal(t, []domain.Location{ex.expected}, res, "Should read coordinates in configured order")
+			} else {
+				assert.Empty(t, res, "Should reject port with invalid coordinates")
+			}
+		})
+	}
+}
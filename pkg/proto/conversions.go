@@ -21,6 +21,7 @@ func PortDomainToProto(p *domain.Port) *Port {
 		Province: p.Province,
 		Timezone: p.Timezone,
 		Unlocs:   p.Unlocs,
+		Revision: p.Revision,
 	}
 }
 
@@ -39,6 +40,7 @@ func PortProtoToDomain(p *Port) *domain.Port {
 		Province: p.Province,
 		Timezone: p.Timezone,
 		Unlocs:   p.Unlocs,
+		Revision: p.Revision,
 	}
 	if p.Coordinates != nil {
 		port.Coordinates = domain.Location{
@@ -48,3 +50,147 @@ func PortProtoToDomain(p *Port) *domain.Port {
 	}
 	return port
 }
+
+func PortsDomainToProto(ps []*domain.Port) []*Port {
+	res := make([]*Port, len(ps))
+	for i, p := range ps {
+		res[i] = PortDomainToProto(p)
+	}
+	return res
+}
+
+func PortsProtoToDomain(ps []*Port) []*domain.Port {
+	res := make([]*domain.Port, len(ps))
+	for i, p := range ps {
+		res[i] = PortProtoToDomain(p)
+	}
+	return res
+}
+
+func PageDomainToProto(p domain.Page) *PortPage {
+	return &PortPage{Ports: PortsDomainToProto(p.Ports), NextCursor: p.NextCursor}
+}
+
+func PageProtoToDomain(p *PortPage) domain.Page {
+	return domain.Page{Ports: PortsProtoToDomain(p.GetPorts()), NextCursor: p.GetNextCursor()}
+}
+
+func FilterDomainToProto(f domain.PortFilter) *SearchFilter {
+	return &SearchFilter{
+		Country:  f.Country,
+		Province: f.Province,
+		City:     f.City,
+		Region:   f.Region,
+		Timezone: f.Timezone,
+	}
+}
+
+func FilterProtoToDomain(f *SearchFilter) domain.PortFilter {
+	return domain.PortFilter{
+		Country:  f.GetCountry(),
+		Province: f.GetProvince(),
+		City:     f.GetCity(),
+		Region:   f.GetRegion(),
+		Timezone: f.GetTimezone(),
+	}
+}
+
+func NearbyDomainToProto(ps []domain.NearbyPort) *NearbyPorts {
+	res := &NearbyPorts{Ports: make([]*NearbyPort, len(ps))}
+	for i := range ps {
+		res.Ports[i] = &NearbyPort{Port: PortDomainToProto(&ps[i].Port), DistanceKm: ps[i].DistanceKm}
+	}
+	return res
+}
+
+func NearbyProtoToDomain(ps *NearbyPorts) []domain.NearbyPort {
+	res := make([]domain.NearbyPort, len(ps.GetPorts()))
+	for i, p := range ps.GetPorts() {
+		res[i] = domain.NearbyPort{Port: *PortProtoToDomain(p.GetPort()), DistanceKm: p.GetDistanceKm()}
+	}
+	return res
+}
+
+func MatchedDomainToProto(ps []domain.MatchedPort) *MatchedPorts {
+	res := &MatchedPorts{Ports: make([]*MatchedPort, len(ps))}
+	for i := range ps {
+		res.Ports[i] = &MatchedPort{Port: PortDomainToProto(&ps[i].Port), Score: ps[i].Score}
+	}
+	return res
+}
+
+func MatchedProtoToDomain(ps *MatchedPorts) []domain.MatchedPort {
+	res := make([]domain.MatchedPort, len(ps.GetPorts()))
+	for i, p := range ps.GetPorts() {
+		res[i] = domain.MatchedPort{Port: *PortProtoToDomain(p.GetPort()), Score: p.GetScore()}
+	}
+	return res
+}
+
+func BoxDomainToProto(b domain.BoundingBox) *BoundingBox {
+	return &BoundingBox{MinLon: b.MinLon, MinLat: b.MinLat, MaxLon: b.MaxLon, MaxLat: b.MaxLat}
+}
+
+func BoxProtoToDomain(b *BoundingBox) domain.BoundingBox {
+	return domain.BoundingBox{
+		MinLon: b.GetMinLon(), MinLat: b.GetMinLat(), MaxLon: b.GetMaxLon(), MaxLat: b.GetMaxLat(),
+	}
+}
+
+var (
+	eventOpsToProto = map[domain.EventOp]PortEvent_Op{
+		domain.PortCreated: PortEvent_CREATED,
+		domain.PortUpdated: PortEvent_UPDATED,
+		domain.PortDeleted: PortEvent_DELETED,
+	}
+	eventOpsToDomain = map[PortEvent_Op]domain.EventOp{
+		PortEvent_CREATED: domain.PortCreated,
+		PortEvent_UPDATED: domain.PortUpdated,
+		PortEvent_DELETED: domain.PortDeleted,
+	}
+)
+
+func EventDomainToProto(e domain.PortEvent) *PortEvent {
+	return &PortEvent{Sequence: e.Sequence, Op: eventOpsToProto[e.Op], Port: PortDomainToProto(e.Port)}
+}
+
+func EventProtoToDomain(e *PortEvent) domain.PortEvent {
+	return domain.PortEvent{
+		Sequence: e.GetSequence(),
+		Op:       eventOpsToDomain[e.GetOp()],
+		Port:     PortProtoToDomain(e.GetPort()),
+	}
+}
+
+func HistoryDomainToProto(changes []domain.PortChange) *PortHistory {
+	res := &PortHistory{Changes: make([]*PortChange, len(changes))}
+	for i, c := range changes {
+		res.Changes[i] = &PortChange{
+			Sequence:  c.Sequence,
+			Op:        eventOpsToProto[c.Op],
+			Source:    c.Source,
+			ChangedAt: c.ChangedAt,
+		}
+		// created ports have no previous version
+		if c.Previous != nil {
+			res.Changes[i].Previous = PortDomainToProto(c.Previous)
+		}
+	}
+	return res
+}
+
+func HistoryProtoToDomain(h *PortHistory) []domain.PortChange {
+	changes := make([]domain.PortChange, len(h.GetChanges()))
+	for i, c := range h.GetChanges() {
+		changes[i] = domain.PortChange{
+			Sequence:  c.GetSequence(),
+			Op:        eventOpsToDomain[c.GetOp()],
+			Source:    c.GetSource(),
+			ChangedAt: c.GetChangedAt(),
+		}
+		if c.GetPrevious() != nil {
+			changes[i].Previous = PortProtoToDomain(c.GetPrevious())
+		}
+	}
+	return changes
+}
@@ -0,0 +1,8 @@
+package proto
+
+// SourceMetadataKey is gRPC metadata key carrying source of changes made by request,
+// see domain.WithSource.
+const SourceMetadataKey = "ports-source"
+
+// BatchSizeMetadataKey is gRPC metadata key carrying number of ports saved at once by ImportPorts.
+const BatchSizeMetadataKey = "ports-batch-size"
@@ -9,6 +9,7 @@ import (
 	fmt "fmt"
 	_ "github.com/gogo/protobuf/gogoproto"
 	proto "github.com/gogo/protobuf/proto"
+	github_com_gogo_protobuf_types "github.com/gogo/protobuf/types"
 	types "github.com/gogo/protobuf/types"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
@@ -16,12 +17,14 @@ import (
 	io "io"
 	math "math"
 	math_bits "math/bits"
+	time "time"
 )
 
 // Reference imports to suppress errors if they are not otherwise used.
 var _ = proto.Marshal
 var _ = fmt.Errorf
 var _ = math.Inf
+var _ = time.Kitchen
 
 // This is a compile-time assertion to ensure that this generated file
 // is compatible with the proto package it is being compiled against.
@@ -29,6 +32,37 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+type PortEvent_Op int32
+
+const (
+	PortEvent_UNKNOWN PortEvent_Op = 0
+	PortEvent_CREATED PortEvent_Op = 1
+	PortEvent_UPDATED PortEvent_Op = 2
+	PortEvent_DELETED PortEvent_Op = 3
+)
+
+var PortEvent_Op_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "CREATED",
+	2: "UPDATED",
+	3: "DELETED",
+}
+
+var PortEvent_Op_value = map[string]int32{
+	"UNKNOWN": 0,
+	"CREATED": 1,
+	"UPDATED": 2,
+	"DELETED": 3,
+}
+
+func (x PortEvent_Op) String() string {
+	return proto.EnumName(PortEvent_Op_name, int32(x))
+}
+
+func (PortEvent_Op) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{22, 0}
+}
+
 type Port struct {
 	Id          string    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name        string    `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
@@ -41,6 +75,8 @@ type Port struct {
 	Timezone    string    `protobuf:"bytes,9,opt,name=timezone,proto3" json:"timezone,omitempty"`
 	Unlocs      []string  `protobuf:"bytes,10,rep,name=unlocs,proto3" json:"unlocs,omitempty"`
 	Code        string    `protobuf:"bytes,11,opt,name=code,proto3" json:"code,omitempty"`
+	// revision is set by server, it grows with every update of the port.
+	Revision int64 `protobuf:"varint,12,opt,name=revision,proto3" json:"revision,omitempty"`
 }
 
 func (m *Port) Reset()         { *m = Port{} }
@@ -153,6 +189,229 @@ func (m *Port) GetCode() string {
 	return ""
 }
 
+func (m *Port) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+// SaveRequest is request of SaveIfRevision, Save writes port unconditionally.
+type SaveRequest struct {
+	Port *Port `protobuf:"bytes,1,opt,name=port,proto3" json:"port,omitempty"`
+	// expected_revision other than 0 makes save fail with FAILED_PRECONDITION
+	// unless stored port has that revision.
+	ExpectedRevision int64 `protobuf:"varint,2,opt,name=expected_revision,json=expectedRevision,proto3" json:"expected_revision,omitempty"`
+}
+
+func (m *SaveRequest) Reset()         { *m = SaveRequest{} }
+func (m *SaveRequest) String() string { return proto.CompactTextString(m) }
+func (*SaveRequest) ProtoMessage()    {}
+func (*SaveRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{1}
+}
+func (m *SaveRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SaveRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SaveRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SaveRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SaveRequest.Merge(m, src)
+}
+func (m *SaveRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SaveRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SaveRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SaveRequest proto.InternalMessageInfo
+
+func (m *SaveRequest) GetPort() *Port {
+	if m != nil {
+		return m.Port
+	}
+	return nil
+}
+
+func (m *SaveRequest) GetExpectedRevision() int64 {
+	if m != nil {
+		return m.ExpectedRevision
+	}
+	return 0
+}
+
+// UpdatePortRequest writes only fields of port named by update_mask paths, like "alias" or "timezone".
+type UpdatePortRequest struct {
+	Port       *Port            `protobuf:"bytes,1,opt,name=port,proto3" json:"port,omitempty"`
+	UpdateMask *types.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	// expected_revision other than 0 makes update fail with FAILED_PRECONDITION
+	// unless stored port has that revision.
+	ExpectedRevision int64 `protobuf:"varint,3,opt,name=expected_revision,json=expectedRevision,proto3" json:"expected_revision,omitempty"`
+}
+
+func (m *UpdatePortRequest) Reset()         { *m = UpdatePortRequest{} }
+func (m *UpdatePortRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdatePortRequest) ProtoMessage()    {}
+func (*UpdatePortRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{2}
+}
+func (m *UpdatePortRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UpdatePortRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UpdatePortRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *UpdatePortRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdatePortRequest.Merge(m, src)
+}
+func (m *UpdatePortRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *UpdatePortRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdatePortRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdatePortRequest proto.InternalMessageInfo
+
+func (m *UpdatePortRequest) GetPort() *Port {
+	if m != nil {
+		return m.Port
+	}
+	return nil
+}
+
+func (m *UpdatePortRequest) GetUpdateMask() *types.FieldMask {
+	if m != nil {
+		return m.UpdateMask
+	}
+	return nil
+}
+
+func (m *UpdatePortRequest) GetExpectedRevision() int64 {
+	if m != nil {
+		return m.ExpectedRevision
+	}
+	return 0
+}
+
+// LookupRequest key is UN/LOCODE for GetByUnlocode and alternative name for GetByAlias.
+type LookupRequest struct {
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (m *LookupRequest) Reset()         { *m = LookupRequest{} }
+func (m *LookupRequest) String() string { return proto.CompactTextString(m) }
+func (*LookupRequest) ProtoMessage()    {}
+func (*LookupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{3}
+}
+func (m *LookupRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LookupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LookupRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LookupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LookupRequest.Merge(m, src)
+}
+func (m *LookupRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *LookupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LookupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LookupRequest proto.InternalMessageInfo
+
+func (m *LookupRequest) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+// PortLookup holds port found by lookup, or candidates if several ports match and none is picked.
+type PortLookup struct {
+	Port       *Port   `protobuf:"bytes,1,opt,name=port,proto3" json:"port,omitempty"`
+	Candidates []*Port `protobuf:"bytes,2,rep,name=candidates,proto3" json:"candidates,omitempty"`
+}
+
+func (m *PortLookup) Reset()         { *m = PortLookup{} }
+func (m *PortLookup) String() string { return proto.CompactTextString(m) }
+func (*PortLookup) ProtoMessage()    {}
+func (*PortLookup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{4}
+}
+func (m *PortLookup) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PortLookup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PortLookup.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PortLookup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PortLookup.Merge(m, src)
+}
+func (m *PortLookup) XXX_Size() int {
+	return m.Size()
+}
+func (m *PortLookup) XXX_DiscardUnknown() {
+	xxx_messageInfo_PortLookup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PortLookup proto.InternalMessageInfo
+
+func (m *PortLookup) GetPort() *Port {
+	if m != nil {
+		return m.Port
+	}
+	return nil
+}
+
+func (m *PortLookup) GetCandidates() []*Port {
+	if m != nil {
+		return m.Candidates
+	}
+	return nil
+}
+
 type Location struct {
 	Latitude  float64 `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
 	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
@@ -162,7 +421,7 @@ func (m *Location) Reset()         { *m = Location{} }
 func (m *Location) String() string { return proto.CompactTextString(m) }
 func (*Location) ProtoMessage()    {}
 func (*Location) Descriptor() ([]byte, []int) {
-	return fileDescriptor_775be50694b55d8f, []int{1}
+	return fileDescriptor_775be50694b55d8f, []int{5}
 }
 func (m *Location) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -207,13 +466,18 @@ func (m *Location) GetLongitude() float64 {
 
 type PortRequest struct {
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// as_of makes Get return port as it was at the moment, current port is returned if it is not set.
+	AsOf *time.Time `protobuf:"bytes,2,opt,name=as_of,json=asOf,proto3,stdtime" json:"as_of,omitempty"`
+	// expected_revision other than 0 makes Delete fail with FAILED_PRECONDITION
+	// unless stored port has that revision.
+	ExpectedRevision int64 `protobuf:"varint,3,opt,name=expected_revision,json=expectedRevision,proto3" json:"expected_revision,omitempty"`
 }
 
 func (m *PortRequest) Reset()         { *m = PortRequest{} }
 func (m *PortRequest) String() string { return proto.CompactTextString(m) }
 func (*PortRequest) ProtoMessage()    {}
 func (*PortRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_775be50694b55d8f, []int{2}
+	return fileDescriptor_775be50694b55d8f, []int{6}
 }
 func (m *PortRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -249,441 +513,6311 @@ func (m *PortRequest) GetId() string {
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*Port)(nil), "ports.Port")
-	proto.RegisterType((*Location)(nil), "ports.Location")
-	proto.RegisterType((*PortRequest)(nil), "ports.PortRequest")
+func (m *PortRequest) GetAsOf() *time.Time {
+	if m != nil {
+		return m.AsOf
+	}
+	return nil
 }
 
-func init() { proto.RegisterFile("pkg/proto/ports.proto", fileDescriptor_775be50694b55d8f) }
-
-var fileDescriptor_775be50694b55d8f = []byte{
-	// 414 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x64, 0x52, 0xbd, 0x8e, 0xd4, 0x30,
-	0x10, 0x4e, 0xb2, 0x3f, 0xb7, 0x3b, 0x91, 0x40, 0xb2, 0xe0, 0x64, 0x05, 0x88, 0x96, 0x54, 0xdb,
-	0x5c, 0x56, 0x1c, 0xd7, 0xd1, 0x21, 0x10, 0x0d, 0x05, 0x0a, 0x1d, 0x15, 0xd9, 0xc4, 0x18, 0x8b,
-	0xc4, 0x13, 0x6c, 0xe7, 0xa4, 0xe5, 0x29, 0x78, 0x03, 0x5e, 0x87, 0xf2, 0x4a, 0x4a, 0xb4, 0xfb,
-	0x22, 0xc8, 0x76, 0xb2, 0x17, 0x89, 0x2a, 0xf3, 0xfd, 0x78, 0x26, 0xf3, 0x03, 0x8f, 0xbb, 0x6f,
-	0x7c, 0xd7, 0x29, 0x34, 0xb8, 0xeb, 0x50, 0x19, 0x9d, 0xbb, 0x98, 0x2c, 0x1c, 0x48, 0xae, 0xb8,
-	0x30, 0x5f, 0xfb, 0x7d, 0x5e, 0x61, 0xbb, 0xe3, 0xc8, 0xd1, 0x3b, 0xf7, 0xfd, 0x17, 0x87, 0xfc,
-	0x33, 0x1b, 0xf9, 0x57, 0xc9, 0x13, 0x8e, 0xc8, 0x1b, 0x76, 0xef, 0x62, 0x6d, 0x67, 0x0e, 0x5e,
-	0xcc, 0x7e, 0x45, 0x30, 0xff, 0x80, 0xca, 0x90, 0x07, 0x10, 0x89, 0x9a, 0x86, 0x9b, 0x70, 0xbb,
-	0x2e, 0x22, 0x51, 0x13, 0x02, 0x73, 0x59, 0xb6, 0x8c, 0x46, 0x8e, 0x71, 0xb1, 0xe5, 0x2a, 0x61,
-	0x0e, 0x74, 0xe6, 0x39, 0x1b, 0x13, 0x0a, 0x17, 0x15, 0xf6, 0xd2, 0xa8, 0x03, 0x9d, 0x3b, 0x7a,
-	0x84, 0xe4, 0x11, 0x2c, 0xca, 0x46, 0x94, 0x9a, 0x2e, 0x36, 0xb3, 0xed, 0xba, 0xf0, 0xc0, 0xfa,
-	0x15, 0xe3, 0x02, 0xa5, 0xa6, 0x4b, 0xc7, 0x8f, 0x90, 0xbc, 0x80, 0xb8, 0x42, 0x54, 0xb5, 0x90,
-	0xa5, 0x61, 0x9a, 0x5e, 0x6c, 0xc2, 0x6d, 0x7c, 0xfd, 0x30, 0xf7, 0x03, 0x78, 0x8f, 0x55, 0x69,
-	0x04, 0xca, 0x62, 0xea, 0x21, 0x09, 0xac, 0x3a, 0x85, 0xb7, 0x42, 0x56, 0x8c, 0xae, 0x5c, 0xf5,
-	0x33, 0xb6, 0x9a, 0x11, 0x2d, 0xfb, 0x81, 0x92, 0xd1, 0xb5, 0xd7, 0x46, 0x4c, 0x2e, 0x61, 0xd9,
-	0xcb, 0x06, 0x2b, 0x4d, 0xc1, 0xfd, 0xc3, 0x80, 0x5c, 0x83, 0x58, 0x33, 0x1a, 0x0f, 0x0d, 0x62,
-	0xcd, 0xb2, 0x37, 0xb0, 0x1a, 0x8b, 0xdb, 0x9c, 0x4d, 0x69, 0x84, 0xe9, 0x6b, 0xe6, 0x46, 0x15,
-	0x16, 0x67, 0x4c, 0x9e, 0xc2, 0xba, 0x41, 0xc9, 0xbd, 0x18, 0x39, 0xf1, 0x9e, 0xc8, 0x9e, 0x41,
-	0x6c, 0xc7, 0x5c, 0xb0, 0xef, 0x3d, 0xd3, 0xff, 0x4d, 0xfb, 0xfa, 0x33, 0x2c, 0xac, 0xac, 0xc9,
-	0x15, 0xcc, 0x3f, 0x96, 0xb7, 0x8c, 0xc4, 0x43, 0xdf, 0x96, 0x4d, 0x2e, 0x73, 0xbf, 0xc2, 0x7c,
-	0x5c, 0x61, 0xfe, 0xd6, 0xae, 0x30, 0x0b, 0xc8, 0x16, 0x66, 0xef, 0x98, 0x21, 0x64, 0xe2, 0x1e,
-	0x4a, 0x24, 0xd3, 0x0c, 0x59, 0xf0, 0xfa, 0xd5, 0xef, 0x63, 0x1a, 0xde, 0x1d, 0xd3, 0xf0, 0xef,
-	0x31, 0x0d, 0x7f, 0x9e, 0xd2, 0xe0, 0xee, 0x94, 0x06, 0x7f, 0x4e, 0x69, 0xf0, 0xe9, 0xf9, 0xe4,
-	0x9c, 0x74, 0x77, 0xa3, 0xea, 0x1b, 0x7f, 0x74, 0xbb, 0xf3, 0x11, 0xee, 0x97, 0xee, 0xf3, 0xf2,
-	0x5f, 0x00, 0x00, 0x00, 0xff, 0xff, 0xcb, 0x3d, 0x80, 0x5e, 0x98, 0x02, 0x00, 0x00,
+func (m *PortRequest) GetExpectedRevision() int64 {
+	if m != nil {
+		return m.ExpectedRevision
+	}
+	return 0
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// PortsClient is the client API for Ports service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type PortsClient interface {
-	Save(ctx context.Context, in *Port, opts ...grpc.CallOption) (*types.Empty, error)
-	Get(ctx context.Context, in *PortRequest, opts ...grpc.CallOption) (*Port, error)
+type PortBatch struct {
+	Ports []*Port `protobuf:"bytes,1,rep,name=ports,proto3" json:"ports,omitempty"`
 }
 
-type portsClient struct {
-	cc *grpc.ClientConn
+func (m *PortBatch) Reset()         { *m = PortBatch{} }
+func (m *PortBatch) String() string { return proto.CompactTextString(m) }
+func (*PortBatch) ProtoMessage()    {}
+func (*PortBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{7}
 }
-
-func NewPortsClient(cc *grpc.ClientConn) PortsClient {
-	return &portsClient{cc}
+func (m *PortBatch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-
-func (c *portsClient) Save(ctx context.Context, in *Port, opts ...grpc.CallOption) (*types.Empty, error) {
-	out := new(types.Empty)
-	err := c.cc.Invoke(ctx, "/ports.Ports/Save", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *PortBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PortBatch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return out, nil
+}
+func (m *PortBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PortBatch.Merge(m, src)
+}
+func (m *PortBatch) XXX_Size() int {
+	return m.Size()
+}
+func (m *PortBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_PortBatch.DiscardUnknown(m)
 }
 
-func (c *portsClient) Get(ctx context.Context, in *PortRequest, opts ...grpc.CallOption) (*Port, error) {
-	out := new(Port)
-	err := c.cc.Invoke(ctx, "/ports.Ports/Get", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_PortBatch proto.InternalMessageInfo
+
+func (m *PortBatch) GetPorts() []*Port {
+	if m != nil {
+		return m.Ports
 	}
-	return out, nil
+	return nil
 }
 
-// PortsServer is the server API for Ports service.
-type PortsServer interface {
-	Save(context.Context, *Port) (*types.Empty, error)
-	Get(context.Context, *PortRequest) (*Port, error)
+type BatchFailure struct {
+	Index   int32  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Id      string `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Code    uint32 `protobuf:"varint,3,opt,name=code,proto3" json:"code,omitempty"`
+	Message string `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
 }
 
-// UnimplementedPortsServer can be embedded to have forward compatible implementations.
-type UnimplementedPortsServer struct {
+func (m *BatchFailure) Reset()         { *m = BatchFailure{} }
+func (m *BatchFailure) String() string { return proto.CompactTextString(m) }
+func (*BatchFailure) ProtoMessage()    {}
+func (*BatchFailure) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{8}
 }
-
-func (*UnimplementedPortsServer) Save(ctx context.Context, req *Port) (*types.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Save not implemented")
+func (m *BatchFailure) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (*UnimplementedPortsServer) Get(ctx context.Context, req *PortRequest) (*Port, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+func (m *BatchFailure) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchFailure.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
 }
-
-func RegisterPortsServer(s *grpc.Server, srv PortsServer) {
-	s.RegisterService(&_Ports_serviceDesc, srv)
+func (m *BatchFailure) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchFailure.Merge(m, src)
+}
+func (m *BatchFailure) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchFailure) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchFailure.DiscardUnknown(m)
 }
 
-func _Ports_Save_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Port)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(PortsServer).Save(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/ports.Ports/Save",
+var xxx_messageInfo_BatchFailure proto.InternalMessageInfo
+
+func (m *BatchFailure) GetIndex() int32 {
+	if m != nil {
+		return m.Index
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PortsServer).Save(ctx, req.(*Port))
+	return 0
+}
+
+func (m *BatchFailure) GetId() string {
+	if m != nil {
+		return m.Id
 	}
-	return interceptor(ctx, in, info, handler)
+	return ""
 }
 
-func _Ports_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PortRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *BatchFailure) GetCode() uint32 {
+	if m != nil {
+		return m.Code
 	}
-	if interceptor == nil {
-		return srv.(PortsServer).Get(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/ports.Ports/Get",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PortsServer).Get(ctx, req.(*PortRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-var _Ports_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "ports.Ports",
-	HandlerType: (*PortsServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Save",
-			Handler:    _Ports_Save_Handler,
-		},
-		{
-			MethodName: "Get",
-			Handler:    _Ports_Get_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "pkg/proto/ports.proto",
+	return 0
 }
 
-func (m *Port) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *BatchFailure) GetMessage() string {
+	if m != nil {
+		return m.Message
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *Port) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type BatchResult struct {
+	Failures []*BatchFailure `protobuf:"bytes,1,rep,name=failures,proto3" json:"failures,omitempty"`
+	Inserted int64           `protobuf:"varint,2,opt,name=inserted,proto3" json:"inserted,omitempty"`
+	Updated  int64           `protobuf:"varint,3,opt,name=updated,proto3" json:"updated,omitempty"`
 }
 
-func (m *Port) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Code) > 0 {
-		i -= len(m.Code)
-		copy(dAtA[i:], m.Code)
-		i = encodeVarintPorts(dAtA, i, uint64(len(m.Code)))
-		i--
-		dAtA[i] = 0x5a
-	}
-	if len(m.Unlocs) > 0 {
-		for iNdEx := len(m.Unlocs) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Unlocs[iNdEx])
-			copy(dAtA[i:], m.Unlocs[iNdEx])
-			i = encodeVarintPorts(dAtA, i, uint64(len(m.Unlocs[iNdEx])))
-			i--
-			dAtA[i] = 0x52
+func (m *BatchResult) Reset()         { *m = BatchResult{} }
+func (m *BatchResult) String() string { return proto.CompactTextString(m) }
+func (*BatchResult) ProtoMessage()    {}
+func (*BatchResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{9}
+}
+func (m *BatchResult) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BatchResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BatchResult.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if len(m.Timezone) > 0 {
-		i -= len(m.Timezone)
-		copy(dAtA[i:], m.Timezone)
-		i = encodeVarintPorts(dAtA, i, uint64(len(m.Timezone)))
-		i--
-		dAtA[i] = 0x4a
+}
+func (m *BatchResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchResult.Merge(m, src)
+}
+func (m *BatchResult) XXX_Size() int {
+	return m.Size()
+}
+func (m *BatchResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchResult.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchResult proto.InternalMessageInfo
+
+func (m *BatchResult) GetFailures() []*BatchFailure {
+	if m != nil {
+		return m.Failures
 	}
-	if len(m.Province) > 0 {
-		i -= len(m.Province)
-		copy(dAtA[i:], m.Province)
-		i = encodeVarintPorts(dAtA, i, uint64(len(m.Province)))
-		i--
-		dAtA[i] = 0x42
+	return nil
+}
+
+func (m *BatchResult) GetInserted() int64 {
+	if m != nil {
+		return m.Inserted
 	}
-	if m.Coordinates != nil {
-		{
-			size, err := m.Coordinates.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintPorts(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x3a
+	return 0
+}
+
+func (m *BatchResult) GetUpdated() int64 {
+	if m != nil {
+		return m.Updated
 	}
-	if len(m.Regions) > 0 {
-		for iNdEx := len(m.Regions) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Regions[iNdEx])
-			copy(dAtA[i:], m.Regions[iNdEx])
-			i = encodeVarintPorts(dAtA, i, uint64(len(m.Regions[iNdEx])))
-			i--
-			dAtA[i] = 0x32
+	return 0
+}
+
+type ImportSummary struct {
+	Inserted       int64            `protobuf:"varint,1,opt,name=inserted,proto3" json:"inserted,omitempty"`
+	Updated        int64            `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	Rejected       int64            `protobuf:"varint,3,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	Total          int64            `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Failures       []*BatchFailure  `protobuf:"bytes,5,rep,name=failures,proto3" json:"failures,omitempty"`
+	RejectedByCode map[string]int64 `protobuf:"bytes,6,rep,name=rejected_by_code,json=rejectedByCode,proto3" json:"rejected_by_code,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+}
+
+func (m *ImportSummary) Reset()         { *m = ImportSummary{} }
+func (m *ImportSummary) String() string { return proto.CompactTextString(m) }
+func (*ImportSummary) ProtoMessage()    {}
+func (*ImportSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{10}
+}
+func (m *ImportSummary) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ImportSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ImportSummary.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if len(m.Alias) > 0 {
-		for iNdEx := len(m.Alias) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Alias[iNdEx])
-			copy(dAtA[i:], m.Alias[iNdEx])
-			i = encodeVarintPorts(dAtA, i, uint64(len(m.Alias[iNdEx])))
-			i--
-			dAtA[i] = 0x2a
-		}
+}
+func (m *ImportSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ImportSummary.Merge(m, src)
+}
+func (m *ImportSummary) XXX_Size() int {
+	return m.Size()
+}
+func (m *ImportSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_ImportSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ImportSummary proto.InternalMessageInfo
+
+func (m *ImportSummary) GetInserted() int64 {
+	if m != nil {
+		return m.Inserted
 	}
-	if len(m.Country) > 0 {
-		i -= len(m.Country)
-		copy(dAtA[i:], m.Country)
-		i = encodeVarintPorts(dAtA, i, uint64(len(m.Country)))
-		i--
-		dAtA[i] = 0x22
+	return 0
+}
+
+func (m *ImportSummary) GetUpdated() int64 {
+	if m != nil {
+		return m.Updated
 	}
-	if len(m.City) > 0 {
-		i -= len(m.City)
-		copy(dAtA[i:], m.City)
-		i = encodeVarintPorts(dAtA, i, uint64(len(m.City)))
-		i--
-		dAtA[i] = 0x1a
+	return 0
+}
+
+func (m *ImportSummary) GetRejected() int64 {
+	if m != nil {
+		return m.Rejected
 	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintPorts(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0x12
+	return 0
+}
+
+func (m *ImportSummary) GetTotal() int64 {
+	if m != nil {
+		return m.Total
 	}
-	if len(m.Id) > 0 {
-		i -= len(m.Id)
-		copy(dAtA[i:], m.Id)
-		i = encodeVarintPorts(dAtA, i, uint64(len(m.Id)))
-		i--
-		dAtA[i] = 0xa
+	return 0
+}
+
+func (m *ImportSummary) GetFailures() []*BatchFailure {
+	if m != nil {
+		return m.Failures
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *Location) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ImportSummary) GetRejectedByCode() map[string]int64 {
+	if m != nil {
+		return m.RejectedByCode
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Location) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type ListRequest struct {
+	Cursor string        `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit  int32         `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Filter *SearchFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
 }
 
-func (m *Location) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Longitude != 0 {
-		i -= 8
-		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Longitude))))
-		i--
-		dAtA[i] = 0x11
+func (m *ListRequest) Reset()         { *m = ListRequest{} }
+func (m *ListRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRequest) ProtoMessage()    {}
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{11}
+}
+func (m *ListRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ListRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ListRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.Latitude != 0 {
-		i -= 8
-		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Latitude))))
-		i--
-		dAtA[i] = 0x9
+}
+func (m *ListRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRequest.Merge(m, src)
+}
+func (m *ListRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *ListRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRequest proto.InternalMessageInfo
+
+func (m *ListRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
 	}
-	return len(dAtA) - i, nil
+	return ""
 }
 
-func (m *PortRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ListRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *PortRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ListRequest) GetFilter() *SearchFilter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
 }
 
-func (m *PortRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Id) > 0 {
-		i -= len(m.Id)
-		copy(dAtA[i:], m.Id)
-		i = encodeVarintPorts(dAtA, i, uint64(len(m.Id)))
-		i--
-		dAtA[i] = 0xa
-	}
-	return len(dAtA) - i, nil
+type SearchFilter struct {
+	Country  string `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	Province string `protobuf:"bytes,2,opt,name=province,proto3" json:"province,omitempty"`
+	City     string `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
+	Region   string `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	Timezone string `protobuf:"bytes,5,opt,name=timezone,proto3" json:"timezone,omitempty"`
 }
 
-func encodeVarintPorts(dAtA []byte, offset int, v uint64) int {
-	offset -= sovPorts(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *SearchFilter) Reset()         { *m = SearchFilter{} }
+func (m *SearchFilter) String() string { return proto.CompactTextString(m) }
+func (*SearchFilter) ProtoMessage()    {}
+func (*SearchFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{12}
+}
+func (m *SearchFilter) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SearchFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SearchFilter.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	dAtA[offset] = uint8(v)
-	return base
 }
-func (m *Port) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *SearchFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchFilter.Merge(m, src)
+}
+func (m *SearchFilter) XXX_Size() int {
+	return m.Size()
+}
+func (m *SearchFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchFilter proto.InternalMessageInfo
+
+func (m *SearchFilter) GetCountry() string {
+	if m != nil {
+		return m.Country
 	}
-	var l int
-	_ = l
-	l = len(m.Id)
-	if l > 0 {
-		n += 1 + l + sovPorts(uint64(l))
+	return ""
+}
+
+func (m *SearchFilter) GetProvince() string {
+	if m != nil {
+		return m.Province
 	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovPorts(uint64(l))
+	return ""
+}
+
+func (m *SearchFilter) GetCity() string {
+	if m != nil {
+		return m.City
 	}
-	l = len(m.City)
-	if l > 0 {
-		n += 1 + l + sovPorts(uint64(l))
+	return ""
+}
+
+func (m *SearchFilter) GetRegion() string {
+	if m != nil {
+		return m.Region
 	}
-	l = len(m.Country)
-	if l > 0 {
-		n += 1 + l + sovPorts(uint64(l))
+	return ""
+}
+
+func (m *SearchFilter) GetTimezone() string {
+	if m != nil {
+		return m.Timezone
 	}
-	if len(m.Alias) > 0 {
-		for _, s := range m.Alias {
-			l = len(s)
-			n += 1 + l + sovPorts(uint64(l))
+	return ""
+}
+
+type PortPage struct {
+	Ports      []*Port `protobuf:"bytes,1,rep,name=ports,proto3" json:"ports,omitempty"`
+	NextCursor string  `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+}
+
+func (m *PortPage) Reset()         { *m = PortPage{} }
+func (m *PortPage) String() string { return proto.CompactTextString(m) }
+func (*PortPage) ProtoMessage()    {}
+func (*PortPage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{13}
+}
+func (m *PortPage) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PortPage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PortPage.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if len(m.Regions) > 0 {
-		for _, s := range m.Regions {
-			l = len(s)
-			n += 1 + l + sovPorts(uint64(l))
+}
+func (m *PortPage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PortPage.Merge(m, src)
+}
+func (m *PortPage) XXX_Size() int {
+	return m.Size()
+}
+func (m *PortPage) XXX_DiscardUnknown() {
+	xxx_messageInfo_PortPage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PortPage proto.InternalMessageInfo
+
+func (m *PortPage) GetPorts() []*Port {
+	if m != nil {
+		return m.Ports
+	}
+	return nil
+}
+
+func (m *PortPage) GetNextCursor() string {
+	if m != nil {
+		return m.NextCursor
+	}
+	return ""
+}
+
+type NearbyRequest struct {
+	Point    *Location `protobuf:"bytes,1,opt,name=point,proto3" json:"point,omitempty"`
+	RadiusKm float64   `protobuf:"fixed64,2,opt,name=radius_km,json=radiusKm,proto3" json:"radius_km,omitempty"`
+	Limit    int32     `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (m *NearbyRequest) Reset()         { *m = NearbyRequest{} }
+func (m *NearbyRequest) String() string { return proto.CompactTextString(m) }
+func (*NearbyRequest) ProtoMessage()    {}
+func (*NearbyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{14}
+}
+func (m *NearbyRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NearbyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NearbyRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if m.Coordinates != nil {
-		l = m.Coordinates.Size()
-		n += 1 + l + sovPorts(uint64(l))
+}
+func (m *NearbyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NearbyRequest.Merge(m, src)
+}
+func (m *NearbyRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *NearbyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_NearbyRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NearbyRequest proto.InternalMessageInfo
+
+func (m *NearbyRequest) GetPoint() *Location {
+	if m != nil {
+		return m.Point
 	}
-	l = len(m.Province)
-	if l > 0 {
-		n += 1 + l + sovPorts(uint64(l))
+	return nil
+}
+
+func (m *NearbyRequest) GetRadiusKm() float64 {
+	if m != nil {
+		return m.RadiusKm
 	}
-	l = len(m.Timezone)
-	if l > 0 {
-		n += 1 + l + sovPorts(uint64(l))
+	return 0
+}
+
+func (m *NearbyRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
 	}
-	if len(m.Unlocs) > 0 {
-		for _, s := range m.Unlocs {
-			l = len(s)
-			n += 1 + l + sovPorts(uint64(l))
+	return 0
+}
+
+type NearbyPort struct {
+	Port       *Port   `protobuf:"bytes,1,opt,name=port,proto3" json:"port,omitempty"`
+	DistanceKm float64 `protobuf:"fixed64,2,opt,name=distance_km,json=distanceKm,proto3" json:"distance_km,omitempty"`
+}
+
+func (m *NearbyPort) Reset()         { *m = NearbyPort{} }
+func (m *NearbyPort) String() string { return proto.CompactTextString(m) }
+func (*NearbyPort) ProtoMessage()    {}
+func (*NearbyPort) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{15}
+}
+func (m *NearbyPort) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NearbyPort) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NearbyPort.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	l = len(m.Code)
-	if l > 0 {
-		n += 1 + l + sovPorts(uint64(l))
+}
+func (m *NearbyPort) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NearbyPort.Merge(m, src)
+}
+func (m *NearbyPort) XXX_Size() int {
+	return m.Size()
+}
+func (m *NearbyPort) XXX_DiscardUnknown() {
+	xxx_messageInfo_NearbyPort.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NearbyPort proto.InternalMessageInfo
+
+func (m *NearbyPort) GetPort() *Port {
+	if m != nil {
+		return m.Port
 	}
-	return n
+	return nil
 }
 
-func (m *Location) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *NearbyPort) GetDistanceKm() float64 {
+	if m != nil {
+		return m.DistanceKm
 	}
-	var l int
-	_ = l
-	if m.Latitude != 0 {
-		n += 9
+	return 0
+}
+
+type NearbyPorts struct {
+	Ports []*NearbyPort `protobuf:"bytes,1,rep,name=ports,proto3" json:"ports,omitempty"`
+}
+
+func (m *NearbyPorts) Reset()         { *m = NearbyPorts{} }
+func (m *NearbyPorts) String() string { return proto.CompactTextString(m) }
+func (*NearbyPorts) ProtoMessage()    {}
+func (*NearbyPorts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{16}
+}
+func (m *NearbyPorts) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *NearbyPorts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_NearbyPorts.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.Longitude != 0 {
-		n += 9
+}
+func (m *NearbyPorts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NearbyPorts.Merge(m, src)
+}
+func (m *NearbyPorts) XXX_Size() int {
+	return m.Size()
+}
+func (m *NearbyPorts) XXX_DiscardUnknown() {
+	xxx_messageInfo_NearbyPorts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_NearbyPorts proto.InternalMessageInfo
+
+func (m *NearbyPorts) GetPorts() []*NearbyPort {
+	if m != nil {
+		return m.Ports
+	}
+	return nil
+}
+
+// SearchRequest query is matched against port name, city and alias, typos are tolerated.
+type SearchRequest struct {
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Limit int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (m *SearchRequest) Reset()         { *m = SearchRequest{} }
+func (m *SearchRequest) String() string { return proto.CompactTextString(m) }
+func (*SearchRequest) ProtoMessage()    {}
+func (*SearchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{17}
+}
+func (m *SearchRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SearchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SearchRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SearchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SearchRequest.Merge(m, src)
+}
+func (m *SearchRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SearchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SearchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SearchRequest proto.InternalMessageInfo
+
+func (m *SearchRequest) GetQuery() string {
+	if m != nil {
+		return m.Query
+	}
+	return ""
+}
+
+func (m *SearchRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+type MatchedPort struct {
+	Port  *Port   `protobuf:"bytes,1,opt,name=port,proto3" json:"port,omitempty"`
+	Score float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+}
+
+func (m *MatchedPort) Reset()         { *m = MatchedPort{} }
+func (m *MatchedPort) String() string { return proto.CompactTextString(m) }
+func (*MatchedPort) ProtoMessage()    {}
+func (*MatchedPort) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{18}
+}
+func (m *MatchedPort) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MatchedPort) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MatchedPort.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MatchedPort) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MatchedPort.Merge(m, src)
+}
+func (m *MatchedPort) XXX_Size() int {
+	return m.Size()
+}
+func (m *MatchedPort) XXX_DiscardUnknown() {
+	xxx_messageInfo_MatchedPort.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MatchedPort proto.InternalMessageInfo
+
+func (m *MatchedPort) GetPort() *Port {
+	if m != nil {
+		return m.Port
+	}
+	return nil
+}
+
+func (m *MatchedPort) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+type MatchedPorts struct {
+	Ports []*MatchedPort `protobuf:"bytes,1,rep,name=ports,proto3" json:"ports,omitempty"`
+}
+
+func (m *MatchedPorts) Reset()         { *m = MatchedPorts{} }
+func (m *MatchedPorts) String() string { return proto.CompactTextString(m) }
+func (*MatchedPorts) ProtoMessage()    {}
+func (*MatchedPorts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{19}
+}
+func (m *MatchedPorts) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MatchedPorts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MatchedPorts.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *MatchedPorts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MatchedPorts.Merge(m, src)
+}
+func (m *MatchedPorts) XXX_Size() int {
+	return m.Size()
+}
+func (m *MatchedPorts) XXX_DiscardUnknown() {
+	xxx_messageInfo_MatchedPorts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MatchedPorts proto.InternalMessageInfo
+
+func (m *MatchedPorts) GetPorts() []*MatchedPort {
+	if m != nil {
+		return m.Ports
+	}
+	return nil
+}
+
+type BoundingBox struct {
+	MinLon float64 `protobuf:"fixed64,1,opt,name=min_lon,json=minLon,proto3" json:"min_lon,omitempty"`
+	MinLat float64 `protobuf:"fixed64,2,opt,name=min_lat,json=minLat,proto3" json:"min_lat,omitempty"`
+	MaxLon float64 `protobuf:"fixed64,3,opt,name=max_lon,json=maxLon,proto3" json:"max_lon,omitempty"`
+	MaxLat float64 `protobuf:"fixed64,4,opt,name=max_lat,json=maxLat,proto3" json:"max_lat,omitempty"`
+}
+
+func (m *BoundingBox) Reset()         { *m = BoundingBox{} }
+func (m *BoundingBox) String() string { return proto.CompactTextString(m) }
+func (*BoundingBox) ProtoMessage()    {}
+func (*BoundingBox) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{20}
+}
+func (m *BoundingBox) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BoundingBox) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BoundingBox.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BoundingBox) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BoundingBox.Merge(m, src)
+}
+func (m *BoundingBox) XXX_Size() int {
+	return m.Size()
+}
+func (m *BoundingBox) XXX_DiscardUnknown() {
+	xxx_messageInfo_BoundingBox.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BoundingBox proto.InternalMessageInfo
+
+func (m *BoundingBox) GetMinLon() float64 {
+	if m != nil {
+		return m.MinLon
+	}
+	return 0
+}
+
+func (m *BoundingBox) GetMinLat() float64 {
+	if m != nil {
+		return m.MinLat
+	}
+	return 0
+}
+
+func (m *BoundingBox) GetMaxLon() float64 {
+	if m != nil {
+		return m.MaxLon
+	}
+	return 0
+}
+
+func (m *BoundingBox) GetMaxLat() float64 {
+	if m != nil {
+		return m.MaxLat
+	}
+	return 0
+}
+
+type WatchRequest struct {
+	// from_sequence is the first streamed sequence, 0 streams only changes made after the request.
+	// Watch is resumed from the last received sequence + 1.
+	FromSequence int64 `protobuf:"varint,1,opt,name=from_sequence,json=fromSequence,proto3" json:"from_sequence,omitempty"`
+}
+
+func (m *WatchRequest) Reset()         { *m = WatchRequest{} }
+func (m *WatchRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchRequest) ProtoMessage()    {}
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{21}
+}
+func (m *WatchRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *WatchRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_WatchRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *WatchRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchRequest.Merge(m, src)
+}
+func (m *WatchRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *WatchRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchRequest proto.InternalMessageInfo
+
+func (m *WatchRequest) GetFromSequence() int64 {
+	if m != nil {
+		return m.FromSequence
+	}
+	return 0
+}
+
+type PortEvent struct {
+	// sequence orders changes of all ports, it is not related to revision of the port.
+	Sequence int64        `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Op       PortEvent_Op `protobuf:"varint,2,opt,name=op,proto3,enum=ports.PortEvent_Op" json:"op,omitempty"`
+	Port     *Port        `protobuf:"bytes,3,opt,name=port,proto3" json:"port,omitempty"`
+}
+
+func (m *PortEvent) Reset()         { *m = PortEvent{} }
+func (m *PortEvent) String() string { return proto.CompactTextString(m) }
+func (*PortEvent) ProtoMessage()    {}
+func (*PortEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{22}
+}
+func (m *PortEvent) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PortEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PortEvent.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PortEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PortEvent.Merge(m, src)
+}
+func (m *PortEvent) XXX_Size() int {
+	return m.Size()
+}
+func (m *PortEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_PortEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PortEvent proto.InternalMessageInfo
+
+func (m *PortEvent) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *PortEvent) GetOp() PortEvent_Op {
+	if m != nil {
+		return m.Op
+	}
+	return PortEvent_UNKNOWN
+}
+
+func (m *PortEvent) GetPort() *Port {
+	if m != nil {
+		return m.Port
+	}
+	return nil
+}
+
+type PortChange struct {
+	// sequence is the one of PortEvent of the same change.
+	Sequence int64        `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Op       PortEvent_Op `protobuf:"varint,2,opt,name=op,proto3,enum=ports.PortEvent_Op" json:"op,omitempty"`
+	// previous is the port before change, it is not set for created ports.
+	Previous *Port `protobuf:"bytes,3,opt,name=previous,proto3" json:"previous,omitempty"`
+	// source is loader file name, gRPC caller or HTTP request id which made the change.
+	Source    string    `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	ChangedAt time.Time `protobuf:"bytes,5,opt,name=changed_at,json=changedAt,proto3,stdtime" json:"changed_at"`
+}
+
+func (m *PortChange) Reset()         { *m = PortChange{} }
+func (m *PortChange) String() string { return proto.CompactTextString(m) }
+func (*PortChange) ProtoMessage()    {}
+func (*PortChange) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{23}
+}
+func (m *PortChange) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PortChange) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PortChange.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PortChange) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PortChange.Merge(m, src)
+}
+func (m *PortChange) XXX_Size() int {
+	return m.Size()
+}
+func (m *PortChange) XXX_DiscardUnknown() {
+	xxx_messageInfo_PortChange.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PortChange proto.InternalMessageInfo
+
+func (m *PortChange) GetSequence() int64 {
+	if m != nil {
+		return m.Sequence
+	}
+	return 0
+}
+
+func (m *PortChange) GetOp() PortEvent_Op {
+	if m != nil {
+		return m.Op
+	}
+	return PortEvent_UNKNOWN
+}
+
+func (m *PortChange) GetPrevious() *Port {
+	if m != nil {
+		return m.Previous
+	}
+	return nil
+}
+
+func (m *PortChange) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *PortChange) GetChangedAt() time.Time {
+	if m != nil {
+		return m.ChangedAt
+	}
+	return time.Time{}
+}
+
+type PortHistory struct {
+	Changes []*PortChange `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+func (m *PortHistory) Reset()         { *m = PortHistory{} }
+func (m *PortHistory) String() string { return proto.CompactTextString(m) }
+func (*PortHistory) ProtoMessage()    {}
+func (*PortHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_775be50694b55d8f, []int{24}
+}
+func (m *PortHistory) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PortHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PortHistory.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PortHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PortHistory.Merge(m, src)
+}
+func (m *PortHistory) XXX_Size() int {
+	return m.Size()
+}
+func (m *PortHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_PortHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PortHistory proto.InternalMessageInfo
+
+func (m *PortHistory) GetChanges() []*PortChange {
+	if m != nil {
+		return m.Changes
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("ports.PortEvent_Op", PortEvent_Op_name, PortEvent_Op_value)
+	proto.RegisterType((*Port)(nil), "ports.Port")
+	proto.RegisterType((*SaveRequest)(nil), "ports.SaveRequest")
+	proto.RegisterType((*UpdatePortRequest)(nil), "ports.UpdatePortRequest")
+	proto.RegisterType((*LookupRequest)(nil), "ports.LookupRequest")
+	proto.RegisterType((*PortLookup)(nil), "ports.PortLookup")
+	proto.RegisterType((*Location)(nil), "ports.Location")
+	proto.RegisterType((*PortRequest)(nil), "ports.PortRequest")
+	proto.RegisterType((*PortBatch)(nil), "ports.PortBatch")
+	proto.RegisterType((*BatchFailure)(nil), "ports.BatchFailure")
+	proto.RegisterType((*BatchResult)(nil), "ports.BatchResult")
+	proto.RegisterType((*ImportSummary)(nil), "ports.ImportSummary")
+	proto.RegisterMapType((map[string]int64)(nil), "ports.ImportSummary.RejectedByCodeEntry")
+	proto.RegisterType((*ListRequest)(nil), "ports.ListRequest")
+	proto.RegisterType((*SearchFilter)(nil), "ports.SearchFilter")
+	proto.RegisterType((*PortPage)(nil), "ports.PortPage")
+	proto.RegisterType((*NearbyRequest)(nil), "ports.NearbyRequest")
+	proto.RegisterType((*NearbyPort)(nil), "ports.NearbyPort")
+	proto.RegisterType((*NearbyPorts)(nil), "ports.NearbyPorts")
+	proto.RegisterType((*SearchRequest)(nil), "ports.SearchRequest")
+	proto.RegisterType((*MatchedPort)(nil), "ports.MatchedPort")
+	proto.RegisterType((*MatchedPorts)(nil), "ports.MatchedPorts")
+	proto.RegisterType((*BoundingBox)(nil), "ports.BoundingBox")
+	proto.RegisterType((*WatchRequest)(nil), "ports.WatchRequest")
+	proto.RegisterType((*PortEvent)(nil), "ports.PortEvent")
+	proto.RegisterType((*PortChange)(nil), "ports.PortChange")
+	proto.RegisterType((*PortHistory)(nil), "ports.PortHistory")
+}
+
+func init() { proto.RegisterFile("pkg/proto/ports.proto", fileDescriptor_775be50694b55d8f) }
+
+var fileDescriptor_775be50694b55d8f = []byte{
+	// 1526 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x57, 0x4b, 0x6f, 0x14, 0xc7,
+	0x16, 0x9e, 0xee, 0x79, 0xd8, 0x73, 0xda, 0x36, 0xa6, 0xf0, 0xf5, 0x6d, 0x0d, 0x57, 0xb6, 0x69,
+	0x74, 0xc5, 0x48, 0x88, 0x31, 0xd7, 0x06, 0x2e, 0x82, 0x95, 0x5f, 0x10, 0x84, 0xb1, 0x51, 0x1b,
+	0x84, 0x44, 0x16, 0xa3, 0x72, 0x77, 0xcd, 0xb8, 0xe2, 0xe9, 0xae, 0xa1, 0xab, 0xda, 0xf2, 0x64,
+	0x97, 0x7d, 0x16, 0xfc, 0x82, 0x6c, 0xf3, 0x23, 0xb2, 0xc8, 0x96, 0x25, 0x52, 0x36, 0x59, 0x25,
+	0x11, 0xfc, 0x91, 0xa8, 0x1e, 0xdd, 0xd3, 0xf3, 0xb0, 0x30, 0xca, 0x6a, 0xfa, 0x3b, 0xaf, 0x3a,
+	0xe7, 0xd4, 0x79, 0xd4, 0xc0, 0xbf, 0xfa, 0xa7, 0xdd, 0xf5, 0x7e, 0xc2, 0x04, 0x5b, 0xef, 0xb3,
+	0x44, 0xf0, 0x96, 0xfa, 0x46, 0x55, 0x05, 0x1a, 0x77, 0xba, 0x54, 0x9c, 0xa4, 0xc7, 0xad, 0x80,
+	0x45, 0xeb, 0x5d, 0xd6, 0x65, 0x5a, 0xf2, 0x38, 0xed, 0x28, 0xa4, 0xd5, 0xe4, 0x97, 0xd6, 0x6a,
+	0x5c, 0xef, 0x32, 0xd6, 0xed, 0x91, 0xa1, 0x14, 0x89, 0xfa, 0x62, 0x60, 0x98, 0x6b, 0xe3, 0xcc,
+	0x0e, 0x25, 0xbd, 0xb0, 0x1d, 0x61, 0x7e, 0x6a, 0x24, 0x56, 0xc7, 0x25, 0x04, 0x8d, 0x08, 0x17,
+	0x38, 0xea, 0x6b, 0x01, 0xef, 0x17, 0x1b, 0x2a, 0x2f, 0x59, 0x22, 0xd0, 0x02, 0xd8, 0x34, 0x74,
+	0xad, 0x35, 0xab, 0x59, 0xf7, 0x6d, 0x1a, 0x22, 0x04, 0x95, 0x18, 0x47, 0xc4, 0xb5, 0x15, 0x45,
+	0x7d, 0x4b, 0x5a, 0x40, 0xc5, 0xc0, 0x2d, 0x6b, 0x9a, 0xfc, 0x46, 0x2e, 0xcc, 0x04, 0x2c, 0x8d,
+	0x45, 0x32, 0x70, 0x2b, 0x8a, 0x9c, 0x41, 0xb4, 0x04, 0x55, 0xdc, 0xa3, 0x98, 0xbb, 0xd5, 0xb5,
+	0x72, 0xb3, 0xee, 0x6b, 0x20, 0xe5, 0x13, 0xd2, 0xa5, 0x2c, 0xe6, 0x6e, 0x4d, 0xd1, 0x33, 0x88,
+	0xfe, 0x07, 0x4e, 0xc0, 0x58, 0x12, 0xd2, 0x18, 0x0b, 0xc2, 0xdd, 0x99, 0x35, 0xab, 0xe9, 0x6c,
+	0x5c, 0x69, 0xe9, 0x1c, 0xee, 0xb3, 0x00, 0x0b, 0xca, 0x62, 0xbf, 0x28, 0x83, 0x1a, 0x30, 0xdb,
+	0x4f, 0xd8, 0x19, 0x8d, 0x03, 0xe2, 0xce, 0xaa, 0xd3, 0x73, 0x2c, 0x79, 0x32, 0xd8, 0xef, 0x59,
+	0x4c, 0xdc, 0xba, 0xe6, 0x65, 0x18, 0x2d, 0x43, 0x2d, 0x8d, 0x7b, 0x2c, 0xe0, 0x2e, 0x28, 0x1f,
+	0x0c, 0x52, 0x01, 0xb2, 0x90, 0xb8, 0x8e, 0x09, 0x90, 0x85, 0xca, 0x4e, 0x42, 0xce, 0x28, 0xa7,
+	0x2c, 0x76, 0xe7, 0xd6, 0xac, 0x66, 0xd9, 0xcf, 0xb1, 0xf7, 0x2d, 0x38, 0x47, 0xf8, 0x8c, 0xf8,
+	0xe4, 0x5d, 0x4a, 0xb8, 0x40, 0xab, 0x50, 0x91, 0xde, 0xaa, 0x2c, 0x3a, 0x1b, 0x8e, 0x71, 0x5d,
+	0xa6, 0xd7, 0x57, 0x0c, 0x74, 0x1b, 0xae, 0x92, 0xf3, 0x3e, 0x09, 0x04, 0x09, 0xdb, 0xb9, 0x51,
+	0x5b, 0x19, 0x5d, 0xcc, 0x18, 0x7e, 0x66, 0xfc, 0x27, 0x0b, 0xae, 0xbe, 0xee, 0x87, 0x58, 0x10,
+	0x65, 0xe1, 0xb2, 0x67, 0x3c, 0x06, 0x27, 0x55, 0x5a, 0xaa, 0x0e, 0x94, 0x75, 0x67, 0xa3, 0xd1,
+	0xd2, 0x85, 0xd0, 0xca, 0x0a, 0xa1, 0xf5, 0x44, 0x96, 0xca, 0x0b, 0xcc, 0x4f, 0x7d, 0xd0, 0xe2,
+	0xf2, 0x7b, 0xba, 0x83, 0xe5, 0x0b, 0x1c, 0xbc, 0x01, 0xf3, 0xfb, 0x8c, 0x9d, 0xa6, 0xfd, 0xcc,
+	0xb7, 0x45, 0x28, 0x9f, 0x92, 0x81, 0x29, 0x22, 0xf9, 0xe9, 0xbd, 0x05, 0x90, 0xae, 0x69, 0xb1,
+	0xcb, 0xe4, 0x07, 0x02, 0x1c, 0x87, 0x34, 0x54, 0x15, 0x60, 0xaf, 0x95, 0xc7, 0xc5, 0x0a, 0x6c,
+	0x6f, 0x17, 0x66, 0xb3, 0xaa, 0x90, 0x97, 0xd4, 0xc3, 0x82, 0x8a, 0x34, 0x24, 0xca, 0xba, 0xe5,
+	0xe7, 0x18, 0xfd, 0x07, 0xea, 0x3d, 0x16, 0x77, 0x35, 0xd3, 0x56, 0xcc, 0x21, 0xc1, 0xfb, 0xc1,
+	0x02, 0xa7, 0x98, 0xdf, 0xf1, 0x3e, 0xb8, 0x0f, 0x55, 0xcc, 0xdb, 0xac, 0x73, 0x61, 0x22, 0x5f,
+	0x65, 0x1d, 0xb5, 0x5d, 0x79, 0xff, 0xe7, 0xaa, 0xe5, 0x57, 0x30, 0x3f, 0xec, 0x7c, 0x5d, 0x22,
+	0x5b, 0x50, 0x97, 0x2e, 0x6c, 0x63, 0x11, 0x9c, 0xa0, 0x1b, 0xa0, 0x27, 0x85, 0x6b, 0x4d, 0x86,
+	0xaf, 0x39, 0xde, 0x31, 0xcc, 0x29, 0xd9, 0x27, 0x98, 0xf6, 0xd2, 0x84, 0xc8, 0x4e, 0xa3, 0x71,
+	0x48, 0xce, 0x95, 0xdb, 0x55, 0x5f, 0x03, 0x13, 0x89, 0x5d, 0xec, 0x68, 0x55, 0xdc, 0xd2, 0x8b,
+	0x79, 0x53, 0xdc, 0x2e, 0xcc, 0x44, 0x84, 0x73, 0xdc, 0x25, 0x59, 0xf7, 0x1a, 0xe8, 0x09, 0x70,
+	0xd4, 0x19, 0x3e, 0xe1, 0x69, 0x4f, 0xa0, 0x75, 0x98, 0xed, 0xe8, 0xd3, 0x32, 0xc7, 0xae, 0x19,
+	0xc7, 0x8a, 0x9e, 0xf8, 0xb9, 0x90, 0xbc, 0x11, 0x1a, 0x73, 0x92, 0x08, 0x12, 0x9a, 0x0a, 0xcf,
+	0xb1, 0x3c, 0x55, 0xd7, 0x5c, 0x68, 0x52, 0x92, 0x41, 0xef, 0x57, 0x1b, 0xe6, 0x9f, 0x45, 0xd2,
+	0xf0, 0x51, 0x1a, 0x45, 0x38, 0x19, 0x8c, 0xd8, 0xb1, 0x2e, 0xb6, 0x63, 0x8f, 0xd8, 0xd1, 0x4d,
+	0xfb, 0x1d, 0x09, 0x86, 0x47, 0xe4, 0x58, 0x66, 0x4b, 0x30, 0x81, 0x7b, 0x2a, 0xe2, 0xb2, 0xaf,
+	0xc1, 0x48, 0x80, 0xd5, 0xcb, 0x04, 0xe8, 0xc3, 0x62, 0x66, 0xb2, 0x7d, 0x3c, 0x68, 0xab, 0xd4,
+	0xd6, 0x94, 0x62, 0xd3, 0x28, 0x8e, 0x04, 0xd2, 0xf2, 0x8d, 0xf0, 0xf6, 0x60, 0x87, 0x85, 0x64,
+	0x4f, 0x8e, 0x48, 0x7f, 0x21, 0x19, 0x21, 0x36, 0xb6, 0xe0, 0xda, 0x14, 0xb1, 0xc9, 0xbe, 0x92,
+	0x31, 0x9c, 0xe1, 0x5e, 0x4a, 0x4c, 0xdc, 0x1a, 0x3c, 0xb2, 0x1f, 0x5a, 0xde, 0x09, 0x38, 0xfb,
+	0x94, 0xe7, 0xe5, 0xbc, 0x0c, 0xb5, 0x20, 0x4d, 0x38, 0x4b, 0x8c, 0xb6, 0x41, 0xd2, 0x40, 0x8f,
+	0x46, 0x54, 0x28, 0x03, 0x55, 0x5f, 0x03, 0x74, 0x1b, 0x6a, 0x1d, 0xda, 0x13, 0x24, 0x51, 0x49,
+	0x1b, 0xa6, 0xe0, 0x88, 0xe0, 0x24, 0x38, 0x79, 0xa2, 0x58, 0xbe, 0x11, 0xf1, 0x7e, 0xb4, 0x60,
+	0xae, 0xc8, 0x28, 0xae, 0x02, 0x6b, 0x74, 0x15, 0x14, 0xe7, 0xb4, 0x3d, 0x36, 0xa7, 0xa7, 0x2d,
+	0x95, 0x65, 0xa8, 0xe9, 0xad, 0x60, 0xaa, 0xd2, 0xa0, 0x91, 0x99, 0x5e, 0x1d, 0x9d, 0xe9, 0xde,
+	0x01, 0xcc, 0xca, 0x1e, 0x79, 0x89, 0xbb, 0xe4, 0x12, 0x3d, 0x84, 0x56, 0xc1, 0x89, 0xc9, 0xb9,
+	0x68, 0x9b, 0xec, 0x68, 0xaf, 0x40, 0x92, 0x76, 0x14, 0xc5, 0xa3, 0x30, 0x7f, 0x40, 0x70, 0x72,
+	0x3c, 0xc8, 0x52, 0xf9, 0x5f, 0x69, 0x94, 0xc6, 0xd9, 0xf8, 0x9a, 0xd8, 0x4c, 0x9a, 0x8b, 0xae,
+	0x43, 0x3d, 0xc1, 0x21, 0x4d, 0x79, 0xfb, 0x34, 0x32, 0xe3, 0x66, 0x56, 0x13, 0x9e, 0x47, 0xc3,
+	0xb4, 0x97, 0x0b, 0x69, 0xf7, 0x0e, 0x00, 0xf4, 0x51, 0x6a, 0x13, 0x7f, 0x71, 0x4a, 0xae, 0x82,
+	0x13, 0x52, 0x2e, 0x70, 0x1c, 0x90, 0xe1, 0x19, 0x90, 0x91, 0x9e, 0x47, 0xde, 0x03, 0x70, 0x86,
+	0xf6, 0x38, 0xba, 0x35, 0x9a, 0x8d, 0xab, 0xc6, 0xe2, 0x50, 0x24, 0x9b, 0x2b, 0x8f, 0x61, 0x5e,
+	0x5f, 0x68, 0x16, 0xf2, 0x12, 0x54, 0xdf, 0xa5, 0x24, 0xbf, 0x4f, 0x0d, 0xa6, 0xd7, 0x8e, 0xb7,
+	0x0b, 0xce, 0x0b, 0xd9, 0x29, 0x24, 0xbc, 0x5c, 0x14, 0x4b, 0x50, 0xe5, 0x01, 0x4b, 0xb2, 0x91,
+	0xac, 0x81, 0xf7, 0x10, 0xe6, 0x0a, 0x56, 0x38, 0x6a, 0x8e, 0xfa, 0x8e, 0x8c, 0x9d, 0x82, 0x4c,
+	0xe6, 0xfc, 0x3b, 0x70, 0xb6, 0x59, 0x1a, 0x87, 0x34, 0xee, 0x6e, 0xb3, 0x73, 0xf4, 0x6f, 0x98,
+	0x89, 0x68, 0xdc, 0xee, 0xb1, 0xd8, 0x2c, 0x84, 0x5a, 0x44, 0xe3, 0x7d, 0x16, 0xe7, 0x0c, 0x2c,
+	0x5c, 0x7b, 0xc8, 0xc0, 0x42, 0x31, 0xf0, 0xb9, 0xd2, 0x28, 0x1b, 0x06, 0x3e, 0xcf, 0x34, 0x24,
+	0x03, 0x0b, 0xb7, 0x32, 0x64, 0x60, 0xe1, 0x6d, 0xc2, 0xdc, 0x1b, 0x2c, 0x86, 0xe9, 0xba, 0x09,
+	0xf3, 0x9d, 0x84, 0x45, 0x6d, 0x2e, 0xb1, 0xac, 0x75, 0x3d, 0xb0, 0xe6, 0x24, 0xf1, 0xc8, 0xd0,
+	0xbc, 0x9f, 0x2d, 0x3d, 0xed, 0xf7, 0xce, 0x48, 0x2c, 0x64, 0x45, 0x8f, 0x49, 0xe7, 0x18, 0xdd,
+	0x04, 0x9b, 0xf5, 0x95, 0x93, 0x0b, 0x79, 0x27, 0xe6, 0x9a, 0xad, 0xc3, 0xbe, 0x6f, 0xb3, 0xe1,
+	0x4e, 0x2d, 0x5f, 0x90, 0x67, 0xef, 0x21, 0xd8, 0x87, 0x7d, 0xe4, 0xc0, 0xcc, 0xeb, 0x83, 0xe7,
+	0x07, 0x87, 0x6f, 0x0e, 0x16, 0x4b, 0x12, 0xec, 0xf8, 0x7b, 0x5b, 0xaf, 0xf6, 0x76, 0x17, 0x2d,
+	0xc5, 0x79, 0xb9, 0xab, 0x80, 0x2d, 0xc1, 0xee, 0xde, 0xfe, 0x9e, 0x04, 0x65, 0xef, 0x37, 0x4b,
+	0x6f, 0xef, 0x9d, 0x13, 0x1c, 0x77, 0xc9, 0x3f, 0x77, 0xf5, 0x96, 0x9c, 0x02, 0xe4, 0x8c, 0xb2,
+	0x94, 0x4f, 0x73, 0x37, 0x67, 0xca, 0xf6, 0xe7, 0x2c, 0x4d, 0x82, 0x6c, 0x29, 0x19, 0x84, 0x76,
+	0x00, 0x02, 0xe5, 0x4b, 0xd8, 0xc6, 0xc2, 0xad, 0x7e, 0x71, 0x21, 0xcf, 0x7e, 0xf8, 0x63, 0xb5,
+	0xa4, 0x96, 0x72, 0xdd, 0xe8, 0x6d, 0x09, 0xef, 0x91, 0xde, 0xf7, 0xdf, 0x50, 0x2e, 0x58, 0x32,
+	0x40, 0xb7, 0x61, 0x46, 0xf3, 0xc6, 0xdb, 0x63, 0x18, 0xb9, 0x9f, 0x49, 0x6c, 0xbc, 0xaf, 0x41,
+	0x55, 0xd7, 0xa5, 0x07, 0x15, 0xf9, 0xf2, 0x43, 0xc5, 0x08, 0x1a, 0x45, 0xe0, 0x95, 0xd0, 0x26,
+	0x2c, 0x48, 0x99, 0x67, 0x9d, 0x6c, 0xd1, 0xa3, 0xac, 0x7c, 0x0b, 0x8f, 0xc6, 0x71, 0xa5, 0x26,
+	0x94, 0x9f, 0x12, 0x81, 0x50, 0x81, 0x7a, 0x81, 0xe4, 0x7d, 0x80, 0xe1, 0xf3, 0x10, 0xb9, 0x86,
+	0x39, 0xf1, 0x62, 0x1c, 0x57, 0x7b, 0x04, 0xf3, 0x4f, 0x89, 0xd8, 0x1e, 0xbc, 0x96, 0x4f, 0x5e,
+	0xf9, 0x06, 0x58, 0xca, 0x07, 0x59, 0xe1, 0x2d, 0xd7, 0x28, 0xa6, 0x41, 0x73, 0xbc, 0x12, 0xfa,
+	0x3f, 0x80, 0xd2, 0xdd, 0x52, 0x4f, 0xf9, 0xaf, 0x50, 0x7c, 0x00, 0xb5, 0x5d, 0xd2, 0x23, 0x82,
+	0x4c, 0x0d, 0x6c, 0x79, 0xe2, 0x0e, 0xf7, 0xe4, 0xbf, 0x1c, 0xaf, 0x84, 0xee, 0x40, 0x45, 0x6e,
+	0xb3, 0x5c, 0xab, 0xb0, 0xda, 0x1a, 0x57, 0x0a, 0x96, 0xe4, 0xd4, 0xf7, 0x4a, 0xe8, 0x1e, 0xd4,
+	0xf4, 0x54, 0xcb, 0x7d, 0x1b, 0x19, 0xe1, 0x0d, 0x34, 0x31, 0xfa, 0xb8, 0x4a, 0x64, 0x4d, 0x8f,
+	0xbd, 0x5c, 0x6b, 0x64, 0x0a, 0x36, 0xae, 0x4d, 0x0e, 0x1d, 0xa9, 0x76, 0x17, 0xea, 0x6f, 0xa8,
+	0x38, 0xa1, 0xb1, 0x1c, 0x37, 0x99, 0xe5, 0xc2, 0x08, 0x1a, 0x4b, 0xfc, 0x5d, 0x0b, 0x6d, 0x42,
+	0x5d, 0xde, 0xbc, 0x7e, 0xe7, 0x2d, 0x16, 0xb8, 0x8a, 0x92, 0x7b, 0x57, 0x78, 0x77, 0xa9, 0x98,
+	0x1c, 0xfd, 0x90, 0xd0, 0x85, 0x37, 0x52, 0x70, 0x4b, 0xd3, 0x5e, 0x1a, 0x5e, 0xa9, 0x69, 0xa1,
+	0x0d, 0xa8, 0xaa, 0xd1, 0x84, 0x32, 0xe7, 0x8b, 0x83, 0xaa, 0xb1, 0x38, 0xde, 0xa2, 0xc6, 0xbd,
+	0x99, 0xac, 0x2b, 0xa6, 0xdd, 0x52, 0x91, 0x66, 0xe4, 0xbc, 0xd2, 0xf6, 0xe3, 0x0f, 0x9f, 0x56,
+	0xac, 0x8f, 0x9f, 0x56, 0xac, 0xbf, 0x3e, 0xad, 0x58, 0xef, 0x3f, 0xaf, 0x94, 0x3e, 0x7e, 0x5e,
+	0x29, 0xfd, 0xfe, 0x79, 0xa5, 0xf4, 0xf6, 0x46, 0xe1, 0x9f, 0x2e, 0xef, 0xdf, 0x4b, 0xc2, 0x7b,
+	0xfa, 0xff, 0xf0, 0x7a, 0xfe, 0xff, 0xf8, 0xb8, 0xa6, 0x7e, 0x36, 0xff, 0x1e, 0x00, 0xfc, 0xef,
+	0x35, 0x87, 0x33, 0x0f, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// PortsClient is the client API for Ports service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type PortsClient interface {
+	// Save responds with stored port, clients expecting google.protobuf.Empty skip its fields.
+	Save(ctx context.Context, in *Port, opts ...grpc.CallOption) (*Port, error)
+	SaveIfRevision(ctx context.Context, in *SaveRequest, opts ...grpc.CallOption) (*Port, error)
+	Get(ctx context.Context, in *PortRequest, opts ...grpc.CallOption) (*Port, error)
+	UpdatePort(ctx context.Context, in *UpdatePortRequest, opts ...grpc.CallOption) (*Port, error)
+	GetByUnlocode(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*PortLookup, error)
+	GetByAlias(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*PortLookup, error)
+	Delete(ctx context.Context, in *PortRequest, opts ...grpc.CallOption) (*types.Empty, error)
+	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*PortPage, error)
+	Nearby(ctx context.Context, in *NearbyRequest, opts ...grpc.CallOption) (*NearbyPorts, error)
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*MatchedPorts, error)
+	WithinBox(ctx context.Context, in *BoundingBox, opts ...grpc.CallOption) (Ports_WithinBoxClient, error)
+	SaveBatch(ctx context.Context, in *PortBatch, opts ...grpc.CallOption) (*BatchResult, error)
+	ImportPorts(ctx context.Context, opts ...grpc.CallOption) (Ports_ImportPortsClient, error)
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Ports_WatchClient, error)
+	History(ctx context.Context, in *PortRequest, opts ...grpc.CallOption) (*PortHistory, error)
+}
+
+type portsClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewPortsClient(cc *grpc.ClientConn) PortsClient {
+	return &portsClient{cc}
+}
+
+func (c *portsClient) Save(ctx context.Context, in *Port, opts ...grpc.CallOption) (*Port, error) {
+	out := new(Port)
+	err := c.cc.Invoke(ctx, "/ports.Ports/Save", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portsClient) SaveIfRevision(ctx context.Context, in *SaveRequest, opts ...grpc.CallOption) (*Port, error) {
+	out := new(Port)
+	err := c.cc.Invoke(ctx, "/ports.Ports/SaveIfRevision", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portsClient) Get(ctx context.Context, in *PortRequest, opts ...grpc.CallOption) (*Port, error) {
+	out := new(Port)
+	err := c.cc.Invoke(ctx, "/ports.Ports/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portsClient) UpdatePort(ctx context.Context, in *UpdatePortRequest, opts ...grpc.CallOption) (*Port, error) {
+	out := new(Port)
+	err := c.cc.Invoke(ctx, "/ports.Ports/UpdatePort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portsClient) GetByUnlocode(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*PortLookup, error) {
+	out := new(PortLookup)
+	err := c.cc.Invoke(ctx, "/ports.Ports/GetByUnlocode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portsClient) GetByAlias(ctx context.Context, in *LookupRequest, opts ...grpc.CallOption) (*PortLookup, error) {
+	out := new(PortLookup)
+	err := c.cc.Invoke(ctx, "/ports.Ports/GetByAlias", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portsClient) Delete(ctx context.Context, in *PortRequest, opts ...grpc.CallOption) (*types.Empty, error) {
+	out := new(types.Empty)
+	err := c.cc.Invoke(ctx, "/ports.Ports/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portsClient) List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*PortPage, error) {
+	out := new(PortPage)
+	err := c.cc.Invoke(ctx, "/ports.Ports/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portsClient) Nearby(ctx context.Context, in *NearbyRequest, opts ...grpc.CallOption) (*NearbyPorts, error) {
+	out := new(NearbyPorts)
+	err := c.cc.Invoke(ctx, "/ports.Ports/Nearby", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portsClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*MatchedPorts, error) {
+	out := new(MatchedPorts)
+	err := c.cc.Invoke(ctx, "/ports.Ports/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portsClient) WithinBox(ctx context.Context, in *BoundingBox, opts ...grpc.CallOption) (Ports_WithinBoxClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Ports_serviceDesc.Streams[0], "/ports.Ports/WithinBox", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &portsWithinBoxClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Ports_WithinBoxClient interface {
+	Recv() (*Port, error)
+	grpc.ClientStream
+}
+
+type portsWithinBoxClient struct {
+	grpc.ClientStream
+}
+
+func (x *portsWithinBoxClient) Recv() (*Port, error) {
+	m := new(Port)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *portsClient) SaveBatch(ctx context.Context, in *PortBatch, opts ...grpc.CallOption) (*BatchResult, error) {
+	out := new(BatchResult)
+	err := c.cc.Invoke(ctx, "/ports.Ports/SaveBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *portsClient) ImportPorts(ctx context.Context, opts ...grpc.CallOption) (Ports_ImportPortsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Ports_serviceDesc.Streams[1], "/ports.Ports/ImportPorts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &portsImportPortsClient{stream}
+	return x, nil
+}
+
+type Ports_ImportPortsClient interface {
+	Send(*Port) error
+	CloseAndRecv() (*ImportSummary, error)
+	grpc.ClientStream
+}
+
+type portsImportPortsClient struct {
+	grpc.ClientStream
+}
+
+func (x *portsImportPortsClient) Send(m *Port) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *portsImportPortsClient) CloseAndRecv() (*ImportSummary, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ImportSummary)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *portsClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (Ports_WatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Ports_serviceDesc.Streams[2], "/ports.Ports/Watch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &portsWatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Ports_WatchClient interface {
+	Recv() (*PortEvent, error)
+	grpc.ClientStream
+}
+
+type portsWatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *portsWatchClient) Recv() (*PortEvent, error) {
+	m := new(PortEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *portsClient) History(ctx context.Context, in *PortRequest, opts ...grpc.CallOption) (*PortHistory, error) {
+	out := new(PortHistory)
+	err := c.cc.Invoke(ctx, "/ports.Ports/History", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PortsServer is the server API for Ports service.
+type PortsServer interface {
+	// Save responds with stored port, clients expecting google.protobuf.Empty skip its fields.
+	Save(context.Context, *Port) (*Port, error)
+	SaveIfRevision(context.Context, *SaveRequest) (*Port, error)
+	Get(context.Context, *PortRequest) (*Port, error)
+	UpdatePort(context.Context, *UpdatePortRequest) (*Port, error)
+	GetByUnlocode(context.Context, *LookupRequest) (*PortLookup, error)
+	GetByAlias(context.Context, *LookupRequest) (*PortLookup, error)
+	Delete(context.Context, *PortRequest) (*types.Empty, error)
+	List(context.Context, *ListRequest) (*PortPage, error)
+	Nearby(context.Context, *NearbyRequest) (*NearbyPorts, error)
+	Search(context.Context, *SearchRequest) (*MatchedPorts, error)
+	WithinBox(*BoundingBox, Ports_WithinBoxServer) error
+	SaveBatch(context.Context, *PortBatch) (*BatchResult, error)
+	ImportPorts(Ports_ImportPortsServer) error
+	Watch(*WatchRequest, Ports_WatchServer) error
+	History(context.Context, *PortRequest) (*PortHistory, error)
+}
+
+// UnimplementedPortsServer can be embedded to have forward compatible implementations.
+type UnimplementedPortsServer struct {
+}
+
+func (*UnimplementedPortsServer) Save(ctx context.Context, req *Port) (*Port, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Save not implemented")
+}
+func (*UnimplementedPortsServer) SaveIfRevision(ctx context.Context, req *SaveRequest) (*Port, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveIfRevision not implemented")
+}
+func (*UnimplementedPortsServer) Get(ctx context.Context, req *PortRequest) (*Port, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (*UnimplementedPortsServer) UpdatePort(ctx context.Context, req *UpdatePortRequest) (*Port, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePort not implemented")
+}
+func (*UnimplementedPortsServer) GetByUnlocode(ctx context.Context, req *LookupRequest) (*PortLookup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByUnlocode not implemented")
+}
+func (*UnimplementedPortsServer) GetByAlias(ctx context.Context, req *LookupRequest) (*PortLookup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByAlias not implemented")
+}
+func (*UnimplementedPortsServer) Delete(ctx context.Context, req *PortRequest) (*types.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (*UnimplementedPortsServer) List(ctx context.Context, req *ListRequest) (*PortPage, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (*UnimplementedPortsServer) Nearby(ctx context.Context, req *NearbyRequest) (*NearbyPorts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Nearby not implemented")
+}
+func (*UnimplementedPortsServer) Search(ctx context.Context, req *SearchRequest) (*MatchedPorts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (*UnimplementedPortsServer) WithinBox(req *BoundingBox, srv Ports_WithinBoxServer) error {
+	return status.Errorf(codes.Unimplemented, "method WithinBox not implemented")
+}
+func (*UnimplementedPortsServer) SaveBatch(ctx context.Context, req *PortBatch) (*BatchResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveBatch not implemented")
+}
+func (*UnimplementedPortsServer) ImportPorts(srv Ports_ImportPortsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportPorts not implemented")
+}
+func (*UnimplementedPortsServer) Watch(req *WatchRequest, srv Ports_WatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
+func (*UnimplementedPortsServer) History(ctx context.Context, req *PortRequest) (*PortHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method History not implemented")
+}
+
+func RegisterPortsServer(s *grpc.Server, srv PortsServer) {
+	s.RegisterService(&_Ports_serviceDesc, srv)
+}
+
+func _Ports_Save_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Port)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortsServer).Save(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ports.Ports/Save",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortsServer).Save(ctx, req.(*Port))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ports_SaveIfRevision_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortsServer).SaveIfRevision(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ports.Ports/SaveIfRevision",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortsServer).SaveIfRevision(ctx, req.(*SaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ports_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortsServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ports.Ports/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortsServer).Get(ctx, req.(*PortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ports_UpdatePort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortsServer).UpdatePort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ports.Ports/UpdatePort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortsServer).UpdatePort(ctx, req.(*UpdatePortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ports_GetByUnlocode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortsServer).GetByUnlocode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ports.Ports/GetByUnlocode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortsServer).GetByUnlocode(ctx, req.(*LookupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ports_GetByAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LookupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortsServer).GetByAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ports.Ports/GetByAlias",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortsServer).GetByAlias(ctx, req.(*LookupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ports_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortsServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ports.Ports/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortsServer).Delete(ctx, req.(*PortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ports_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortsServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ports.Ports/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortsServer).List(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ports_Nearby_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NearbyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortsServer).Nearby(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ports.Ports/Nearby",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortsServer).Nearby(ctx, req.(*NearbyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ports_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortsServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ports.Ports/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortsServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ports_WithinBox_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BoundingBox)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PortsServer).WithinBox(m, &portsWithinBoxServer{stream})
+}
+
+type Ports_WithinBoxServer interface {
+	Send(*Port) error
+	grpc.ServerStream
+}
+
+type portsWithinBoxServer struct {
+	grpc.ServerStream
+}
+
+func (x *portsWithinBoxServer) Send(m *Port) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Ports_SaveBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PortBatch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortsServer).SaveBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ports.Ports/SaveBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortsServer).SaveBatch(ctx, req.(*PortBatch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ports_ImportPorts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PortsServer).ImportPorts(&portsImportPortsServer{stream})
+}
+
+type Ports_ImportPortsServer interface {
+	SendAndClose(*ImportSummary) error
+	Recv() (*Port, error)
+	grpc.ServerStream
+}
+
+type portsImportPortsServer struct {
+	grpc.ServerStream
+}
+
+func (x *portsImportPortsServer) SendAndClose(m *ImportSummary) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *portsImportPortsServer) Recv() (*Port, error) {
+	m := new(Port)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Ports_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PortsServer).Watch(m, &portsWatchServer{stream})
+}
+
+type Ports_WatchServer interface {
+	Send(*PortEvent) error
+	grpc.ServerStream
+}
+
+type portsWatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *portsWatchServer) Send(m *PortEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Ports_History_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PortsServer).History(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ports.Ports/History",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PortsServer).History(ctx, req.(*PortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Ports_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "ports.Ports",
+	HandlerType: (*PortsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Save",
+			Handler:    _Ports_Save_Handler,
+		},
+		{
+			MethodName: "SaveIfRevision",
+			Handler:    _Ports_SaveIfRevision_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _Ports_Get_Handler,
+		},
+		{
+			MethodName: "UpdatePort",
+			Handler:    _Ports_UpdatePort_Handler,
+		},
+		{
+			MethodName: "GetByUnlocode",
+			Handler:    _Ports_GetByUnlocode_Handler,
+		},
+		{
+			MethodName: "GetByAlias",
+			Handler:    _Ports_GetByAlias_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _Ports_Delete_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _Ports_List_Handler,
+		},
+		{
+			MethodName: "Nearby",
+			Handler:    _Ports_Nearby_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _Ports_Search_Handler,
+		},
+		{
+			MethodName: "SaveBatch",
+			Handler:    _Ports_SaveBatch_Handler,
+		},
+		{
+			MethodName: "History",
+			Handler:    _Ports_History_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WithinBox",
+			Handler:       _Ports_WithinBox_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportPorts",
+			Handler:       _Ports_ImportPorts_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Watch",
+			Handler:       _Ports_Watch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/proto/ports.proto",
+}
+
+func (m *Port) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Port) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Port) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Revision != 0 {
+		i = encodeVarintPorts(dAtA, i, uint64(m.Revision))
+		i--
+		dAtA[i] = 0x60
+	}
+	if len(m.Code) > 0 {
+		i -= len(m.Code)
+		copy(dAtA[i:], m.Code)
+		i = encodeVarintPorts(dAtA, i, uint64(len(m.Code)))
+		i--
+		dAtA[i] = 0x5a
+	}
+	if len(m.Unlocs) > 0 {
+		for iNdEx := len(m.Unlocs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Unlocs[iNdEx])
+			copy(dAtA[i:], m.Unlocs[iNdEx])
+			i = encodeVarintPorts(dAtA, i, uint64(len(m.Unlocs[iNdEx])))
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if len(m.Timezone) > 0 {
+		i -= len(m.Timezone)
+		copy(dAtA[i:], m.Timezone)
+		i = encodeVarintPorts(dAtA, i, uint64(len(m.Timezone)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.Province) > 0 {
+		i -= len(m.Province)
+		copy(dAtA[i:], m.Province)
+		i = encodeVarintPorts(dAtA, i, uint64(len(m.Province)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Coordinates != nil {
+		{
+			size, err := m.Coordinates.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPorts(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if len(m.Regions) > 0 {
+		for iNdEx := len(m.Regions) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Regions[iNdEx])
+			copy(dAtA[i:], m.Regions[iNdEx])
+			i = encodeVarintPorts(dAtA, i, uint64(len(m.Regions[iNdEx])))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.Alias) > 0 {
+		for iNdEx := len(m.Alias) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Alias[iNdEx])
+			copy(dAtA[i:], m.Alias[iNdEx])
+			i = encodeVarintPorts(dAtA, i, uint64(len(m.Alias[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Country) > 0 {
+		i -= len(m.Country)
+		copy(dAtA[i:], m.Country)
+		i = encodeVarintPorts(dAtA, i, uint64(len(m.Country)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.City) > 0 {
+		i -= len(m.City)
+		copy(dAtA[i:], m.City)
+		i = encodeVarintPorts(dAtA, i, uint64(len(m.City)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintPorts(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintPorts(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SaveRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SaveRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SaveRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ExpectedRevision != 0 {
+		i = encodeVarintPorts(dAtA, i, uint64(m.ExpectedRevision))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Port != nil {
+		{
+			size, err := m.Port.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPorts(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UpdatePortRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UpdatePortRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UpdatePortRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ExpectedRevision != 0 {
+		i = encodeVarintPorts(dAtA, i, uint64(m.ExpectedRevision))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.UpdateMask != nil {
+		{
+			size, err := m.UpdateMask.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPorts(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Port != nil {
+		{
+			size, err := m.Port.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPorts(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LookupRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LookupRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LookupRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintPorts(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PortLookup) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PortLookup) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PortLookup) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Candidates) > 0 {
+		for iNdEx := len(m.Candidates) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Candidates[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPorts(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Port != nil {
+		{
+			size, err := m.Port.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPorts(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Location) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Location) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Location) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Longitude != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Longitude))))
+		i--
+		dAtA[i] = 0x11
+	}
+	if m.Latitude != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Latitude))))
+		i--
+		dAtA[i] = 0x9
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PortRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PortRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PortRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.ExpectedRevision != 0 {
+		i = encodeVarintPorts(dAtA, i, uint64(m.ExpectedRevision))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.AsOf != nil {
+		n6, err6 := github_com_gogo_protobuf_types.StdTimeMarshalTo(*m.AsOf, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(*m.AsOf):])
+		if err6 != nil {
+			return 0, err6
+		}
+		i -= n6
+		i = encodeVarintPorts(dAtA, i, uint64(n6))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintPorts(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PortBatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PortBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PortBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Ports) > 0 {
+		for iNdEx := len(m.Ports) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Ports[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPorts(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BatchFailure) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchFailure) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BatchFailure) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Message) > 0 {
+		i -= len(m.Message)
+		copy(dAtA[i:], m.Message)
+		i = encodeVarintPorts(dAtA, i, uint64(len(m.Message)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Code != 0 {
+		i = encodeVarintPorts(dAtA, i, uint64(m.Code))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Id) > 0 {
+		i -= len(m.Id)
+		copy(dAtA[i:], m.Id)
+		i = encodeVarintPorts(dAtA, i, uint64(len(m.Id)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Index != 0 {
+		i = encodeVarintPorts(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BatchResult) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BatchResult) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BatchResult) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Updated != 0 {
+		i = encodeVarintPorts(dAtA, i, uint64(m.Updated))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Inserted != 0 {
+		i = encodeVarintPorts(dAtA, i, uint64(m.Inserted))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Failures) > 0 {
+		for iNdEx := len(m.Failures) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Failures[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPorts(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ImportSummary) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ImportSummary) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ImportSummary) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.RejectedByCode) > 0 {
+		for k := range m.RejectedByCode {
+			v := m.RejectedByCode[k]
+			baseI := i
+			i = encodeVarintPorts(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintPorts(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintPorts(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x32
+		}
+	}
+	if len(m.Failures) > 0 {
+		for iNdEx := len(m.Failures) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Failures[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPorts(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if m.Total != 0 {
+		i = encodeVarintPorts(dAtA, i, uint64(m.Total))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Rejected != 0 {
+		i = encodeVarintPorts(dAtA, i, uint64(m.Rejected))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Updated != 0 {
+		i = encodeVarintPorts(dAtA, i, uint64(m.Updated))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Inserted != 0 {
+		i = encodeVarintPorts(dAtA, i, uint64(m.Inserted))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ListRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ListRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ListRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Filter != nil {
+		{
+			size, err := m.Filter.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPorts(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Limit != 0 {
+		i = encodeVarintPorts(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Cursor) > 0 {
+		i -= len(m.Cursor)
+		copy(dAtA[i:], m.Cursor)
+		i = encodeVarintPorts(dAtA, i, uint64(len(m.Cursor)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SearchFilter) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SearchFilter) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SearchFilter) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Timezone) > 0 {
+		i -= len(m.Timezone)
+		copy(dAtA[i:], m.Timezone)
+		i = encodeVarintPorts(dAtA, i, uint64(len(m.Timezone)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Region) > 0 {
+		i -= len(m.Region)
+		copy(dAtA[i:], m.Region)
+		i = encodeVarintPorts(dAtA, i, uint64(len(m.Region)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.City) > 0 {
+		i -= len(m.City)
+		copy(dAtA[i:], m.City)
+		i = encodeVarintPorts(dAtA, i, uint64(len(m.City)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Province) > 0 {
+		i -= len(m.Province)
+		copy(dAtA[i:], m.Province)
+		i = encodeVarintPorts(dAtA, i, uint64(len(m.Province)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Country) > 0 {
+		i -= len(m.Country)
+		copy(dAtA[i:], m.Country)
+		i = encodeVarintPorts(dAtA, i, uint64(len(m.Country)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PortPage) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PortPage) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PortPage) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.NextCursor) > 0 {
+		i -= len(m.NextCursor)
+		copy(dAtA[i:], m.NextCursor)
+		i = encodeVarintPorts(dAtA, i, uint64(len(m.NextCursor)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Ports) > 0 {
+		for iNdEx := len(m.Ports) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Ports[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPorts(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NearbyRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NearbyRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NearbyRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Limit != 0 {
+		i = encodeVarintPorts(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.RadiusKm != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.RadiusKm))))
+		i--
+		dAtA[i] = 0x11
+	}
+	if m.Point != nil {
+		{
+			size, err := m.Point.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPorts(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NearbyPort) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NearbyPort) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NearbyPort) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.DistanceKm != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.DistanceKm))))
+		i--
+		dAtA[i] = 0x11
+	}
+	if m.Port != nil {
+		{
+			size, err := m.Port.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPorts(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NearbyPorts) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NearbyPorts) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NearbyPorts) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Ports) > 0 {
+		for iNdEx := len(m.Ports) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Ports[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPorts(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SearchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SearchRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SearchRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Limit != 0 {
+		i = encodeVarintPorts(dAtA, i, uint64(m.Limit))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Query) > 0 {
+		i -= len(m.Query)
+		copy(dAtA[i:], m.Query)
+		i = encodeVarintPorts(dAtA, i, uint64(len(m.Query)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MatchedPort) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MatchedPort) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MatchedPort) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Score != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Score))))
+		i--
+		dAtA[i] = 0x11
+	}
+	if m.Port != nil {
+		{
+			size, err := m.Port.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPorts(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MatchedPorts) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MatchedPorts) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MatchedPorts) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Ports) > 0 {
+		for iNdEx := len(m.Ports) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Ports[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPorts(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BoundingBox) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BoundingBox) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BoundingBox) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.MaxLat != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.MaxLat))))
+		i--
+		dAtA[i] = 0x21
+	}
+	if m.MaxLon != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.MaxLon))))
+		i--
+		dAtA[i] = 0x19
+	}
+	if m.MinLat != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.MinLat))))
+		i--
+		dAtA[i] = 0x11
+	}
+	if m.MinLon != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.MinLon))))
+		i--
+		dAtA[i] = 0x9
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *WatchRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *WatchRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *WatchRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.FromSequence != 0 {
+		i = encodeVarintPorts(dAtA, i, uint64(m.FromSequence))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PortEvent) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PortEvent) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PortEvent) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Port != nil {
+		{
+			size, err := m.Port.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPorts(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Op != 0 {
+		i = encodeVarintPorts(dAtA, i, uint64(m.Op))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Sequence != 0 {
+		i = encodeVarintPorts(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PortChange) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PortChange) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PortChange) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	n12, err12 := github_com_gogo_protobuf_types.StdTimeMarshalTo(m.ChangedAt, dAtA[i-github_com_gogo_protobuf_types.SizeOfStdTime(m.ChangedAt):])
+	if err12 != nil {
+		return 0, err12
+	}
+	i -= n12
+	i = encodeVarintPorts(dAtA, i, uint64(n12))
+	i--
+	dAtA[i] = 0x2a
+	if len(m.Source) > 0 {
+		i -= len(m.Source)
+		copy(dAtA[i:], m.Source)
+		i = encodeVarintPorts(dAtA, i, uint64(len(m.Source)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Previous != nil {
+		{
+			size, err := m.Previous.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintPorts(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Op != 0 {
+		i = encodeVarintPorts(dAtA, i, uint64(m.Op))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Sequence != 0 {
+		i = encodeVarintPorts(dAtA, i, uint64(m.Sequence))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PortHistory) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PortHistory) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PortHistory) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Changes) > 0 {
+		for iNdEx := len(m.Changes) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Changes[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintPorts(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintPorts(dAtA []byte, offset int, v uint64) int {
+	offset -= sovPorts(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Port) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	l = len(m.City)
+	if l > 0 {
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	l = len(m.Country)
+	if l > 0 {
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	if len(m.Alias) > 0 {
+		for _, s := range m.Alias {
+			l = len(s)
+			n += 1 + l + sovPorts(uint64(l))
+		}
+	}
+	if len(m.Regions) > 0 {
+		for _, s := range m.Regions {
+			l = len(s)
+			n += 1 + l + sovPorts(uint64(l))
+		}
+	}
+	if m.Coordinates != nil {
+		l = m.Coordinates.Size()
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	l = len(m.Province)
+	if l > 0 {
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	l = len(m.Timezone)
+	if l > 0 {
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	if len(m.Unlocs) > 0 {
+		for _, s := range m.Unlocs {
+			l = len(s)
+			n += 1 + l + sovPorts(uint64(l))
+		}
+	}
+	l = len(m.Code)
+	if l > 0 {
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	if m.Revision != 0 {
+		n += 1 + sovPorts(uint64(m.Revision))
+	}
+	return n
+}
+
+func (m *SaveRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Port != nil {
+		l = m.Port.Size()
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	if m.ExpectedRevision != 0 {
+		n += 1 + sovPorts(uint64(m.ExpectedRevision))
+	}
+	return n
+}
+
+func (m *UpdatePortRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Port != nil {
+		l = m.Port.Size()
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	if m.UpdateMask != nil {
+		l = m.UpdateMask.Size()
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	if m.ExpectedRevision != 0 {
+		n += 1 + sovPorts(uint64(m.ExpectedRevision))
+	}
+	return n
+}
+
+func (m *LookupRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	return n
+}
+
+func (m *PortLookup) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Port != nil {
+		l = m.Port.Size()
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	if len(m.Candidates) > 0 {
+		for _, e := range m.Candidates {
+			l = e.Size()
+			n += 1 + l + sovPorts(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Location) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Latitude != 0 {
+		n += 9
+	}
+	if m.Longitude != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *PortRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	if m.AsOf != nil {
+		l = github_com_gogo_protobuf_types.SizeOfStdTime(*m.AsOf)
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	if m.ExpectedRevision != 0 {
+		n += 1 + sovPorts(uint64(m.ExpectedRevision))
+	}
+	return n
+}
+
+func (m *PortBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Ports) > 0 {
+		for _, e := range m.Ports {
+			l = e.Size()
+			n += 1 + l + sovPorts(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *BatchFailure) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovPorts(uint64(m.Index))
+	}
+	l = len(m.Id)
+	if l > 0 {
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	if m.Code != 0 {
+		n += 1 + sovPorts(uint64(m.Code))
+	}
+	l = len(m.Message)
+	if l > 0 {
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	return n
+}
+
+func (m *BatchResult) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Failures) > 0 {
+		for _, e := range m.Failures {
+			l = e.Size()
+			n += 1 + l + sovPorts(uint64(l))
+		}
+	}
+	if m.Inserted != 0 {
+		n += 1 + sovPorts(uint64(m.Inserted))
+	}
+	if m.Updated != 0 {
+		n += 1 + sovPorts(uint64(m.Updated))
+	}
+	return n
+}
+
+func (m *ImportSummary) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Inserted != 0 {
+		n += 1 + sovPorts(uint64(m.Inserted))
+	}
+	if m.Updated != 0 {
+		n += 1 + sovPorts(uint64(m.Updated))
+	}
+	if m.Rejected != 0 {
+		n += 1 + sovPorts(uint64(m.Rejected))
+	}
+	if m.Total != 0 {
+		n += 1 + sovPorts(uint64(m.Total))
+	}
+	if len(m.Failures) > 0 {
+		for _, e := range m.Failures {
+			l = e.Size()
+			n += 1 + l + sovPorts(uint64(l))
+		}
+	}
+	if len(m.RejectedByCode) > 0 {
+		for k, v := range m.RejectedByCode {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovPorts(uint64(len(k))) + 1 + sovPorts(uint64(v))
+			n += mapEntrySize + 1 + sovPorts(uint64(mapEntrySize))
+		}
+	}
+	return n
+}
+
+func (m *ListRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Cursor)
+	if l > 0 {
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovPorts(uint64(m.Limit))
+	}
+	if m.Filter != nil {
+		l = m.Filter.Size()
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	return n
+}
+
+func (m *SearchFilter) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Country)
+	if l > 0 {
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	l = len(m.Province)
+	if l > 0 {
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	l = len(m.City)
+	if l > 0 {
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	l = len(m.Region)
+	if l > 0 {
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	l = len(m.Timezone)
+	if l > 0 {
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	return n
+}
+
+func (m *PortPage) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Ports) > 0 {
+		for _, e := range m.Ports {
+			l = e.Size()
+			n += 1 + l + sovPorts(uint64(l))
+		}
+	}
+	l = len(m.NextCursor)
+	if l > 0 {
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	return n
+}
+
+func (m *NearbyRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Point != nil {
+		l = m.Point.Size()
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	if m.RadiusKm != 0 {
+		n += 9
+	}
+	if m.Limit != 0 {
+		n += 1 + sovPorts(uint64(m.Limit))
+	}
+	return n
+}
+
+func (m *NearbyPort) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Port != nil {
+		l = m.Port.Size()
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	if m.DistanceKm != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *NearbyPorts) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Ports) > 0 {
+		for _, e := range m.Ports {
+			l = e.Size()
+			n += 1 + l + sovPorts(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SearchRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Query)
+	if l > 0 {
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	if m.Limit != 0 {
+		n += 1 + sovPorts(uint64(m.Limit))
+	}
+	return n
+}
+
+func (m *MatchedPort) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Port != nil {
+		l = m.Port.Size()
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	if m.Score != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *MatchedPorts) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Ports) > 0 {
+		for _, e := range m.Ports {
+			l = e.Size()
+			n += 1 + l + sovPorts(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *BoundingBox) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MinLon != 0 {
+		n += 9
+	}
+	if m.MinLat != 0 {
+		n += 9
+	}
+	if m.MaxLon != 0 {
+		n += 9
+	}
+	if m.MaxLat != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *WatchRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.FromSequence != 0 {
+		n += 1 + sovPorts(uint64(m.FromSequence))
+	}
+	return n
+}
+
+func (m *PortEvent) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Sequence != 0 {
+		n += 1 + sovPorts(uint64(m.Sequence))
+	}
+	if m.Op != 0 {
+		n += 1 + sovPorts(uint64(m.Op))
+	}
+	if m.Port != nil {
+		l = m.Port.Size()
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	return n
+}
+
+func (m *PortChange) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Sequence != 0 {
+		n += 1 + sovPorts(uint64(m.Sequence))
+	}
+	if m.Op != 0 {
+		n += 1 + sovPorts(uint64(m.Op))
+	}
+	if m.Previous != nil {
+		l = m.Previous.Size()
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	l = len(m.Source)
+	if l > 0 {
+		n += 1 + l + sovPorts(uint64(l))
+	}
+	l = github_com_gogo_protobuf_types.SizeOfStdTime(m.ChangedAt)
+	n += 1 + l + sovPorts(uint64(l))
+	return n
+}
+
+func (m *PortHistory) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Changes) > 0 {
+		for _, e := range m.Changes {
+			l = e.Size()
+			n += 1 + l + sovPorts(uint64(l))
+		}
+	}
+	return n
+}
+
+func sovPorts(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozPorts(x uint64) (n int) {
+	return sovPorts(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *Port) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Port: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Port: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field City", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.City = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Country", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Country = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Alias", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Alias = append(m.Alias, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Regions", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Regions = append(m.Regions, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Coordinates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Coordinates == nil {
+				m.Coordinates = &Location{}
+			}
+			if err := m.Coordinates.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Province", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Province = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timezone", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Timezone = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Unlocs", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Unlocs = append(m.Unlocs, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Code = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Revision", wireType)
+			}
+			m.Revision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Revision |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SaveRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SaveRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SaveRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Port == nil {
+				m.Port = &Port{}
+			}
+			if err := m.Port.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedRevision", wireType)
+			}
+			m.ExpectedRevision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpectedRevision |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *UpdatePortRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: UpdatePortRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: UpdatePortRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Port == nil {
+				m.Port = &Port{}
+			}
+			if err := m.Port.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UpdateMask", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.UpdateMask == nil {
+				m.UpdateMask = &types.FieldMask{}
+			}
+			if err := m.UpdateMask.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedRevision", wireType)
+			}
+			m.ExpectedRevision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpectedRevision |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LookupRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LookupRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LookupRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PortLookup) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PortLookup: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PortLookup: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Port == nil {
+				m.Port = &Port{}
+			}
+			if err := m.Port.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Candidates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Candidates = append(m.Candidates, &Port{})
+			if err := m.Candidates[len(m.Candidates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Location) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Location: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Location: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Latitude", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Latitude = float64(math.Float64frombits(v))
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Longitude", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Longitude = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PortRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PortRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PortRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AsOf", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AsOf == nil {
+				m.AsOf = new(time.Time)
+			}
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(m.AsOf, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExpectedRevision", wireType)
+			}
+			m.ExpectedRevision = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExpectedRevision |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PortBatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PortBatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PortBatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ports", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ports = append(m.Ports, &Port{})
+			if err := m.Ports[len(m.Ports)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BatchFailure) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BatchFailure: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BatchFailure: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Id = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BatchResult) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BatchResult: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BatchResult: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Failures", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Failures = append(m.Failures, &BatchFailure{})
+			if err := m.Failures[len(m.Failures)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Inserted", wireType)
+			}
+			m.Inserted = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Inserted |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Updated", wireType)
+			}
+			m.Updated = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Updated |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ImportSummary) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ImportSummary: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ImportSummary: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Inserted", wireType)
+			}
+			m.Inserted = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Inserted |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Updated", wireType)
+			}
+			m.Updated = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Updated |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Rejected", wireType)
+			}
+			m.Rejected = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Rejected |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
+			}
+			m.Total = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Total |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Failures", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Failures = append(m.Failures, &BatchFailure{})
+			if err := m.Failures[len(m.Failures)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RejectedByCode", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RejectedByCode == nil {
+				m.RejectedByCode = make(map[string]int64)
+			}
+			var mapkey string
+			var mapvalue int64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowPorts
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPorts
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthPorts
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthPorts
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowPorts
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipPorts(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthPorts
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.RejectedByCode[mapkey] = mapvalue
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ListRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ListRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ListRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cursor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Cursor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Filter", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Filter == nil {
+				m.Filter = &SearchFilter{}
+			}
+			if err := m.Filter.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SearchFilter) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SearchFilter: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SearchFilter: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Country", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Country = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Province", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Province = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field City", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.City = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Region", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Region = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timezone", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Timezone = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PortPage) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PortPage: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PortPage: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ports", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ports = append(m.Ports, &Port{})
+			if err := m.Ports[len(m.Ports)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextCursor", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.NextCursor = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NearbyRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NearbyRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NearbyRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Point", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Point == nil {
+				m.Point = &Location{}
+			}
+			if err := m.Point.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RadiusKm", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.RadiusKm = float64(math.Float64frombits(v))
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NearbyPort) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NearbyPort: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NearbyPort: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Port == nil {
+				m.Port = &Port{}
+			}
+			if err := m.Port.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DistanceKm", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.DistanceKm = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NearbyPorts) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NearbyPorts: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NearbyPorts: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ports", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Ports = append(m.Ports, &NearbyPort{})
+			if err := m.Ports[len(m.Ports)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SearchRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SearchRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SearchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Query", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Query = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Limit", wireType)
+			}
+			m.Limit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Limit |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *MatchedPort) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: MatchedPort: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: MatchedPort: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthPorts
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Port == nil {
+				m.Port = &Port{}
+			}
+			if err := m.Port.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Score", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Score = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func (m *PortRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.Id)
-	if l > 0 {
-		n += 1 + l + sovPorts(uint64(l))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
 	}
-	return n
-}
-
-func sovPorts(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozPorts(x uint64) (n int) {
-	return sovPorts(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	return nil
 }
-func (m *Port) Unmarshal(dAtA []byte) error {
+func (m *MatchedPorts) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -706,17 +6840,17 @@ func (m *Port) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Port: wiretype end group for non-group")
+			return fmt.Errorf("proto: MatchedPorts: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Port: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MatchedPorts: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Ports", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPorts
@@ -726,93 +6860,181 @@ func (m *Port) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPorts
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPorts
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			m.Ports = append(m.Ports, &MatchedPort{})
+			if err := m.Ports[len(m.Ports)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPorts
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if skippy < 0 {
 				return ErrInvalidLengthPorts
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthPorts
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Name = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BoundingBox) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BoundingBox: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BoundingBox: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinLon", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.MinLon = float64(math.Float64frombits(v))
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinLat", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.MinLat = float64(math.Float64frombits(v))
 		case 3:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field City", wireType)
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxLon", wireType)
 			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowPorts
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.MaxLon = float64(math.Float64frombits(v))
+		case 4:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxLat", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.MaxLat = float64(math.Float64frombits(v))
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthPorts
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthPorts
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.City = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Country", wireType)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *WatchRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
 			}
-			var stringLen uint64
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: WatchRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: WatchRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FromSequence", wireType)
+			}
+			m.FromSequence = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPorts
@@ -822,29 +7044,69 @@ func (m *Port) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.FromSequence |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
 				return ErrInvalidLengthPorts
 			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
+			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthPorts
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Country = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Alias", wireType)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PortEvent) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
 			}
-			var stringLen uint64
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PortEvent: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PortEvent: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPorts
@@ -854,29 +7116,16 @@ func (m *Port) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Sequence |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPorts
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPorts
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Alias = append(m.Alias, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Regions", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Op", wireType)
 			}
-			var stringLen uint64
+			m.Op = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPorts
@@ -886,27 +7135,14 @@ func (m *Port) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Op |= PortEvent_Op(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPorts
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPorts
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.Regions = append(m.Regions, string(dAtA[iNdEx:postIndex]))
-			iNdEx = postIndex
-		case 7:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Coordinates", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Port", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -933,18 +7169,71 @@ func (m *Port) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Coordinates == nil {
-				m.Coordinates = &Location{}
+			if m.Port == nil {
+				m.Port = &Port{}
 			}
-			if err := m.Coordinates.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Port.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 8:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Province", wireType)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipPorts(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			var stringLen uint64
+			if skippy < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthPorts
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PortChange) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowPorts
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PortChange: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PortChange: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sequence", wireType)
+			}
+			m.Sequence = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPorts
@@ -954,29 +7243,35 @@ func (m *Port) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Sequence |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthPorts
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthPorts
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Op", wireType)
 			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.Op = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowPorts
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Op |= PortEvent_Op(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			m.Province = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 9:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timezone", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Previous", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPorts
@@ -986,27 +7281,31 @@ func (m *Port) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPorts
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPorts
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Timezone = string(dAtA[iNdEx:postIndex])
+			if m.Previous == nil {
+				m.Previous = &Port{}
+			}
+			if err := m.Previous.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
-		case 10:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Unlocs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Source", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -1034,13 +7333,13 @@ func (m *Port) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Unlocs = append(m.Unlocs, string(dAtA[iNdEx:postIndex]))
+			m.Source = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
-		case 11:
+		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangedAt", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPorts
@@ -1050,99 +7349,25 @@ func (m *Port) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPorts
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPorts
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Code = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipPorts(dAtA[iNdEx:])
-			if err != nil {
+			if err := github_com_gogo_protobuf_types.StdTimeUnmarshal(&m.ChangedAt, dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthPorts
-			}
-			if (iNdEx + skippy) < 0 {
-				return ErrInvalidLengthPorts
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Location) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowPorts
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Location: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Location: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Latitude", wireType)
-			}
-			var v uint64
-			if (iNdEx + 8) > l {
-				return io.ErrUnexpectedEOF
-			}
-			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-			m.Latitude = float64(math.Float64frombits(v))
-		case 2:
-			if wireType != 1 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Longitude", wireType)
-			}
-			var v uint64
-			if (iNdEx + 8) > l {
-				return io.ErrUnexpectedEOF
-			}
-			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
-			iNdEx += 8
-			m.Longitude = float64(math.Float64frombits(v))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipPorts(dAtA[iNdEx:])
@@ -1167,7 +7392,7 @@ func (m *Location) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PortRequest) Unmarshal(dAtA []byte) error {
+func (m *PortHistory) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1190,17 +7415,17 @@ func (m *PortRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PortRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: PortHistory: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PortRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: PortHistory: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Id", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Changes", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowPorts
@@ -1210,23 +7435,25 @@ func (m *PortRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthPorts
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthPorts
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Id = string(dAtA[iNdEx:postIndex])
+			m.Changes = append(m.Changes, &PortChange{})
+			if err := m.Changes[len(m.Changes)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sp4rd4/ports/pkg/domain"
+)
+
+const (
+	// DefaultImportBatchSize is used by port domain service when client does not tell batch size of import.
+	DefaultImportBatchSize = 500
+
+	// Rejection codes are named after grpc status codes so that load reports
+	// look the same whether ports are imported locally or through port domain service.
+	rejectedInvalid  = "InvalidArgument"
+	rejectedInternal = "Internal"
+)
+
+var _ domain.PortImporter = PortService{}
+
+// Import saves ports from channel in chunks of batchSize, failures of separate ports are
+// counted as rejected while any other error aborts the import.
+func (s PortService) Import(
+	ctx context.Context, ports <-chan *domain.Port, batchSize int,
+) (domain.ImportSummary, error) {
+	summary := domain.ImportSummary{}
+	if batchSize <= 0 {
+		go drain(ports)
+		return summary, fmt.Errorf("[%v] import: %w", errorTagPort, ErrInvalidBatchSize)
+	}
+	// chunk grows as ports come, batch size is not trusted enough to allocate it upfront
+	var chunk []*domain.Port
+	for p := range ports {
+		chunk = append(chunk, p)
+		if len(chunk) < batchSize {
+			continue
+		}
+		if err := s.importChunk(ctx, chunk, &summary); err != nil {
+			go drain(ports)
+			return summary, fmt.Errorf("[%v] import: %w", errorTagPort, err)
+		}
+		chunk = chunk[:0]
+	}
+
+	if err := s.importChunk(ctx, chunk, &summary); err != nil {
+		return summary, fmt.Errorf("[%v] import: %w", errorTagPort, err)
+	}
+	return summary, nil
+}
+
+func (s PortService) importChunk(ctx context.Context, chunk []*domain.Port, summary *domain.ImportSummary) error {
+	if len(chunk) == 0 {
+		return nil
+	}
+	offset := summary.Total
+	summary.Total += len(chunk)
+
+	res, err := s.SaveBatch(ctx, chunk)
+	summary.Inserted += res.Inserted
+	summary.Updated += res.Updated
+
+	var batchErr *domain.BatchError
+	if !errors.As(err, &batchErr) {
+		return err
+	}
+
+	summary.Rejected += len(batchErr.Failures)
+	if summary.RejectedByCode == nil {
+		summary.RejectedByCode = make(map[string]int)
+	}
+	for _, f := range batchErr.Failures {
+		summary.RejectedByCode[rejectionCode(f.Err)]++
+		if len(summary.Failures) >= reportedFailures {
+			continue
+		}
+		f.Index += offset
+		summary.Failures = append(summary.Failures, f)
+	}
+	return nil
+}
+
+func rejectionCode(err error) string {
+	var verr *domain.ValidationError
+	switch {
+	case errors.Is(err, ErrPortMissingID), errors.Is(err, ErrInvalidInput), errors.As(err, &verr):
+		return rejectedInvalid
+	default:
+		return rejectedInternal
+	}
+}
+
+// drain unblocks producer of ports that are not going to be saved.
+func drain(ports <-chan *domain.Port) {
+	for range ports {
+	}
+}
@@ -0,0 +1,100 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/sp4rd4/ports/pkg/domain"
+	"github.com/sp4rd4/ports/pkg/service"
+	"github.com/sp4rd4/ports/pkg/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func portsChan(ports []*domain.Port) <-chan *domain.Port {
+	res := make(chan *domain.Port)
+	go func() {
+		for _, p := range ports {
+			res <- p
+		}
+		close(res)
+	}()
+	return res
+}
+
+// unlocode generates distinct valid UN/LOCODE for i < 26^3.
+func unlocode(i int) string {
+	return string([]byte{'A', 'A', byte('A' + i/676%26), byte('A' + i/26%26), byte('A' + i%26)})
+}
+
+func TestImport(t *testing.T) {
+	ports := make([]*domain.Port, 0, 1003)
+	for i := 0; i < 1000; i++ {
+		ports = append(ports, &domain.Port{ID: unlocode(i), Name: "Port"})
+	}
+	ports = append(ports,
+		&domain.Port{Name: "Port"},
+		&domain.Port{ID: unlocode(1), Name: "Port"},
+		&domain.Port{ID: "ZAPLZ"},
+	)
+
+	storage := failingStorage{
+		Storage: newStorage(&domain.Port{ID: unlocode(0)}),
+		fail:    map[string]error{unlocode(700): errFoo},
+	}
+	ps := service.NewPortService(storage)
+	summary, err := ps.Import(context.TODO(), portsChan(ports), 300)
+	assert.Nil(t, err, "Should import with no error")
+	assert.Equal(t, 1003, summary.Total, "Should count all ports")
+	assert.Equal(t, 998, summary.Inserted, "Should count inserted ports")
+	assert.Equal(t, 2, summary.Updated, "Should count updated ports")
+	assert.Equal(t, 3, summary.Rejected, "Should count rejected ports")
+	assert.Equal(t, map[string]int{"InvalidArgument": 2, "Internal": 1}, summary.RejectedByCode,
+		"Should group rejected ports by code")
+
+	indexes := make([]int, len(summary.Failures))
+	for i, f := range summary.Failures {
+		indexes[i] = f.Index
+	}
+	assert.Equal(t, []int{700, 1000, 1002}, indexes, "Should report failures by index in stream")
+}
+
+func TestImportCanceled(t *testing.T) {
+	ports := make([]*domain.Port, 1200)
+	for i := range ports {
+		ports[i] = &domain.Port{ID: unlocode(i % 1000), Name: "Port"}
+	}
+
+	ps := service.NewPortService(memory.New())
+	_, err := ps.Import(canceledContext(), portsChan(ports), 500)
+	assert.True(t, errors.Is(err, context.Canceled), "Error should be same as expected")
+}
+
+func TestImportInvalidBatchSize(t *testing.T) {
+	ps := service.NewPortService(memory.New())
+	_, err := ps.Import(context.TODO(), portsChan([]*domain.Port{{ID: "AEAJM", Name: "Ajman"}}), 0)
+	assert.True(t, errors.Is(err, service.ErrInvalidBatchSize), "Error should be same as expected")
+}
+
+func TestImportDuplicates(t *testing.T) {
+	ports := []*domain.Port{
+		{ID: "AEAJM", Name: "Ajman"},
+		{ID: "ZAPLZ", Name: "Port Elizabeth"},
+		{ID: "AEAJM", Name: "Ajman Port"},
+		{ID: "AEAJM"},
+	}
+
+	ps := service.NewPortService(memory.New())
+	summary, err := ps.Import(context.TODO(), portsChan(ports), 10)
+	assert.Nil(t, err, "Should import with no error")
+	assert.Equal(t, domain.ImportSummary{
+		Total: 4, Inserted: 2, Updated: 1, Rejected: 1,
+		Failures:       summary.Failures,
+		RejectedByCode: map[string]int{"InvalidArgument": 1},
+	}, summary, "Should count every port of chunk once")
+	assert.Equal(t, summary.Total, summary.Inserted+summary.Updated+summary.Rejected, "Should account for all ports")
+
+	port, err := ps.Get(context.TODO(), "AEAJM")
+	assert.Nil(t, err, "Should get port with no error")
+	assert.Equal(t, "Ajman Port", port.Name, "Should keep last valid occurrence")
+}
@@ -1,45 +1,91 @@
 package service
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
-	"github.com/panjf2000/ants/v2"
 	"github.com/sp4rd4/ports/pkg/domain"
 	"github.com/sp4rd4/ports/pkg/domain/loader"
-	"go.uber.org/zap"
 )
 
-const errorTagLoader = "load-service"
+const (
+	errorTagLoader = "load-service"
+
+	// reportedFailures limits number of failed port ids and skipped records kept in load report.
+	reportedFailures = 20
+)
+
+var ErrInvalidBatchSize = errors.New("batch size should be positive")
 
 type LoadService struct {
-	loader  loader.Ports
-	storage domain.PortRepository
-	logger  *zap.Logger
-	pool    *ants.Pool
+	loader    loader.Ports
+	importer  domain.PortImporter
+	batchSize int
+}
+
+// LoadReport describes finished load.
+type LoadReport struct {
+	Total          int
+	Inserted       int
+	Updated        int
+	Rejected       int
+	RejectedByCode map[string]int
+	FailedIDs      []string
+	Skipped        int
+	SkippedRecords []loader.ParseError
+	Duration       time.Duration
+}
+
+// Succeeded reports whether every record of the source was parsed and saved.
+func (r LoadReport) Succeeded() bool {
+	return r.Rejected == 0 && r.Skipped == 0
 }
 
-func NewLoadService(
-	ldr loader.Ports, storage domain.PortRepository, logger *zap.Logger, workers int,
-) (LoadService, error) {
-	pool, err := ants.NewPool(workers)
+func NewLoadService(ldr loader.Ports, importer domain.PortImporter, batchSize int) (LoadService, error) {
+	if batchSize <= 0 {
+		return LoadService{}, fmt.Errorf("[%v] init: %w", errorTagLoader, ErrInvalidBatchSize)
+	}
+	return LoadService{loader: ldr, importer: importer, batchSize: batchSize}, nil
+}
+
+// Load streams ports from loader to importer, that saves them in batches of configured size, and blocks
+// until import is finished or ctx is done. Report is returned in both cases, error is set if import did not complete.
+func (s LoadService) Load(ctx context.Context) (LoadReport, error) {
+	start := time.Now()
+	summary, err := s.importer.Import(ctx, s.loader.Load(), s.batchSize)
+	report := newLoadReport(summary)
+	report.Duration = time.Since(start)
 	if err != nil {
-		return LoadService{}, fmt.Errorf("[%v] pool init: %w", errorTagLoader, err)
+		return report, fmt.Errorf("[%v] import: %w", errorTagLoader, err)
 	}
-	return LoadService{storage: storage, loader: ldr, logger: logger, pool: pool}, nil
+
+	skipped := s.loader.Skipped()
+	report.Skipped = len(skipped)
+	if len(skipped) > reportedFailures {
+		skipped = skipped[:reportedFailures]
+	}
+	report.SkippedRecords = skipped
+	if loadErr := s.loader.Err(); loadErr != nil {
+		return report, fmt.Errorf("[%v] load: %w", errorTagLoader, loadErr)
+	}
+	return report, nil
 }
 
-func (s LoadService) Load() {
-	ports := s.loader.Load()
-	for p := range ports {
-		lp := p
-		err := s.pool.Submit(func() {
-			err := s.storage.Save(lp)
-			if err != nil {
-				s.logger.Error(fmt.Errorf("[%v] save: %w", errorTagLoader, err).Error())
-			}
-		})
-		if err != nil {
-			s.logger.Error(fmt.Errorf("[%v] pool submit: %w", errorTagLoader, err).Error())
+func newLoadReport(summary domain.ImportSummary) LoadReport {
+	report := LoadReport{
+		Total:          summary.Total,
+		Inserted:       summary.Inserted,
+		Updated:        summary.Updated,
+		Rejected:       summary.Rejected,
+		RejectedByCode: summary.RejectedByCode,
+	}
+	for _, f := range summary.Failures {
+		if len(report.FailedIDs) == reportedFailures {
+			break
 		}
+		report.FailedIDs = append(report.FailedIDs, f.ID)
 	}
+	return report
 }
@@ -1,36 +1,46 @@
 package service_test
 
 import (
+	"context"
+	"errors"
 	"strconv"
 	"testing"
 
 	"github.com/sp4rd4/ports/pkg/domain"
+	"github.com/sp4rd4/ports/pkg/domain/loader"
 	"github.com/sp4rd4/ports/pkg/service"
 	"github.com/stretchr/testify/assert"
-	"go.uber.org/zap"
-	"go.uber.org/zap/zapcore"
-	"go.uber.org/zap/zaptest/observer"
 )
 
-type MockPortSliceStorage struct {
-	err   error
-	ports []*domain.Port
+type mockImporter struct {
+	err       error
+	summary   domain.ImportSummary
+	ports     []*domain.Port
+	batchSize int
 }
 
-func (ms *MockPortSliceStorage) Save(p *domain.Port) error {
-	if ms.err != nil {
-		return ms.err
+func (mi *mockImporter) Import(
+	_ context.Context, ports <-chan *domain.Port, batchSize int,
+) (domain.ImportSummary, error) {
+	mi.batchSize = batchSize
+	for p := range ports {
+		mi.ports = append(mi.ports, p)
 	}
-	ms.ports = append(ms.ports, p)
-	return nil
+	return mi.summary, mi.err
 }
 
-func (ms *MockPortSliceStorage) Get(id string) (*domain.Port, error) {
-	return nil, nil
+type loaderSlice struct {
+	ports   []*domain.Port
+	err     error
+	skipped []loader.ParseError
 }
 
-type loaderSlice struct {
-	ports []*domain.Port
+func (l *loaderSlice) Err() error {
+	return l.err
+}
+
+func (l *loaderSlice) Skipped() []loader.ParseError {
+	return l.skipped
 }
 
 func (l *loaderSlice) Load() <-chan *domain.Port {
@@ -105,29 +115,72 @@ var examplesLoad = []struct {
 }
 
 func TestLoad(t *testing.T) {
-	ms := &MockPortSliceStorage{}
-	core, observed := observer.New(zapcore.DebugLevel)
-	logger := zap.New(core)
+	mi := &mockImporter{}
 	ldr := &loaderSlice{}
-	ps, err := service.NewLoadService(ldr, ms, logger, 100)
-	if err != nil {
-		t.Fatalf("Could not create service: %s", err)
-	}
+	ls, err := service.NewLoadService(ldr, mi, 100)
+	assert.Nil(t, err, "Should create service with no error")
 
 	for _, ex := range examplesLoad {
 		ldr.ports = ex.ports
-		ms.err = ex.errStorage
-		ms.ports = nil
-		observed.TakeAll()
+		mi.err = ex.errStorage
+		mi.ports = nil
+		mi.summary = domain.ImportSummary{Total: len(ex.ports), Inserted: len(ex.ports)}
 
 		t.Run(ex.name, func(t *testing.T) {
-			ps.Load()
-			if ex.errStorage == nil {
-				assert.Zero(t, observed.Len(), "Should be zero errors logged")
-			} else {
-				assert.Equalf(t, observed.Len(), len(ex.ports), "Should be %v errors logged", strconv.Itoa(len(ex.ports)))
-			}
-			assert.ElementsMatch(t, ms.ports, ex.ports, "Ports should be same as expected")
+			report, err := ls.Load(context.TODO())
+			assert.True(t, errors.Is(err, ex.errStorage), "Error should be same as expected")
+			assert.Equal(t, len(ex.ports), report.Total, "Should report total")
+			assert.Equal(t, len(ex.ports), report.Inserted, "Should report inserted")
+			assert.True(t, report.Succeeded(), "Should succeed with no rejected ports")
+			assert.ElementsMatch(t, mi.ports, ex.ports, "Ports should be same as expected")
+			assert.Equal(t, 100, mi.batchSize, "Should import in batches of configured size")
 		})
 	}
 }
+
+func TestLoadReportFailures(t *testing.T) {
+	failures := make([]domain.BatchFailure, 30)
+	for i := range failures {
+		failures[i] = domain.BatchFailure{Index: i, ID: strconv.Itoa(i), Err: errFoo}
+	}
+	mi := &mockImporter{summary: domain.ImportSummary{
+		Total:          40,
+		Inserted:       10,
+		Rejected:       35,
+		Failures:       failures,
+		RejectedByCode: map[string]int{"InvalidArgument": 35},
+	}}
+	ls, err := service.NewLoadService(&loaderSlice{}, mi, 100)
+	assert.Nil(t, err, "Should create service with no error")
+
+	report, err := ls.Load(context.TODO())
+	assert.Nil(t, err, "Should return no error")
+	assert.False(t, report.Succeeded(), "Should not succeed with rejected ports")
+	assert.Equal(t, 35, report.Rejected, "Should report rejected")
+	assert.Equal(t, map[string]int{"InvalidArgument": 35}, report.RejectedByCode, "Should report failures by code")
+	assert.Len(t, report.FailedIDs, 20, "Should keep only first failed ids")
+	assert.Equal(t, "0", report.FailedIDs[0], "Should keep ids in order")
+}
+
+func TestLoadParseErrors(t *testing.T) {
+	skipped := make([]loader.ParseError, 25)
+	for i := range skipped {
+		skipped[i] = loader.ParseError{Offset: int64(i), Key: strconv.Itoa(i), Err: errFoo}
+	}
+	ldr := &loaderSlice{skipped: skipped, err: errFoo}
+	ls, err := service.NewLoadService(ldr, &mockImporter{}, 100)
+	assert.Nil(t, err, "Should create service with no error")
+
+	report, err := ls.Load(context.TODO())
+	assert.True(t, errors.Is(err, errFoo), "Should return loader error")
+	assert.False(t, report.Succeeded(), "Should not succeed with skipped records")
+	assert.Equal(t, 25, report.Skipped, "Should report skipped records count")
+	assert.Len(t, report.SkippedRecords, 20, "Should keep only first skipped records")
+}
+
+func TestNewLoadServiceInvalidBatchSize(t *testing.T) {
+	for _, batchSize := range []int{0, -1} {
+		_, err := service.NewLoadService(&loaderSlice{}, &mockImporter{}, batchSize)
+		assert.True(t, errors.Is(err, service.ErrInvalidBatchSize), "Error should be same as expected")
+	}
+}
@@ -1,17 +1,44 @@
 package service
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/sp4rd4/ports/pkg/domain"
 )
 
 const errorTagPort = "load-service"
 
+const (
+	DefaultListLimit = 100
+	MaxListLimit     = 1000
+
+	maxFilterLength = 128
+
+	DefaultNearbyRadiusKm = 100
+	// MaxNearbyRadiusKm is a half of earth circumference.
+	MaxNearbyRadiusKm = 20038
+
+	DefaultSearchLimit = 10
+	MaxSearchLimit     = 100
+)
+
 var (
-	ErrPortMissingID = errors.New("port missing id")
-	ErrInvalidInput  = errors.New("invalid input")
+	ErrPortMissingID   = errors.New("port missing id")
+	ErrInvalidInput    = errors.New("invalid input")
+	ErrInvalidLimit    = errors.New("invalid limit")
+	ErrInvalidFilter   = errors.New("invalid filter")
+	ErrInvalidPoint    = errors.New("invalid coordinates")
+	ErrInvalidRadius   = errors.New("invalid radius")
+	ErrInvalidBox      = errors.New("invalid bounding box")
+	ErrInvalidRevision = errors.New("invalid revision")
+	ErrInvalidSequence = errors.New("invalid sequence")
+	ErrInvalidMask     = errors.New("invalid update mask")
+	ErrInvalidQuery    = errors.New("invalid search query")
 )
 
 type PortService struct {
@@ -22,27 +49,342 @@ func NewPortService(storage domain.PortRepository) PortService {
 	return PortService{storage: storage}
 }
 
-func (s PortService) Save(port *domain.Port) error {
+// Save stores valid port and returns stored one, expectedRevision other than 0 makes save conditional.
+func (s PortService) Save(ctx context.Context, port *domain.Port, expectedRevision int64) (*domain.Port, error) {
 	if port == nil {
-		return fmt.Errorf("[%v] save: %w", errorTagPort, ErrInvalidInput)
+		return nil, fmt.Errorf("[%v] save: %w", errorTagPort, ErrInvalidInput)
+	}
+	if expectedRevision < 0 {
+		return nil, fmt.Errorf("[%v] save: %w", errorTagPort, ErrInvalidRevision)
 	}
 	if port.ID == "" {
-		return fmt.Errorf("[%v] save: %w", errorTagPort, ErrPortMissingID)
+		return nil, fmt.Errorf("[%v] save: %w", errorTagPort, ErrPortMissingID)
 	}
-	err := s.storage.Save(port)
+	if err := port.Validate(); err != nil {
+		return nil, fmt.Errorf("[%v] save: %w", errorTagPort, err)
+	}
+	stored, err := s.storage.Save(ctx, port, expectedRevision)
 	if err != nil {
-		return fmt.Errorf("[%v] save: %w", errorTagPort, err)
+		return nil, fmt.Errorf("[%v] save: %w", errorTagPort, err)
 	}
-	return nil
+	return stored, nil
 }
 
-func (s PortService) Get(id string) (*domain.Port, error) {
+// Update writes fields of port named by mask, stored port with them replaced has to be valid.
+// Fields missing in mask keep stored values however empty they are in port. Update fails with
+// domain.ErrRevisionMismatch if port was changed since it was read or its revision is not non-zero expectedRevision.
+func (s PortService) Update(
+	ctx context.Context, port *domain.Port, mask []string, expectedRevision int64,
+) (*domain.Port, error) {
+	if port == nil {
+		return nil, fmt.Errorf("[%v] update: %w", errorTagPort, ErrInvalidInput)
+	}
+	if port.ID == "" {
+		return nil, fmt.Errorf("[%v] update: %w", errorTagPort, ErrPortMissingID)
+	}
+	fields, err := normalizeMask(mask)
+	if err != nil {
+		return nil, fmt.Errorf("[%v] update: %w", errorTagPort, err)
+	}
+	merged, err := s.storage.Get(ctx, port.ID)
+	if err != nil {
+		return nil, fmt.Errorf("[%v] update: %w", errorTagPort, err)
+	}
+	if expectedRevision != 0 && merged.Revision != expectedRevision {
+		return nil, fmt.Errorf("[%v] update: %w", errorTagPort, domain.ErrRevisionMismatch)
+	}
+	if err = merged.CopyFields(port, fields); err != nil {
+		return nil, fmt.Errorf("[%v] update: %w", errorTagPort, err)
+	}
+	if err = merged.Validate(); err != nil {
+		return nil, fmt.Errorf("[%v] update: %w", errorTagPort, err)
+	}
+	// Port is validated as it was read, so update fails if it was changed since.
+	updated, err := s.storage.Update(ctx, merged, fields, merged.Revision)
+	if err != nil {
+		return nil, fmt.Errorf("[%v] update: %w", errorTagPort, err)
+	}
+	return updated, nil
+}
+
+// normalizeMask drops repeated paths, every path has to name one of domain.PortFields.
+func normalizeMask(mask []string) ([]string, error) {
+	fields := make([]string, 0, len(mask))
+	for _, path := range mask {
+		if !contains(domain.PortFields, path) {
+			return nil, fmt.Errorf("%q: %w", path, ErrInvalidMask)
+		}
+		if !contains(fields, path) {
+			fields = append(fields, path)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, ErrInvalidMask
+	}
+	return fields, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (s PortService) Get(ctx context.Context, id string) (*domain.Port, error) {
 	if id == "" {
 		return nil, fmt.Errorf("[%v] get: %w", errorTagPort, ErrPortMissingID)
 	}
-	port, err := s.storage.Get(id)
+	port, err := s.storage.Get(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("[%v] get: %w", errorTagPort, err)
 	}
 	return port, nil
 }
+
+// GetByUnlocode resolves port by any of its UN/LOCODEs, port having the code as id wins over others listing it.
+func (s PortService) GetByUnlocode(ctx context.Context, code string) (*domain.Port, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return nil, fmt.Errorf("[%v] by unlocode: %w", errorTagPort, ErrInvalidInput)
+	}
+	ports, err := s.storage.ByUnlocode(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("[%v] by unlocode: %w", errorTagPort, err)
+	}
+	for _, p := range ports {
+		if p.ID == code {
+			return p, nil
+		}
+	}
+	port, err := onlyCandidate(ports)
+	if err != nil {
+		return nil, fmt.Errorf("[%v] by unlocode: %w", errorTagPort, err)
+	}
+	return port, nil
+}
+
+// GetByAlias resolves port by alternative name, *domain.AmbiguousError lists candidates if several ports have it.
+func (s PortService) GetByAlias(ctx context.Context, name string) (*domain.Port, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fmt.Errorf("[%v] by alias: %w", errorTagPort, ErrInvalidInput)
+	}
+	ports, err := s.storage.ByAlias(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("[%v] by alias: %w", errorTagPort, err)
+	}
+	port, err := onlyCandidate(ports)
+	if err != nil {
+		return nil, fmt.Errorf("[%v] by alias: %w", errorTagPort, err)
+	}
+	return port, nil
+}
+
+func onlyCandidate(ports []*domain.Port) (*domain.Port, error) {
+	switch len(ports) {
+	case 0:
+		return nil, domain.ErrNotFound
+	case 1:
+		return ports[0], nil
+	default:
+		return nil, &domain.AmbiguousError{Candidates: ports}
+	}
+}
+
+// GetAsOf returns port as it was at the moment.
+func (s PortService) GetAsOf(ctx context.Context, id string, at time.Time) (*domain.Port, error) {
+	if id == "" {
+		return nil, fmt.Errorf("[%v] get as of: %w", errorTagPort, ErrPortMissingID)
+	}
+	port, err := s.storage.GetAsOf(ctx, id, at)
+	if err != nil {
+		return nil, fmt.Errorf("[%v] get as of: %w", errorTagPort, err)
+	}
+	return port, nil
+}
+
+// List returns page of ports matching filter after cursor, zero limit means default one.
+func (s PortService) List(
+	ctx context.Context, filter domain.PortFilter, cursor string, limit int,
+) (domain.Page, error) {
+	if limit == 0 {
+		limit = DefaultListLimit
+	}
+	if limit < 0 || limit > MaxListLimit {
+		return domain.Page{}, fmt.Errorf("[%v] list: %w", errorTagPort, ErrInvalidLimit)
+	}
+	filter, err := normalizeFilter(filter)
+	if err != nil {
+		return domain.Page{}, fmt.Errorf("[%v] list: %w", errorTagPort, err)
+	}
+	page, err := s.storage.List(ctx, filter, cursor, limit)
+	if err != nil {
+		return domain.Page{}, fmt.Errorf("[%v] list: %w", errorTagPort, err)
+	}
+	if page.Ports == nil {
+		page.Ports = []*domain.Port{}
+	}
+	return page, nil
+}
+
+// normalizeFilter trims filter values and checks they are of reasonable length.
+func normalizeFilter(filter domain.PortFilter) (domain.PortFilter, error) {
+	for _, v := range []*string{&filter.Country, &filter.Province, &filter.City, &filter.Region, &filter.Timezone} {
+		*v = strings.TrimSpace(*v)
+		if len(*v) > maxFilterLength {
+			return filter, ErrInvalidFilter
+		}
+	}
+	return filter, nil
+}
+
+// Nearby returns ports closest to point within radius, zero radius and limit mean default ones.
+func (s PortService) Nearby(
+	ctx context.Context, point domain.Location, radiusKm float64, limit int,
+) ([]domain.NearbyPort, error) {
+	if point.Validate() != nil {
+		return nil, fmt.Errorf("[%v] nearby: %w", errorTagPort, ErrInvalidPoint)
+	}
+	if radiusKm == 0 {
+		radiusKm = DefaultNearbyRadiusKm
+	}
+	if radiusKm < 0 || radiusKm > MaxNearbyRadiusKm {
+		return nil, fmt.Errorf("[%v] nearby: %w", errorTagPort, ErrInvalidRadius)
+	}
+	if limit == 0 {
+		limit = DefaultListLimit
+	}
+	if limit < 0 || limit > MaxListLimit {
+		return nil, fmt.Errorf("[%v] nearby: %w", errorTagPort, ErrInvalidLimit)
+	}
+
+	ports, err := s.storage.Nearby(ctx, point, radiusKm, limit)
+	if err != nil {
+		return nil, fmt.Errorf("[%v] nearby: %w", errorTagPort, err)
+	}
+	if ports == nil {
+		ports = []domain.NearbyPort{}
+	}
+	return ports, nil
+}
+
+// SearchByName finds ports by similar name, city or alias, query is expected to be typed by human.
+func (s PortService) SearchByName(ctx context.Context, query string, limit int) ([]domain.MatchedPort, error) {
+	query = strings.TrimSpace(query)
+	if query == "" || len(query) > maxFilterLength {
+		return nil, fmt.Errorf("[%v] search by name: %w", errorTagPort, ErrInvalidQuery)
+	}
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit < 0 || limit > MaxSearchLimit {
+		return nil, fmt.Errorf("[%v] search by name: %w", errorTagPort, ErrInvalidLimit)
+	}
+
+	ports, err := s.storage.SearchByName(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("[%v] search by name: %w", errorTagPort, err)
+	}
+	if ports == nil {
+		ports = []domain.MatchedPort{}
+	}
+	return ports, nil
+}
+
+// WithinBox calls fn for every port inside box.
+func (s PortService) WithinBox(ctx context.Context, box domain.BoundingBox, fn func(*domain.Port) error) error {
+	southWest := domain.Location{Latitude: box.MinLat, Longitude: box.MinLon}
+	northEast := domain.Location{Latitude: box.MaxLat, Longitude: box.MaxLon}
+	if southWest.Validate() != nil || northEast.Validate() != nil || box.MinLat > box.MaxLat {
+		return fmt.Errorf("[%v] within box: %w", errorTagPort, ErrInvalidBox)
+	}
+
+	if err := s.storage.WithinBox(ctx, box, fn); err != nil {
+		return fmt.Errorf("[%v] within box: %w", errorTagPort, err)
+	}
+	return nil
+}
+
+// Watch calls fn for every port change starting from fromSequence until ctx is done or fn fails.
+func (s PortService) Watch(ctx context.Context, fromSequence int64, fn func(domain.PortEvent) error) error {
+	if fromSequence < 0 {
+		return fmt.Errorf("[%v] watch: %w", errorTagPort, ErrInvalidSequence)
+	}
+
+	if err := s.storage.Watch(ctx, fromSequence, fn); err != nil {
+		return fmt.Errorf("[%v] watch: %w", errorTagPort, err)
+	}
+	return nil
+}
+
+// History returns changes of port ordered by sequence.
+func (s PortService) History(ctx context.Context, id string) ([]domain.PortChange, error) {
+	if id == "" {
+		return nil, fmt.Errorf("[%v] history: %w", errorTagPort, ErrPortMissingID)
+	}
+	changes, err := s.storage.History(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("[%v] history: %w", errorTagPort, err)
+	}
+	return changes, nil
+}
+
+// Delete removes port, expectedRevision other than 0 makes delete conditional.
+func (s PortService) Delete(ctx context.Context, id string, expectedRevision int64) error {
+	if id == "" {
+		return fmt.Errorf("[%v] delete: %w", errorTagPort, ErrPortMissingID)
+	}
+	if expectedRevision < 0 {
+		return fmt.Errorf("[%v] delete: %w", errorTagPort, ErrInvalidRevision)
+	}
+	err := s.storage.Delete(ctx, id, expectedRevision)
+	if err != nil {
+		return fmt.Errorf("[%v] delete: %w", errorTagPort, err)
+	}
+	return nil
+}
+
+// SaveBatch validates ports and saves valid ones, invalid ports are reported
+// along with storage failures in *domain.BatchError.
+func (s PortService) SaveBatch(ctx context.Context, ports []*domain.Port) (domain.SaveResult, error) {
+	batchErr := &domain.BatchError{}
+	valid := make([]*domain.Port, 0, len(ports))
+	indexes := make([]int, 0, len(ports))
+	for i, port := range ports {
+		switch {
+		case port == nil:
+			batchErr.Add(i, "", ErrInvalidInput)
+		case port.ID == "":
+			batchErr.Add(i, "", ErrPortMissingID)
+		default:
+			if err := port.Validate(); err != nil {
+				batchErr.Add(i, port.ID, err)
+				continue
+			}
+			valid = append(valid, port)
+			indexes = append(indexes, i)
+		}
+	}
+
+	res, err := s.storage.SaveBatch(ctx, valid)
+	var storageErr *domain.BatchError
+	switch {
+	case errors.As(err, &storageErr):
+		for _, f := range storageErr.Failures {
+			batchErr.Add(indexes[f.Index], f.ID, f.Err)
+		}
+	case err != nil:
+		return res, fmt.Errorf("[%v] save batch: %w", errorTagPort, err)
+	}
+
+	if batchErr.ErrOrNil() == nil {
+		return res, nil
+	}
+	sort.Slice(batchErr.Failures, func(i, j int) bool {
+		return batchErr.Failures[i].Index < batchErr.Failures[j].Index
+	})
+	return res, fmt.Errorf("[%v] save batch: %w", errorTagPort, batchErr)
+}
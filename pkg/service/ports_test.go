@@ -1,117 +1,968 @@
 package service_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/sp4rd4/ports/pkg/domain"
 	"github.com/sp4rd4/ports/pkg/service"
+	"github.com/sp4rd4/ports/pkg/storage/memory"
 	"github.com/stretchr/testify/assert"
 )
 
-type MockPortStorage struct {
-	err  error
-	port *domain.Port
+// failingStorage fails to save ports with listed ids the way postgres reports failures of separate rows.
+type failingStorage struct {
+	*memory.Storage
+	fail map[string]error
 }
 
-func (ms *MockPortStorage) Save(*domain.Port) error {
-	return ms.err
+func (fs failingStorage) SaveBatch(ctx context.Context, ports []*domain.Port) (domain.SaveResult, error) {
+	batchErr := &domain.BatchError{}
+	valid := make([]*domain.Port, 0, len(ports))
+	for i, p := range ports {
+		if err, ok := fs.fail[p.ID]; ok {
+			batchErr.Add(i, p.ID, err)
+			continue
+		}
+		valid = append(valid, p)
+	}
+	res, err := fs.Storage.SaveBatch(ctx, valid)
+	if err != nil {
+		return res, err
+	}
+	return res, batchErr.ErrOrNil()
+}
+
+// racingStorage saves port every time after it was read, as concurrent writer would do.
+type racingStorage struct {
+	*memory.Storage
+}
+
+func (rs racingStorage) Get(ctx context.Context, id string) (*domain.Port, error) {
+	port, err := rs.Storage.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = rs.Storage.Save(ctx, port.Clone(), 0); err != nil {
+		return nil, err
+	}
+	return port, nil
 }
 
-func (ms *MockPortStorage) Get(id string) (*domain.Port, error) {
-	return ms.port, ms.err
+func newStorage(ports ...*domain.Port) *memory.Storage {
+	storage := memory.New()
+	if _, err := storage.SaveBatch(context.TODO(), ports); err != nil {
+		panic(err)
+	}
+	return storage
+}
+
+func canceledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+func portIDs(ports []*domain.Port) []string {
+	ids := make([]string, len(ports))
+	for i := range ports {
+		ids[i] = ports[i].ID
+	}
+	return ids
 }
 
 var errFoo = errors.New("test")
 
 var examplesSave = []struct {
 	name        string
-	errStorage  error
+	ctx         context.Context
 	errExpected error
 	port        *domain.Port
+	revision    int64
 }{
 	{
 		name:        "No error",
-		errStorage:  nil,
+		ctx:         context.TODO(),
 		errExpected: nil,
-		port:        &domain.Port{ID: "id", City: "city", Name: "Port"},
+		port:        &domain.Port{ID: "AEAJM", City: "city", Name: "Port", Revision: 1},
 	},
 	{
-		name:        "Test error",
-		errStorage:  errFoo,
-		errExpected: errFoo,
-		port:        &domain.Port{ID: "id", City: "city", Name: "Port"},
+		name:        "Revision mismatch",
+		ctx:         context.TODO(),
+		errExpected: domain.ErrRevisionMismatch,
+		port:        &domain.Port{ID: "AEAJM", City: "city", Name: "Port"},
+		revision:    1,
+	},
+	{
+		name:        "Negative revision",
+		ctx:         context.TODO(),
+		errExpected: service.ErrInvalidRevision,
+		port:        &domain.Port{ID: "AEAJM", City: "city", Name: "Port"},
+		revision:    -1,
+	},
+	{
+		name:        "Canceled",
+		ctx:         canceledContext(),
+		errExpected: context.Canceled,
+		port:        &domain.Port{ID: "AEAJM", City: "city", Name: "Port"},
 	},
 	{
 		name:        "Nil port",
-		errStorage:  nil,
+		ctx:         context.TODO(),
 		errExpected: service.ErrInvalidInput,
 		port:        nil,
 	},
 	{
 		name:        "Incorrect port",
-		errStorage:  nil,
+		ctx:         context.TODO(),
 		errExpected: service.ErrPortMissingID,
 		port:        &domain.Port{City: "city", Name: "Port"},
 	},
 }
 
 func TestSave(t *testing.T) {
-	ms := &MockPortStorage{}
-	ps := service.NewPortService(ms)
 	for _, ex := range examplesSave {
-		ms.err = ex.errStorage
 		t.Run(ex.name, func(t *testing.T) {
-			err := ps.Save(ex.port)
+			storage := memory.New()
+			ps := service.NewPortService(storage)
+			_, err := ps.Save(ex.ctx, ex.port, ex.revision)
 			assert.True(t, errors.Is(err, ex.errExpected), "Error should be same as expected")
+			if err != nil {
+				return
+			}
+			port, err := storage.Get(context.TODO(), ex.port.ID)
+			assert.Nil(t, err, "Should store port")
+			assert.Equal(t, ex.port, port, "Should store port same as saved")
+		})
+	}
+}
+
+var examplesValidate = []struct {
+	name       string
+	port       *domain.Port
+	violations []domain.FieldViolation
+}{
+	{
+		name: "Valid",
+		port: &domain.Port{
+			ID:          "AEAJM",
+			Name:        "Ajman",
+			Alias:       domain.StringArray{"Ajman Port", "Port Rashid"},
+			Coordinates: domain.Location{Latitude: 25.4052165, Longitude: 55.5136433},
+			Timezone:    "Asia/Dubai",
+			Unlocs:      domain.StringArray{"AEAJM"},
+		},
+	},
+	{
+		name: "Invalid fields",
+		port: &domain.Port{
+			ID:          "aeajm",
+			Name:        " ",
+			Alias:       domain.StringArray{"Ajman", "Port Rashid", "Ajman"},
+			Coordinates: domain.Location{Latitude: 25.4052165, Longitude: 255.5136433},
+			Timezone:    "Asia/Ajman",
+			Unlocs:      domain.StringArray{"AEAJM", "AE-AJ"},
+		},
+		violations: []domain.FieldViolation{
+			{Field: "id", Description: "must be UN/LOCODE"},
+			{Field: "name", Description: "must not be empty"},
+			{Field: "coordinates.lon", Description: "must be within [-180, 180]"},
+			{Field: "timezone", Description: "must be IANA time zone name"},
+			{Field: "unlocs[1]", Description: "must be UN/LOCODE"},
+			{Field: "alias[2]", Description: "duplicates alias[0]"},
+		},
+	},
+	{
+		name:       "Local timezone",
+		port:       &domain.Port{ID: "AEAJM", Name: "Ajman", Timezone: "Local"},
+		violations: []domain.FieldViolation{{Field: "timezone", Description: "must be IANA time zone name"}},
+	},
+	{
+		name: "Both coordinates out of range",
+		port: &domain.Port{ID: "AEAJM", Name: "Ajman", Coordinates: domain.Location{Latitude: 95.4, Longitude: 255.5}},
+		violations: []domain.FieldViolation{
+			{Field: "coordinates.lat", Description: "must be within [-90, 90]"},
+			{Field: "coordinates.lon", Description: "must be within [-180, 180]"},
+		},
+	},
+}
+
+func TestSaveValidate(t *testing.T) {
+	ps := service.NewPortService(memory.New())
+	for _, ex := range examplesValidate {
+		t.Run(ex.name, func(t *testing.T) {
+			_, err := ps.Save(context.TODO(), ex.port, 0)
+			if ex.violations == nil {
+				assert.Nil(t, err, "Should return no error")
+				return
+			}
+			var verr *domain.ValidationError
+			if assert.True(t, errors.As(err, &verr), "Should return validation error") {
+				assert.Equal(t, ex.violations, verr.Violations, "Should report invalid fields")
+			}
 		})
 	}
 }
 
 var examplesGet = []struct {
 	name        string
-	errStorage  error
+	ctx         context.Context
 	errExpected error
 	id          string
-	memory      *domain.Port
 	expected    *domain.Port
 }{
 	{
 		name:        "No error",
-		errStorage:  nil,
+		ctx:         context.TODO(),
 		errExpected: nil,
-		id:          "id",
-		memory:      &domain.Port{ID: "id", City: "city", Name: "Port"},
-		expected:    &domain.Port{ID: "id", City: "city", Name: "Port"},
+		id:          "AEAJM",
+		expected:    &domain.Port{ID: "AEAJM", City: "city", Name: "Port", Revision: 1},
+	},
+	{
+		name:        "Canceled",
+		ctx:         canceledContext(),
+		errExpected: context.Canceled,
+		id:          "AEAJM",
+		expected:    nil,
 	},
 	{
-		name:        "Test error",
-		errStorage:  errFoo,
-		errExpected: errFoo,
-		id:          "id",
-		memory:      &domain.Port{ID: "id", City: "city", Name: "Port"},
+		name:        "Not found",
+		ctx:         context.TODO(),
+		errExpected: domain.ErrNotFound,
+		id:          "ZAPLZ",
 		expected:    nil,
 	},
 	{
 		name:        "No id",
-		errStorage:  nil,
+		ctx:         context.TODO(),
 		errExpected: service.ErrPortMissingID,
 		id:          "",
-		memory:      &domain.Port{ID: "id", City: "city", Name: "Port"},
 		expected:    nil,
 	},
 }
 
 func TestGet(t *testing.T) {
-	ms := &MockPortStorage{}
-	ps := service.NewPortService(ms)
+	ps := service.NewPortService(newStorage(&domain.Port{ID: "AEAJM", City: "city", Name: "Port"}))
 	for _, ex := range examplesGet {
-		ms.err = ex.errStorage
-		ms.port = ex.memory
 		t.Run(ex.name, func(t *testing.T) {
-			port, err := ps.Get(ex.id)
+			port, err := ps.Get(ex.ctx, ex.id)
 			assert.True(t, errors.Is(err, ex.errExpected), "Error should be same as expected")
 			assert.Equal(t, ex.expected, port, "Should return port same as expected")
 		})
 	}
 }
+
+func TestGetAsOf(t *testing.T) {
+	ps := service.NewPortService(newStorage(&domain.Port{ID: "AEAJM", Name: "Port"}))
+
+	port, err := ps.GetAsOf(context.TODO(), "AEAJM", time.Now())
+	assert.Nil(t, err, "Should get port with no error")
+	assert.Equal(t, "Port", port.Name, "Should return port as of time")
+	_, err = ps.GetAsOf(context.TODO(), "AEAJM", time.Now().Add(-time.Hour))
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "Should not find port before it was created")
+	_, err = ps.GetAsOf(context.TODO(), "", time.Now())
+	assert.True(t, errors.Is(err, service.ErrPortMissingID), "Should require id")
+}
+
+var examplesList = []struct {
+	name        string
+	ctx         context.Context
+	errExpected error
+	limit       int
+	size        int
+	nextCursor  string
+}{
+	{
+		name:       "No error",
+		ctx:        context.TODO(),
+		limit:      2,
+		size:       2,
+		nextCursor: "P0001",
+	},
+	{
+		name:       "Default limit",
+		ctx:        context.TODO(),
+		limit:      0,
+		size:       service.DefaultListLimit,
+		nextCursor: fmt.Sprintf("P%04d", service.DefaultListLimit-1),
+	},
+	{
+		name:        "Negative limit",
+		ctx:         context.TODO(),
+		limit:       -1,
+		errExpected: service.ErrInvalidLimit,
+	},
+	{
+		name:        "Too big limit",
+		ctx:         context.TODO(),
+		limit:       service.MaxListLimit + 1,
+		errExpected: service.ErrInvalidLimit,
+	},
+	{
+		name:        "Canceled",
+		ctx:         canceledContext(),
+		limit:       10,
+		errExpected: context.Canceled,
+	},
+}
+
+func TestList(t *testing.T) {
+	ports := make([]*domain.Port, service.DefaultListLimit+1)
+	for i := range ports {
+		ports[i] = &domain.Port{ID: fmt.Sprintf("P%04d", i)}
+	}
+	ps := service.NewPortService(newStorage(ports...))
+	for _, ex := range examplesList {
+		t.Run(ex.name, func(t *testing.T) {
+			page, err := ps.List(ex.ctx, domain.PortFilter{}, "", ex.limit)
+			assert.True(t, errors.Is(err, ex.errExpected), "Error should be same as expected")
+			assert.Equal(t, ex.size, len(page.Ports), "Should return page of expected size")
+			assert.Equal(t, ex.nextCursor, page.NextCursor, "Should return expected cursor")
+		})
+	}
+}
+
+var examplesListFilter = []struct {
+	name        string
+	filter      domain.PortFilter
+	expected    []string
+	errExpected error
+}{
+	{
+		name:     "Trimmed",
+		filter:   domain.PortFilter{Country: " Belgium ", Region: "Europe\t"},
+		expected: []string{"BEANR"},
+	},
+	{
+		name:        "Too long",
+		filter:      domain.PortFilter{City: strings.Repeat("a", 129)},
+		expected:    []string{},
+		errExpected: service.ErrInvalidFilter,
+	},
+}
+
+func TestListFilter(t *testing.T) {
+	ps := service.NewPortService(newStorage(
+		&domain.Port{ID: "BEANR", Country: "Belgium", Regions: domain.StringArray{"Europe"}},
+		&domain.Port{ID: "FRCQF", Country: "France", Regions: domain.StringArray{"Europe"}},
+	))
+	for _, ex := range examplesListFilter {
+		t.Run(ex.name, func(t *testing.T) {
+			page, err := ps.List(context.TODO(), ex.filter, "", 10)
+			assert.True(t, errors.Is(err, ex.errExpected), "Error should be same as expected")
+			assert.Equal(t, ex.expected, portIDs(page.Ports), "Should query storage with normalized filter")
+		})
+	}
+}
+
+var examplesNearby = []struct {
+	name        string
+	ctx         context.Context
+	errExpected error
+	point       domain.Location
+	radius      float64
+	limit       int
+	expected    []string
+}{
+	{
+		name:     "No error",
+		ctx:      context.TODO(),
+		point:    domain.Location{Latitude: 51.2, Longitude: 4.4},
+		radius:   50,
+		limit:    5,
+		expected: []string{"BEANR"},
+	},
+	{
+		name:     "Default radius",
+		ctx:      context.TODO(),
+		point:    domain.Location{Latitude: 51.2, Longitude: 4.4},
+		expected: []string{"BEANR", "NLRTM"},
+	},
+	{
+		name:     "Nothing found",
+		ctx:      context.TODO(),
+		expected: []string{},
+	},
+	{
+		name:        "Invalid latitude",
+		ctx:         context.TODO(),
+		point:       domain.Location{Latitude: 91},
+		errExpected: service.ErrInvalidPoint,
+	},
+	{
+		name:        "Invalid longitude",
+		ctx:         context.TODO(),
+		point:       domain.Location{Longitude: -181},
+		errExpected: service.ErrInvalidPoint,
+	},
+	{
+		name:        "Negative radius",
+		ctx:         context.TODO(),
+		radius:      -1,
+		errExpected: service.ErrInvalidRadius,
+	},
+	{
+		name:        "Too big radius",
+		ctx:         context.TODO(),
+		radius:      service.MaxNearbyRadiusKm + 1,
+		errExpected: service.ErrInvalidRadius,
+	},
+	{
+		name:        "Too big limit",
+		ctx:         context.TODO(),
+		limit:       service.MaxListLimit + 1,
+		errExpected: service.ErrInvalidLimit,
+	},
+	{
+		name:        "Canceled",
+		ctx:         canceledContext(),
+		errExpected: context.Canceled,
+	},
+}
+
+func TestNearby(t *testing.T) {
+	ps := service.NewPortService(newStorage(
+		&domain.Port{ID: "BEANR", Coordinates: domain.Location{Latitude: 51.2194475, Longitude: 4.4024643}},
+		&domain.Port{ID: "FRCQF", Coordinates: domain.Location{Latitude: 50.95129, Longitude: 1.858686}},
+		&domain.Port{ID: "NLRTM", Coordinates: domain.Location{Latitude: 51.9244201, Longitude: 4.4777325}},
+	))
+	for _, ex := range examplesNearby {
+		t.Run(ex.name, func(t *testing.T) {
+			ports, err := ps.Nearby(ex.ctx, ex.point, ex.radius, ex.limit)
+			assert.True(t, errors.Is(err, ex.errExpected), "Error should be same as expected")
+			if err != nil {
+				assert.Nil(t, ports, "Should return no ports")
+				return
+			}
+			ids := make([]string, len(ports))
+			for i := range ports {
+				ids[i] = ports[i].ID
+			}
+			assert.Equal(t, ex.expected, ids, "Should return ports same as expected")
+		})
+	}
+}
+
+var examplesSearchByName = []struct {
+	name        string
+	ctx         context.Context
+	errExpected error
+	query       string
+	limit       int
+	expected    []string
+}{
+	{
+		name:     "No error",
+		ctx:      context.TODO(),
+		query:    "rotter",
+		limit:    1,
+		expected: []string{"NLRTM"},
+	},
+	{
+		name:     "Default limit",
+		ctx:      context.TODO(),
+		query:    "  antwerp ",
+		expected: []string{"BEANR"},
+	},
+	{
+		name:     "Nothing found",
+		ctx:      context.TODO(),
+		query:    "hamburg",
+		expected: []string{},
+	},
+	{
+		name:        "Empty query",
+		ctx:         context.TODO(),
+		query:       "  ",
+		errExpected: service.ErrInvalidQuery,
+	},
+	{
+		name:        "Too long query",
+		ctx:         context.TODO(),
+		query:       strings.Repeat("a", 1000),
+		errExpected: service.ErrInvalidQuery,
+	},
+	{
+		name:        "Too big limit",
+		ctx:         context.TODO(),
+		query:       "rotter",
+		limit:       service.MaxSearchLimit + 1,
+		errExpected: service.ErrInvalidLimit,
+	},
+	{
+		name:        "Canceled",
+		ctx:         canceledContext(),
+		query:       "rotter",
+		errExpected: context.Canceled,
+	},
+}
+
+func TestSearchByName(t *testing.T) {
+	ps := service.NewPortService(newStorage(
+		&domain.Port{ID: "BEANR", Name: "Antwerp", City: "Antwerp"},
+		&domain.Port{ID: "NLRTM", Name: "Rotterdam", City: "Rotterdam"},
+		&domain.Port{ID: "NLVLA", Name: "Vlaardingen", Alias: domain.StringArray{"Rotterdam Vlaardingen"}},
+	))
+	for _, ex := range examplesSearchByName {
+		t.Run(ex.name, func(t *testing.T) {
+			ports, err := ps.SearchByName(ex.ctx, ex.query, ex.limit)
+			assert.True(t, errors.Is(err, ex.errExpected), "Error should be same as expected")
+			if err != nil {
+				assert.Nil(t, ports, "Should return no ports")
+				return
+			}
+			ids := make([]string, len(ports))
+			for i := range ports {
+				ids[i] = ports[i].ID
+			}
+			assert.Equal(t, ex.expected, ids, "Should return ports same as expected")
+		})
+	}
+}
+
+var examplesWithinBox = []struct {
+	name        string
+	ctx         context.Context
+	errExpected error
+	box         domain.BoundingBox
+	expected    []string
+}{
+	{
+		name:     "No error",
+		ctx:      context.TODO(),
+		box:      domain.BoundingBox{MinLon: 2, MinLat: 50, MaxLon: 5, MaxLat: 52},
+		expected: []string{"BEANR", "NLRTM"},
+	},
+	{
+		name:     "Antimeridian",
+		ctx:      context.TODO(),
+		box:      domain.BoundingBox{MinLon: 170, MinLat: -20, MaxLon: -170, MaxLat: -10},
+		expected: []string{"FJSUV", "FJTVU"},
+	},
+	{
+		name:        "Swapped latitudes",
+		ctx:         context.TODO(),
+		box:         domain.BoundingBox{MinLon: 2, MinLat: 52, MaxLon: 5, MaxLat: 50},
+		errExpected: service.ErrInvalidBox,
+	},
+	{
+		name:        "Invalid longitude",
+		ctx:         context.TODO(),
+		box:         domain.BoundingBox{MinLon: -190, MinLat: 50, MaxLon: 5, MaxLat: 52},
+		errExpected: service.ErrInvalidBox,
+	},
+	{
+		name:        "Canceled",
+		ctx:         canceledContext(),
+		box:         domain.BoundingBox{MinLon: 2, MinLat: 50, MaxLon: 5, MaxLat: 52},
+		errExpected: context.Canceled,
+	},
+}
+
+func TestWithinBox(t *testing.T) {
+	ps := service.NewPortService(newStorage(
+		&domain.Port{ID: "BEANR", Coordinates: domain.Location{Latitude: 51.2194475, Longitude: 4.4024643}},
+		&domain.Port{ID: "FJSUV", Coordinates: domain.Location{Latitude: -18.1416, Longitude: 178.4419}},
+		&domain.Port{ID: "FJTVU", Coordinates: domain.Location{Latitude: -16.8, Longitude: -179.97}},
+		&domain.Port{ID: "NLRTM", Coordinates: domain.Location{Latitude: 51.9244201, Longitude: 4.4777325}},
+	))
+	for _, ex := range examplesWithinBox {
+		t.Run(ex.name, func(t *testing.T) {
+			var ids []string
+			err := ps.WithinBox(ex.ctx, ex.box, func(p *domain.Port) error {
+				ids = append(ids, p.ID)
+				return nil
+			})
+			assert.True(t, errors.Is(err, ex.errExpected), "Error should be same as expected")
+			assert.Equal(t, ex.expected, ids, "Should iterate over expected ports")
+		})
+	}
+}
+
+var lookupPorts = []*domain.Port{
+	{ID: "AEAJM", Unlocs: domain.StringArray{"AEAJM", "AEQIW"}, Alias: domain.StringArray{"Ajman Port"}},
+	{ID: "AEQIW", Unlocs: domain.StringArray{"AEQIW"}, Alias: domain.StringArray{"Umm al Qaiwain"}},
+	{ID: "AESHJ", Unlocs: domain.StringArray{"AESHJ", "AEKLF"}, Alias: domain.StringArray{"Ajman Port", "Sharjah"}},
+	{ID: "AEKHL", Unlocs: domain.StringArray{"AEKHL", "AEKLF"}},
+}
+
+var examplesLookup = []struct {
+	name        string
+	byAlias     bool
+	key         string
+	errExpected error
+	id          string
+	candidates  []string
+}{
+	{
+		name: "Primary code",
+		key:  "AEQIW",
+		id:   "AEQIW",
+	},
+	{
+		name: "Alternate code",
+		key:  " aeshj",
+		id:   "AESHJ",
+	},
+	{
+		name:       "Ambiguous code",
+		key:        "AEKLF",
+		candidates: []string{"AEKHL", "AESHJ"},
+	},
+	{
+		name:        "Unknown code",
+		key:         "ZAPLZ",
+		errExpected: domain.ErrNotFound,
+	},
+	{
+		name:        "Empty code",
+		key:         " ",
+		errExpected: service.ErrInvalidInput,
+	},
+	{
+		name:    "Alias",
+		byAlias: true,
+		key:     "Sharjah ",
+		id:      "AESHJ",
+	},
+	{
+		name:       "Ambiguous alias",
+		byAlias:    true,
+		key:        "Ajman Port",
+		candidates: []string{"AEAJM", "AESHJ"},
+	},
+	{
+		name:        "Unknown alias",
+		byAlias:     true,
+		key:         "Dubai",
+		errExpected: domain.ErrNotFound,
+	},
+}
+
+func TestLookup(t *testing.T) {
+	ps := service.NewPortService(newStorage(lookupPorts...))
+	for _, ex := range examplesLookup {
+		t.Run(ex.name, func(t *testing.T) {
+			lookup := ps.GetByUnlocode
+			if ex.byAlias {
+				lookup = ps.GetByAlias
+			}
+			port, err := lookup(context.TODO(), ex.key)
+			if ex.candidates != nil {
+				var aerr *domain.AmbiguousError
+				if assert.True(t, errors.As(err, &aerr), "Should return ambiguous error") {
+					assert.Equal(t, ex.candidates, portIDs(aerr.Candidates), "Should list candidates")
+				}
+				return
+			}
+			assert.True(t, errors.Is(err, ex.errExpected), "Error should be same as expected")
+			if ex.id != "" {
+				assert.Equal(t, ex.id, port.ID, "Should find expected port")
+			}
+		})
+	}
+}
+
+var storedAjman = &domain.Port{
+	ID:       "AEAJM",
+	Name:     "Ajman",
+	City:     "Ajman",
+	Alias:    domain.StringArray{"Ajman Port"},
+	Timezone: "Asia/Dubai",
+	Unlocs:   domain.StringArray{"AEAJM"},
+}
+
+var examplesUpdate = []struct {
+	name        string
+	errExpected error
+	port        *domain.Port
+	mask        []string
+	revision    int64
+	expected    *domain.Port
+}{
+	{
+		name: "Only masked fields",
+		port: &domain.Port{ID: "AEAJM", Alias: domain.StringArray{"AJM"}, Timezone: "Asia/Muscat"},
+		mask: []string{"alias", "timezone", "alias"},
+		expected: &domain.Port{
+			ID: "AEAJM", Name: "Ajman", City: "Ajman", Alias: domain.StringArray{"AJM"}, Timezone: "Asia/Muscat",
+			Unlocs: domain.StringArray{"AEAJM"}, Revision: 2,
+		},
+	},
+	{
+		name:     "Expected revision",
+		port:     &domain.Port{ID: "AEAJM", Timezone: "Asia/Muscat"},
+		mask:     []string{"timezone"},
+		revision: 1,
+		expected: &domain.Port{
+			ID: "AEAJM", Name: "Ajman", City: "Ajman", Alias: domain.StringArray{"Ajman Port"}, Timezone: "Asia/Muscat",
+			Unlocs: domain.StringArray{"AEAJM"}, Revision: 2,
+		},
+	},
+	{
+		name:        "Revision mismatch",
+		port:        &domain.Port{ID: "AEAJM", Timezone: "Asia/Muscat"},
+		mask:        []string{"timezone"},
+		revision:    2,
+		errExpected: domain.ErrRevisionMismatch,
+	},
+	{
+		name: "Clear field",
+		port: &domain.Port{ID: "AEAJM"},
+		mask: []string{"city"},
+		expected: &domain.Port{
+			ID: "AEAJM", Name: "Ajman", Alias: domain.StringArray{"Ajman Port"}, Timezone: "Asia/Dubai",
+			Unlocs: domain.StringArray{"AEAJM"}, Revision: 2,
+		},
+	},
+	{
+		name:        "Invalid result",
+		port:        &domain.Port{ID: "AEAJM"},
+		mask:        []string{"name"},
+		errExpected: &domain.ValidationError{},
+	},
+	{
+		name:        "Empty mask",
+		port:        &domain.Port{ID: "AEAJM", Name: "Ajman"},
+		errExpected: service.ErrInvalidMask,
+	},
+	{
+		name:        "Unknown field",
+		port:        &domain.Port{ID: "AEAJM", Name: "Ajman"},
+		mask:        []string{"name", "revision"},
+		errExpected: service.ErrInvalidMask,
+	},
+	{
+		name:        "Missing ID",
+		port:        &domain.Port{Name: "Ajman"},
+		mask:        []string{"name"},
+		errExpected: service.ErrPortMissingID,
+	},
+	{
+		name:        "Nil port",
+		mask:        []string{"name"},
+		errExpected: service.ErrInvalidInput,
+	},
+	{
+		name:        "Not found",
+		port:        &domain.Port{ID: "ZAPLZ", Name: "Port Elizabeth"},
+		mask:        []string{"name"},
+		errExpected: domain.ErrNotFound,
+	},
+}
+
+func TestUpdate(t *testing.T) {
+	for _, ex := range examplesUpdate {
+		t.Run(ex.name, func(t *testing.T) {
+			storage := newStorage(storedAjman)
+			ps := service.NewPortService(storage)
+			port, err := ps.Update(context.TODO(), ex.port, ex.mask, ex.revision)
+			var verr *domain.ValidationError
+			if errors.As(ex.errExpected, &verr) {
+				assert.True(t, errors.As(err, &verr), "Should return validation error")
+			} else {
+				assert.True(t, errors.Is(err, ex.errExpected), "Error should be same as expected")
+			}
+			assert.Equal(t, ex.expected, port, "Should return updated port")
+
+			if ex.expected != nil {
+				stored, err := storage.Get(context.TODO(), "AEAJM")
+				assert.Nil(t, err, "Should load port with no error")
+				assert.Equal(t, ex.expected, stored, "Should store updated port")
+			}
+		})
+	}
+}
+
+func TestUpdateChanged(t *testing.T) {
+	storage := newStorage(storedAjman)
+	ps := service.NewPortService(racingStorage{Storage: storage})
+	_, err := ps.Update(context.TODO(), &domain.Port{ID: "AEAJM", Timezone: "Asia/Muscat"}, []string{"timezone"}, 0)
+	assert.True(t, errors.Is(err, domain.ErrRevisionMismatch), "Should not update port changed since it was read")
+
+	stored, err := storage.Get(context.TODO(), "AEAJM")
+	assert.Nil(t, err, "Should load port with no error")
+	assert.Equal(t, "Asia/Dubai", stored.Timezone, "Should keep port changed since it was read")
+}
+
+var examplesDelete = []struct {
+	name        string
+	errExpected error
+	id          string
+	revision    int64
+}{
+	{
+		name:        "Expected revision",
+		errExpected: nil,
+		id:          "AEAJM",
+		revision:    1,
+	},
+	{
+		name:        "Revision mismatch",
+		errExpected: domain.ErrRevisionMismatch,
+		id:          "AEAJM",
+		revision:    2,
+	},
+	{
+		name:        "Negative revision",
+		errExpected: service.ErrInvalidRevision,
+		id:          "AEAJM",
+		revision:    -1,
+	},
+	{
+		name:        "No error",
+		errExpected: nil,
+		id:          "AEAJM",
+	},
+	{
+		name:        "Not found",
+		errExpected: domain.ErrNotFound,
+		id:          "ZAPLZ",
+	},
+	{
+		name:        "No id",
+		errExpected: service.ErrPortMissingID,
+		id:          "",
+	},
+}
+
+func TestDelete(t *testing.T) {
+	for _, ex := range examplesDelete {
+		t.Run(ex.name, func(t *testing.T) {
+			ps := service.NewPortService(newStorage(&domain.Port{ID: "AEAJM"}))
+			err := ps.Delete(context.TODO(), ex.id, ex.revision)
+			assert.True(t, errors.Is(err, ex.errExpected), "Error should be same as expected")
+		})
+	}
+}
+
+var examplesHistory = []struct {
+	name        string
+	id          string
+	errExpected error
+	changes     int
+}{
+	{
+		name:    "No error",
+		id:      "AEAJM",
+		changes: 2,
+	},
+	{
+		name:        "Not found",
+		id:          "ZAPLZ",
+		errExpected: domain.ErrNotFound,
+	},
+	{
+		name:        "Missing ID",
+		errExpected: service.ErrPortMissingID,
+	},
+}
+
+func TestHistory(t *testing.T) {
+	storage := newStorage(&domain.Port{ID: "AEAJM"})
+	if _, err := storage.Save(context.TODO(), &domain.Port{ID: "AEAJM", Name: "Ajman"}, 0); err != nil {
+		t.Fatal(err)
+	}
+	ps := service.NewPortService(storage)
+	for _, ex := range examplesHistory {
+		t.Run(ex.name, func(t *testing.T) {
+			changes, err := ps.History(context.TODO(), ex.id)
+			assert.True(t, errors.Is(err, ex.errExpected), "Error should be same as expected")
+			assert.Equal(t, ex.changes, len(changes), "Should return changes of port")
+		})
+	}
+}
+
+var examplesSaveBatch = []struct {
+	name     string
+	fail     map[string]error
+	ports    []*domain.Port
+	saved    []*domain.Port
+	failures []domain.BatchFailure
+}{
+	{
+		name:  "No error",
+		ports: []*domain.Port{{ID: "AEAJM", Name: "Ajman"}, {ID: "ZAPLZ", Name: "Port Elizabeth"}},
+		saved: []*domain.Port{
+			{ID: "AEAJM", Name: "Ajman", Revision: 1},
+			{ID: "ZAPLZ", Name: "Port Elizabeth", Revision: 1},
+		},
+		failures: nil,
+	},
+	{
+		name: "Invalid ports",
+		ports: []*domain.Port{
+			nil,
+			{ID: "AEAJM", Name: "Ajman"},
+			{Name: "Port"},
+			{ID: "ZAPLZ", Name: "Port Elizabeth", Coordinates: domain.Location{Latitude: -91}},
+		},
+		saved: []*domain.Port{{ID: "AEAJM", Name: "Ajman", Revision: 1}},
+		failures: []domain.BatchFailure{
+			{Index: 0, Err: service.ErrInvalidInput},
+			{Index: 2, Err: service.ErrPortMissingID},
+			{Index: 3, ID: "ZAPLZ", Err: &domain.ValidationError{Violations: []domain.FieldViolation{
+				{Field: "coordinates.lat", Description: "must be within [-90, 90]"},
+			}}},
+		},
+	},
+	{
+		name:  "Storage item error",
+		fail:  map[string]error{"ZAPLZ": errFoo},
+		ports: []*domain.Port{nil, {ID: "AEAJM", Name: "Ajman"}, {ID: "ZAPLZ", Name: "Port Elizabeth"}},
+		saved: []*domain.Port{{ID: "AEAJM", Name: "Ajman", Revision: 1}},
+		failures: []domain.BatchFailure{
+			{Index: 0, Err: service.ErrInvalidInput},
+			{Index: 2, ID: "ZAPLZ", Err: errFoo},
+		},
+	},
+}
+
+func TestSaveBatch(t *testing.T) {
+	for _, ex := range examplesSaveBatch {
+		t.Run(ex.name, func(t *testing.T) {
+			storage := memory.New()
+			ps := service.NewPortService(failingStorage{Storage: storage, fail: ex.fail})
+			res, err := ps.SaveBatch(context.TODO(), ex.ports)
+			page, _ := storage.List(context.TODO(), domain.PortFilter{}, "", service.MaxListLimit)
+			assert.Equal(t, ex.saved, page.Ports, "Should save only valid ports")
+			assert.Equal(t, len(ex.saved), res.Inserted, "Should return storage result")
+			if ex.failures == nil {
+				assert.Nil(t, err, "Should return no error")
+				return
+			}
+			var batchErr *domain.BatchError
+			if assert.True(t, errors.As(err, &batchErr), "Should return batch error") {
+				assert.Equal(t, ex.failures, batchErr.Failures, "Should report failures by original index")
+			}
+		})
+	}
+}
+
+func TestSaveBatchStorageError(t *testing.T) {
+	ps := service.NewPortService(memory.New())
+	_, err := ps.SaveBatch(canceledContext(), []*domain.Port{{ID: "AEAJM", Name: "Ajman"}})
+	assert.True(t, errors.Is(err, context.Canceled), "Error should be same as expected")
+}
+
+func TestWatch(t *testing.T) {
+	storage := newStorage(&domain.Port{ID: "AEAJM"})
+	ps := service.NewPortService(storage)
+
+	err := ps.Watch(context.TODO(), -1, func(domain.PortEvent) error { return nil })
+	assert.True(t, errors.Is(err, service.ErrInvalidSequence), "Should reject negative sequence")
+
+	var events []domain.PortEvent
+	err = ps.Watch(context.TODO(), 1, func(e domain.PortEvent) error {
+		events = append(events, e)
+		return errFoo
+	})
+	assert.True(t, errors.Is(err, errFoo), "Error should be same as expected")
+	assert.Equal(t,
+		[]domain.PortEvent{{Sequence: 1, Op: domain.PortCreated, Port: &domain.Port{ID: "AEAJM", Revision: 1}}},
+		events, "Should pass changes from storage")
+}
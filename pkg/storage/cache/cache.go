@@ -0,0 +1,277 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sp4rd4/ports/pkg/domain"
+)
+
+const errorTag = "cache"
+
+// Storage is a read-through cache of ports by id in front of another repository.
+// Entries are evicted when least recently used or once ttl passes, missing ports are cached too.
+// Concurrent misses of the same id share a single request to the underlying repository.
+// Writes go straight to the underlying repository and drop cached entries of written ids,
+// queries other than Get are not cached. Imports made through Importer are handled as writes too.
+type Storage struct {
+	domain.PortRepository
+
+	size        int
+	ttl         time.Duration
+	negativeTTL time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	calls   map[string]*call
+	stats   Stats
+	// importing counts running imports, ports are not cached while there are any
+	importing int
+}
+
+// Stats counts Get calls served from cache and passed to the underlying repository.
+type Stats struct {
+	Hits    uint64
+	Misses  uint64
+	Entries int
+}
+
+type entry struct {
+	id      string
+	port    *domain.Port
+	expires time.Time
+}
+
+// call is in-flight Get of the underlying repository, waiters are released once done is closed.
+type call struct {
+	done  chan struct{}
+	port  *domain.Port
+	err   error
+	stale bool
+}
+
+var _ domain.PortRepository = &Storage{}
+
+type Option func(*Storage)
+
+// NegativeTTL sets how long ErrNotFound is cached, ttl is used by default.
+func NegativeTTL(ttl time.Duration) Option {
+	return func(s *Storage) {
+		s.negativeTTL = ttl
+	}
+}
+
+// New creates cache keeping up to size ports for ttl, size must be positive.
+func New(storage domain.PortRepository, size int, ttl time.Duration, opts ...Option) *Storage {
+	s := &Storage{
+		PortRepository: storage,
+		size:           size,
+		ttl:            ttl,
+		negativeTTL:    ttl,
+		entries:        make(map[string]*list.Element, size),
+		lru:            list.New(),
+		calls:          make(map[string]*call),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+func (s *Storage) Get(ctx context.Context, id string) (*domain.Port, error) {
+	// Get is counted once, by its first lookup, retries after canceled request of another caller are not
+	missed := false
+	for {
+		s.mu.Lock()
+		if el, ok := s.entries[id]; ok {
+			e := el.Value.(*entry)
+			if time.Now().Before(e.expires) {
+				if !missed {
+					s.stats.Hits++
+				}
+				s.lru.MoveToFront(el)
+				s.mu.Unlock()
+				return found(e.port)
+			}
+			s.remove(el)
+		}
+		if !missed {
+			s.stats.Misses++
+			missed = true
+		}
+		c, ok := s.calls[id]
+		if !ok {
+			c = &call{done: make(chan struct{})}
+			s.calls[id] = c
+		}
+		s.mu.Unlock()
+
+		if !ok {
+			s.fetch(ctx, id, c)
+		}
+		select {
+		case <-c.done:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("[%v] get: %w", errorTag, ctx.Err())
+		}
+		if ok && canceled(c.err) {
+			// context of the caller which made the request is done, but this one is not
+			continue
+		}
+		if c.err != nil {
+			return nil, fmt.Errorf("[%v] get: %w", errorTag, c.err)
+		}
+		return c.port.Clone(), nil
+	}
+}
+
+func canceled(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func found(port *domain.Port) (*domain.Port, error) {
+	if port == nil {
+		return nil, fmt.Errorf("[%v] get: %w", errorTag, domain.ErrNotFound)
+	}
+	return port.Clone(), nil
+}
+
+// fetch gets port from the underlying repository and caches it unless it was written meanwhile.
+func (s *Storage) fetch(ctx context.Context, id string, c *call) {
+	c.port, c.err = s.PortRepository.Get(ctx, id)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	defer close(c.done)
+	delete(s.calls, id)
+	if c.stale || s.importing > 0 {
+		return
+	}
+	switch {
+	case c.err == nil:
+		s.add(id, c.port, s.ttl)
+	case errors.Is(c.err, domain.ErrNotFound):
+		s.add(id, nil, s.negativeTTL)
+	}
+}
+
+func (s *Storage) add(id string, port *domain.Port, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	if el, ok := s.entries[id]; ok {
+		s.remove(el)
+	}
+	s.entries[id] = s.lru.PushFront(&entry{id: id, port: port, expires: time.Now().Add(ttl)})
+	for s.lru.Len() > s.size {
+		s.remove(s.lru.Back())
+	}
+}
+
+func (s *Storage) remove(el *list.Element) {
+	s.lru.Remove(el)
+	delete(s.entries, el.Value.(*entry).id)
+}
+
+// invalidate drops cached ports and marks in-flight fetches of them as stale.
+func (s *Storage) invalidate(ids ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range ids {
+		if el, ok := s.entries[id]; ok {
+			s.remove(el)
+		}
+		if c, ok := s.calls[id]; ok {
+			c.stale = true
+		}
+	}
+}
+
+func (s *Storage) Save(ctx context.Context, port *domain.Port, expectedRevision int64) (*domain.Port, error) {
+	if port != nil {
+		defer s.invalidate(port.ID)
+	}
+	return s.PortRepository.Save(ctx, port, expectedRevision)
+}
+
+func (s *Storage) Update(
+	ctx context.Context, port *domain.Port, fields []string, expectedRevision int64,
+) (*domain.Port, error) {
+	if port != nil {
+		defer s.invalidate(port.ID)
+	}
+	return s.PortRepository.Update(ctx, port, fields, expectedRevision)
+}
+
+func (s *Storage) Delete(ctx context.Context, id string, expectedRevision int64) error {
+	defer s.invalidate(id)
+	return s.PortRepository.Delete(ctx, id, expectedRevision)
+}
+
+func (s *Storage) SaveBatch(ctx context.Context, ports []*domain.Port) (domain.SaveResult, error) {
+	ids := make([]string, 0, len(ports))
+	for _, p := range ports {
+		if p != nil {
+			ids = append(ids, p.ID)
+		}
+	}
+	defer s.invalidate(ids...)
+	return s.PortRepository.SaveBatch(ctx, ports)
+}
+
+type importer struct {
+	cache    *Storage
+	importer domain.PortImporter
+}
+
+// Importer wraps importer writing to the underlying repository, so ports it imports are not served stale:
+// ports read while import is running are not cached and cached ones are dropped as they are passed to it.
+func (s *Storage) Importer(imp domain.PortImporter) domain.PortImporter {
+	return importer{cache: s, importer: imp}
+}
+
+func (i importer) Import(ctx context.Context, ports <-chan *domain.Port, batchSize int) (domain.ImportSummary, error) {
+	i.cache.mu.Lock()
+	i.cache.importing++
+	i.cache.mu.Unlock()
+	defer func() {
+		i.cache.mu.Lock()
+		i.cache.importing--
+		i.cache.mu.Unlock()
+	}()
+
+	forwarded := make(chan *domain.Port)
+	go func() {
+		defer close(forwarded)
+		for p := range ports {
+			if p != nil {
+				i.cache.invalidate(p.ID)
+			}
+			forwarded <- p
+		}
+	}()
+	// importer may stop reading on failure, ports are consumed till the end so forwarding is not blocked
+	defer func() {
+		go drain(forwarded)
+	}()
+	return i.importer.Import(ctx, forwarded, batchSize)
+}
+
+func drain(ports <-chan *domain.Port) {
+	for range ports {
+	}
+}
+
+// Stats returns counters collected since cache creation.
+func (s *Storage) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := s.stats
+	stats.Entries = s.lru.Len()
+	return stats
+}
@@ -0,0 +1,249 @@
+package cache_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sp4rd4/ports/pkg/domain"
+	"github.com/sp4rd4/ports/pkg/storage/cache"
+	"github.com/sp4rd4/ports/pkg/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingStorage counts Get calls, which are blocked until release is closed if it is set.
+type countingStorage struct {
+	*memory.Storage
+	gets    int64
+	release chan struct{}
+}
+
+func (cs *countingStorage) Get(ctx context.Context, id string) (*domain.Port, error) {
+	atomic.AddInt64(&cs.gets, 1)
+	if cs.release != nil {
+		select {
+		case <-cs.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return cs.Storage.Get(ctx, id)
+}
+
+func newStorage(ports ...*domain.Port) *countingStorage {
+	storage := memory.New()
+	for _, p := range ports {
+		if _, err := storage.Save(context.TODO(), p, 0); err != nil {
+			panic(err)
+		}
+	}
+	return &countingStorage{Storage: storage}
+}
+
+func TestGet(t *testing.T) {
+	storage := newStorage(&domain.Port{ID: "AEAJM", Name: "Ajman", Alias: domain.StringArray{"Ajman"}})
+	c := cache.New(storage, 10, time.Minute)
+
+	port, err := c.Get(context.TODO(), "AEAJM")
+	assert.Nil(t, err, "Should get port with no error")
+	port.Alias[0] = "Changed"
+
+	port, err = c.Get(context.TODO(), "AEAJM")
+	assert.Nil(t, err, "Should get port with no error")
+	assert.Equal(t, &domain.Port{ID: "AEAJM", Name: "Ajman", Alias: domain.StringArray{"Ajman"}, Revision: 1}, port,
+		"Should not share cached port with callers")
+	assert.Equal(t, int64(1), storage.gets, "Should get port from storage once")
+	assert.Equal(t, cache.Stats{Hits: 1, Misses: 1, Entries: 1}, c.Stats(), "Should count hits and misses")
+}
+
+func TestGetNotFound(t *testing.T) {
+	storage := newStorage()
+	c := cache.New(storage, 10, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		port, err := c.Get(context.TODO(), "AEAJM")
+		assert.Nil(t, port, "Should return nil port")
+		assert.True(t, errors.Is(err, domain.ErrNotFound), "Should return not found error")
+	}
+	assert.Equal(t, int64(1), storage.gets, "Should cache missing port")
+
+	_, err := c.Save(context.TODO(), &domain.Port{ID: "AEAJM", Name: "Ajman"}, 0)
+	assert.Nil(t, err, "Should save port with no error")
+	port, err := c.Get(context.TODO(), "AEAJM")
+	assert.Nil(t, err, "Should get saved port")
+	assert.Equal(t, "Ajman", port.Name, "Should return saved port")
+}
+
+func TestGetError(t *testing.T) {
+	storage := newStorage(&domain.Port{ID: "AEAJM"})
+	c := cache.New(storage, 10, time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.Get(ctx, "AEAJM")
+	assert.True(t, errors.Is(err, context.Canceled), "Should return storage error")
+	_, err = c.Get(context.TODO(), "AEAJM")
+	assert.Nil(t, err, "Should not cache errors")
+	assert.Equal(t, int64(2), storage.gets, "Should get port from storage again")
+}
+
+func TestTTL(t *testing.T) {
+	storage := newStorage(&domain.Port{ID: "AEAJM"})
+	c := cache.New(storage, 10, 10*time.Millisecond, cache.NegativeTTL(0))
+
+	_, _ = c.Get(context.TODO(), "AEAJM")
+	time.Sleep(20 * time.Millisecond)
+	_, _ = c.Get(context.TODO(), "AEAJM")
+	assert.Equal(t, int64(2), storage.gets, "Should expire cached port")
+
+	_, _ = c.Get(context.TODO(), "ZAPLZ")
+	_, _ = c.Get(context.TODO(), "ZAPLZ")
+	assert.Equal(t, int64(4), storage.gets, "Should not cache missing port without negative ttl")
+}
+
+func TestEviction(t *testing.T) {
+	storage := newStorage(&domain.Port{ID: "AEAJM"}, &domain.Port{ID: "BEANR"}, &domain.Port{ID: "NLRTM"})
+	c := cache.New(storage, 2, time.Minute)
+
+	for _, id := range []string{"AEAJM", "BEANR", "AEAJM", "NLRTM"} {
+		_, _ = c.Get(context.TODO(), id)
+	}
+	assert.Equal(t, int64(3), storage.gets, "Should get recently used port from cache")
+
+	_, _ = c.Get(context.TODO(), "AEAJM")
+	assert.Equal(t, int64(3), storage.gets, "Should keep recently used port")
+	_, _ = c.Get(context.TODO(), "BEANR")
+	assert.Equal(t, int64(4), storage.gets, "Should evict least recently used port")
+	assert.Equal(t, 2, c.Stats().Entries, "Should keep cache size")
+}
+
+func TestInvalidate(t *testing.T) {
+	storage := newStorage(&domain.Port{ID: "AEAJM"}, &domain.Port{ID: "BEANR"})
+	c := cache.New(storage, 10, time.Minute)
+	_, _ = c.Get(context.TODO(), "AEAJM")
+	_, _ = c.Get(context.TODO(), "BEANR")
+
+	_, err := c.SaveBatch(context.TODO(), []*domain.Port{nil, {ID: "AEAJM", Name: "Ajman"}})
+	assert.NotNil(t, err, "Should return storage error")
+	port, err := c.Get(context.TODO(), "AEAJM")
+	assert.Nil(t, err, "Should get port with no error")
+	assert.Equal(t, "Ajman", port.Name, "Should drop ports saved in batch")
+
+	_, err = c.Update(context.TODO(), &domain.Port{ID: "AEAJM", City: "Ajman"}, []string{"city"}, 0)
+	assert.Nil(t, err, "Should update port with no error")
+	port, err = c.Get(context.TODO(), "AEAJM")
+	assert.Nil(t, err, "Should get port with no error")
+	assert.Equal(t, "Ajman", port.City, "Should drop updated port")
+
+	err = c.Delete(context.TODO(), "BEANR", 0)
+	assert.Nil(t, err, "Should delete port with no error")
+	_, err = c.Get(context.TODO(), "BEANR")
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "Should drop deleted port")
+}
+
+func TestSingleflight(t *testing.T) {
+	storage := newStorage(&domain.Port{ID: "AEAJM"})
+	storage.release = make(chan struct{})
+	c := cache.New(storage, 10, time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			port, err := c.Get(context.TODO(), "AEAJM")
+			assert.Nil(t, err, "Should get port with no error")
+			assert.Equal(t, "AEAJM", port.ID, "Should return requested port")
+		}()
+	}
+	waitMisses(t, c, 10)
+	close(storage.release)
+	wg.Wait()
+	assert.Equal(t, int64(1), storage.gets, "Should get port from storage once")
+}
+
+func TestSingleflightCanceled(t *testing.T) {
+	storage := newStorage(&domain.Port{ID: "AEAJM"})
+	storage.release = make(chan struct{})
+	c := cache.New(storage, 10, time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error)
+	go func() {
+		_, err := c.Get(ctx, "AEAJM")
+		leader <- err
+	}()
+	waitMisses(t, c, 1)
+	waiter := make(chan error)
+	go func() {
+		_, err := c.Get(context.TODO(), "AEAJM")
+		waiter <- err
+	}()
+	waitMisses(t, c, 2)
+
+	cancel()
+	assert.True(t, errors.Is(<-leader, context.Canceled), "Should cancel request of leader")
+	close(storage.release)
+	assert.Nil(t, <-waiter, "Should repeat request for waiter with live context")
+	assert.Equal(t, cache.Stats{Misses: 2, Entries: 1}, c.Stats(), "Should count miss once per get")
+}
+
+func waitMisses(t *testing.T, c *cache.Storage, misses uint64) {
+	deadline := time.Now().Add(time.Second)
+	for c.Stats().Misses < misses {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d misses, got %d", misses, c.Stats().Misses)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// savingImporter saves ports to storage one by one and calls after once each is saved.
+type savingImporter struct {
+	storage *countingStorage
+	after   func()
+}
+
+func (si savingImporter) Import(ctx context.Context, ports <-chan *domain.Port, _ int) (domain.ImportSummary, error) {
+	summary := domain.ImportSummary{}
+	for p := range ports {
+		if _, err := si.storage.Save(ctx, p, 0); err != nil {
+			return summary, err
+		}
+		summary.Total++
+		si.after()
+	}
+	return summary, nil
+}
+
+func TestImporter(t *testing.T) {
+	storage := newStorage(&domain.Port{ID: "AEAJM", Name: "Ajman"})
+	c := cache.New(storage, 10, time.Minute)
+	_, err := c.Get(context.TODO(), "AEAJM")
+	assert.Nil(t, err, "Should get port with no error")
+	_, err = c.Get(context.TODO(), "ZAPLZ")
+	assert.True(t, errors.Is(err, domain.ErrNotFound), "Should return not found error")
+
+	imp := c.Importer(savingImporter{storage: storage, after: func() {
+		_, _ = c.Get(context.TODO(), "ZAPLZ")
+	}})
+	ports := make(chan *domain.Port)
+	go func() {
+		defer close(ports)
+		ports <- &domain.Port{ID: "AEAJM", Name: "Ajman Port"}
+		ports <- &domain.Port{ID: "ZAPLZ", Name: "Port Elizabeth"}
+	}()
+	summary, err := imp.Import(context.TODO(), ports, 1)
+	assert.Nil(t, err, "Should import with no error")
+	assert.Equal(t, 2, summary.Total, "Should return summary of importer")
+
+	port, err := c.Get(context.TODO(), "AEAJM")
+	assert.Nil(t, err, "Should get port with no error")
+	assert.Equal(t, "Ajman Port", port.Name, "Should not return port cached before import")
+	port, err = c.Get(context.TODO(), "ZAPLZ")
+	assert.Nil(t, err, "Should not return not found cached during import")
+	assert.Equal(t, "Port Elizabeth", port.Name, "Should return imported port")
+}
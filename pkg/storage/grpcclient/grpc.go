@@ -2,15 +2,29 @@ package grpcclient
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"strconv"
+	"time"
 
+	"github.com/gogo/protobuf/types"
 	"github.com/sp4rd4/ports/pkg/domain"
 	"github.com/sp4rd4/ports/pkg/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
-const errorTag = "grpc-store"
+const (
+	errorTag = "grpc-store"
+
+	// watchRetryDelay is a pause before watch is resumed after server became unavailable.
+	watchRetryDelay = time.Second
+)
+
+var errStreamClosed = errors.New("stream closed by server")
 
 type storage struct {
 	client proto.PortsClient
@@ -20,23 +34,324 @@ func New(client proto.PortsClient) domain.PortRepository {
 	return &storage{client: client}
 }
 
-func (s storage) Save(port *domain.Port) error {
-	_, err := s.client.Save(context.Background(), proto.PortDomainToProto(port))
+func NewImporter(client proto.PortsClient) domain.PortImporter {
+	return &storage{client: client}
+}
+
+// forwardSource passes source of changes made with ctx to port domain service.
+func forwardSource(ctx context.Context) context.Context {
+	if source := domain.SourceFromContext(ctx); source != "" {
+		return metadata.AppendToOutgoingContext(ctx, proto.SourceMetadataKey, source)
+	}
+	return ctx
+}
+
+// Save uses SaveIfRevision only for conditional writes, so unconditional ones work with servers
+// which do not know about revisions. Such servers respond with empty port.
+func (s storage) Save(ctx context.Context, port *domain.Port, expectedRevision int64) (*domain.Port, error) {
+	var (
+		stored *proto.Port
+		err    error
+	)
+	if expectedRevision == 0 {
+		stored, err = s.client.Save(forwardSource(ctx), proto.PortDomainToProto(port))
+	} else {
+		stored, err = s.client.SaveIfRevision(forwardSource(ctx), &proto.SaveRequest{
+			Port:             proto.PortDomainToProto(port),
+			ExpectedRevision: expectedRevision,
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[%v] save: %w", errorTag, convertErrFromProto(err))
+	}
+	return proto.PortProtoToDomain(stored), nil
+}
+
+func (s storage) Update(
+	ctx context.Context, port *domain.Port, fields []string, expectedRevision int64,
+) (*domain.Port, error) {
+	updated, err := s.client.UpdatePort(forwardSource(ctx), &proto.UpdatePortRequest{
+		Port:             proto.PortDomainToProto(port),
+		UpdateMask:       &types.FieldMask{Paths: fields},
+		ExpectedRevision: expectedRevision,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[%v] update: %w", errorTag, convertErrFromProto(err))
+	}
+
+	return proto.PortProtoToDomain(updated), nil
+}
+
+func (s storage) Get(ctx context.Context, id string) (*domain.Port, error) {
+	port, err := s.client.Get(ctx, &proto.PortRequest{Id: id})
+	if err != nil {
+		return nil, fmt.Errorf("[%v] get: %w", errorTag, convertErrFromProto(err))
+	}
+
+	return proto.PortProtoToDomain(port), nil
+}
+
+func (s storage) ByUnlocode(ctx context.Context, code string) ([]*domain.Port, error) {
+	ports, err := lookupFromProto(s.client.GetByUnlocode(ctx, &proto.LookupRequest{Key: code}))
+	if err != nil {
+		return nil, fmt.Errorf("[%v] by unlocode: %w", errorTag, err)
+	}
+	return ports, nil
+}
+
+func (s storage) ByAlias(ctx context.Context, name string) ([]*domain.Port, error) {
+	ports, err := lookupFromProto(s.client.GetByAlias(ctx, &proto.LookupRequest{Key: name}))
 	if err != nil {
-		return fmt.Errorf("[%v] save: %w", errorTag, err)
+		return nil, fmt.Errorf("[%v] by alias: %w", errorTag, err)
+	}
+	return ports, nil
+}
+
+// lookupFromProto turns lookup result back into candidates, server has already picked port if it could.
+func lookupFromProto(res *proto.PortLookup, err error) ([]*domain.Port, error) {
+	switch {
+	case status.Code(err) == codes.NotFound:
+		return []*domain.Port{}, nil
+	case err != nil:
+		return nil, convertErrFromProto(err)
+	case res.GetPort() != nil:
+		return []*domain.Port{proto.PortProtoToDomain(res.GetPort())}, nil
+	default:
+		return proto.PortsProtoToDomain(res.GetCandidates()), nil
+	}
+}
+
+func (s storage) GetAsOf(ctx context.Context, id string, at time.Time) (*domain.Port, error) {
+	port, err := s.client.Get(ctx, &proto.PortRequest{Id: id, AsOf: &at})
+	if err != nil {
+		return nil, fmt.Errorf("[%v] get as of: %w", errorTag, convertErrFromProto(err))
+	}
+
+	return proto.PortProtoToDomain(port), nil
+}
+
+func (s storage) List(ctx context.Context, filter domain.PortFilter, cursor string, limit int) (domain.Page, error) {
+	page, err := s.client.List(ctx, &proto.ListRequest{
+		Cursor: cursor,
+		Limit:  int32(limit),
+		Filter: proto.FilterDomainToProto(filter),
+	})
+	if err != nil {
+		return domain.Page{}, fmt.Errorf("[%v] list: %w", errorTag, convertErrFromProto(err))
+	}
+	return proto.PageProtoToDomain(page), nil
+}
+
+func (s storage) Nearby(
+	ctx context.Context, point domain.Location, radiusKm float64, limit int,
+) ([]domain.NearbyPort, error) {
+	ports, err := s.client.Nearby(ctx, &proto.NearbyRequest{
+		Point:    &proto.Location{Latitude: point.Latitude, Longitude: point.Longitude},
+		RadiusKm: radiusKm,
+		Limit:    int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[%v] nearby: %w", errorTag, convertErrFromProto(err))
+	}
+	return proto.NearbyProtoToDomain(ports), nil
+}
+
+func (s storage) SearchByName(ctx context.Context, query string, limit int) ([]domain.MatchedPort, error) {
+	ports, err := s.client.Search(ctx, &proto.SearchRequest{Query: query, Limit: int32(limit)})
+	if err != nil {
+		return nil, fmt.Errorf("[%v] search by name: %w", errorTag, convertErrFromProto(err))
+	}
+	return proto.MatchedProtoToDomain(ports), nil
+}
+
+func (s storage) WithinBox(ctx context.Context, box domain.BoundingBox, fn func(*domain.Port) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := s.client.WithinBox(ctx, proto.BoxDomainToProto(box))
+	if err != nil {
+		return fmt.Errorf("[%v] within box: %w", errorTag, convertErrFromProto(err))
+	}
+	for {
+		var port *proto.Port
+		port, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("[%v] within box: %w", errorTag, convertErrFromProto(err))
+		}
+		if err = fn(proto.PortProtoToDomain(port)); err != nil {
+			return fmt.Errorf("[%v] within box: %w", errorTag, err)
+		}
+	}
+}
+
+// Watch resumes from the last received sequence when server becomes unavailable. Watch started from 0
+// misses changes made while it reconnects if no event was received before.
+func (s storage) Watch(ctx context.Context, fromSequence int64, fn func(domain.PortEvent) error) error {
+	for {
+		err := s.watch(ctx, &fromSequence, fn)
+		if status.Code(err) != codes.Unavailable {
+			return fmt.Errorf("[%v] watch: %w", errorTag, convertErrFromProto(err))
+		}
+		select {
+		case <-time.After(watchRetryDelay):
+		case <-ctx.Done():
+			return fmt.Errorf("[%v] watch: %w", errorTag, ctx.Err())
+		}
+	}
+}
+
+// watch streams events until error, fromSequence is advanced past every event passed to fn.
+func (s storage) watch(ctx context.Context, fromSequence *int64, fn func(domain.PortEvent) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := s.client.Watch(ctx, &proto.WatchRequest{FromSequence: *fromSequence})
+	if err != nil {
+		return err
+	}
+	for {
+		var event *proto.PortEvent
+		event, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return errStreamClosed
+		}
+		if err != nil {
+			return err
+		}
+		if err = fn(proto.EventProtoToDomain(event)); err != nil {
+			return err
+		}
+		*fromSequence = event.GetSequence() + 1
+	}
+}
+
+func (s storage) History(ctx context.Context, id string) ([]domain.PortChange, error) {
+	history, err := s.client.History(ctx, &proto.PortRequest{Id: id})
+	if err != nil {
+		return nil, fmt.Errorf("[%v] history: %w", errorTag, convertErrFromProto(err))
+	}
+	return proto.HistoryProtoToDomain(history), nil
+}
+
+func (s storage) Delete(ctx context.Context, id string, expectedRevision int64) error {
+	_, err := s.client.Delete(forwardSource(ctx), &proto.PortRequest{Id: id, ExpectedRevision: expectedRevision})
+	if err != nil {
+		return fmt.Errorf("[%v] delete: %w", errorTag, convertErrFromProto(err))
 	}
 	return nil
 }
 
-func (s storage) Get(id string) (*domain.Port, error) {
-	port, err := s.client.Get(context.Background(), &proto.PortRequest{Id: id})
+func (s storage) SaveBatch(ctx context.Context, ports []*domain.Port) (domain.SaveResult, error) {
+	res, err := s.client.SaveBatch(forwardSource(ctx), &proto.PortBatch{Ports: proto.PortsDomainToProto(ports)})
+	if err != nil {
+		return domain.SaveResult{}, fmt.Errorf("[%v] save batch: %w", errorTag, convertErrFromProto(err))
+	}
+	saveRes := domain.SaveResult{Inserted: int(res.GetInserted()), Updated: int(res.GetUpdated())}
+	if len(res.GetFailures()) == 0 {
+		return saveRes, nil
+	}
+
+	return saveRes, fmt.Errorf("[%v] save batch: %w", errorTag, batchFailuresFromProto(res.GetFailures()))
+}
+
+// Import sends ports over single client stream, server saves them in chunks of batchSize
+// and replies with summary once stream is closed.
+func (s storage) Import(ctx context.Context, ports <-chan *domain.Port, batchSize int) (domain.ImportSummary, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, proto.BatchSizeMetadataKey, strconv.Itoa(batchSize))
+	stream, err := s.client.ImportPorts(forwardSource(ctx))
 	if err != nil {
-		st := status.Convert(err)
-		if st.Code() == codes.NotFound {
-			return nil, fmt.Errorf("[%v] save: %w", errorTag, domain.ErrNotFound)
+		return domain.ImportSummary{}, fmt.Errorf("[%v] import: %w", errorTag, convertErrFromProto(err))
+	}
+
+	for p := range ports {
+		err = stream.Send(proto.PortDomainToProto(p))
+		if errors.Is(err, io.EOF) {
+			// server aborted the stream, actual error is returned by CloseAndRecv
+			go drain(ports)
+			break
+		}
+		if err != nil {
+			go drain(ports)
+			return domain.ImportSummary{}, fmt.Errorf("[%v] import: %w", errorTag, convertErrFromProto(err))
 		}
-		return nil, fmt.Errorf("[%v] save: %w", errorTag, err)
 	}
 
-	return proto.PortProtoToDomain(port), nil
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return domain.ImportSummary{}, fmt.Errorf("[%v] import: %w", errorTag, convertErrFromProto(err))
+	}
+
+	summary := domain.ImportSummary{
+		Inserted: int(res.GetInserted()),
+		Updated:  int(res.GetUpdated()),
+		Rejected: int(res.GetRejected()),
+		Total:    int(res.GetTotal()),
+	}
+	if len(res.GetFailures()) > 0 {
+		summary.Failures = batchFailuresFromProto(res.GetFailures()).Failures
+	}
+	if len(res.GetRejectedByCode()) > 0 {
+		summary.RejectedByCode = make(map[string]int, len(res.GetRejectedByCode()))
+		for code, n := range res.GetRejectedByCode() {
+			summary.RejectedByCode[code] = int(n)
+		}
+	}
+	return summary, nil
+}
+
+// drain unblocks producer of ports that are not going to be sent.
+func drain(ports <-chan *domain.Port) {
+	for range ports {
+	}
+}
+
+func batchFailuresFromProto(failures []*proto.BatchFailure) *domain.BatchError {
+	batchErr := &domain.BatchError{}
+	for _, f := range failures {
+		itemErr := status.Error(codes.Code(f.GetCode()), f.GetMessage())
+		batchErr.Add(int(f.GetIndex()), f.GetId(), convertErrFromProto(itemErr))
+	}
+	return batchErr
+}
+
+// convertErrFromProto maps grpc status codes back to errors known to callers,
+// so cancellation and deadlines survive the round trip to portdomain.
+func convertErrFromProto(err error) error {
+	switch status.Code(err) {
+	case codes.NotFound:
+		return domain.ErrNotFound
+	case codes.FailedPrecondition:
+		return domain.ErrRevisionMismatch
+	case codes.OutOfRange:
+		return domain.ErrEventsPruned
+	case codes.Canceled:
+		return context.Canceled
+	case codes.DeadlineExceeded:
+		return context.DeadlineExceeded
+	case codes.InvalidArgument:
+		if verr := validationErrFromProto(status.Convert(err)); verr != nil {
+			return verr
+		}
+		return err
+	default:
+		return err
+	}
+}
+
+// validationErrFromProto restores *domain.ValidationError from google.rpc.BadRequest details.
+func validationErrFromProto(st *status.Status) error {
+	verr := &domain.ValidationError{}
+	for _, detail := range st.Details() {
+		badRequest, ok := detail.(*errdetails.BadRequest)
+		if !ok {
+			continue
+		}
+		for _, v := range badRequest.GetFieldViolations() {
+			verr.Add(v.GetField(), v.GetDescription())
+		}
+	}
+	return verr.ErrOrNil()
 }
@@ -3,29 +3,231 @@ package grpcclient_test
 import (
 	"context"
 	"errors"
+	"io"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/types"
 	"github.com/sp4rd4/ports/pkg/domain"
 	"github.com/sp4rd4/ports/pkg/proto"
 	"github.com/sp4rd4/ports/pkg/storage/grpcclient"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
 type MockPortsClient struct {
 	err          error
+	batchResult  *proto.BatchResult
+	stream       *mockImportStream
 	memory       *domain.Port
 	grpcResponse *proto.Port
+	saved        *proto.Port
+	boxStream    *mockBoxStream
+	watchStreams []*mockWatchStream
+	watchFrom    []int64
+	history      *proto.PortHistory
+	source       []string
+	batchSize    []string
+	asOf         *time.Time
+	revision     int64
+	conditional  bool
+	update       *proto.UpdatePortRequest
+	lookup       *proto.PortLookup
+	key          string
+	search       *proto.SearchRequest
 }
 
-func (c *MockPortsClient) Save(_ context.Context, _ *proto.Port, _ ...grpc.CallOption) (*types.Empty, error) {
-	return &types.Empty{}, c.err
+func (c *MockPortsClient) History(
+	_ context.Context, _ *proto.PortRequest, _ ...grpc.CallOption,
+) (*proto.PortHistory, error) {
+	return c.history, c.err
+}
+
+func (c *MockPortsClient) Watch(
+	_ context.Context, in *proto.WatchRequest, _ ...grpc.CallOption,
+) (proto.Ports_WatchClient, error) {
+	c.watchFrom = append(c.watchFrom, in.FromSequence)
+	if c.err != nil {
+		return nil, c.err
+	}
+	stream := c.watchStreams[0]
+	c.watchStreams = c.watchStreams[1:]
+	return stream, nil
+}
+
+type mockWatchStream struct {
+	grpc.ClientStream
+	errRecv error
+	events  []*proto.PortEvent
+}
+
+func (ms *mockWatchStream) Recv() (*proto.PortEvent, error) {
+	if len(ms.events) == 0 {
+		return nil, ms.errRecv
+	}
+	e := ms.events[0]
+	ms.events = ms.events[1:]
+	return e, nil
+}
+
+func (c *MockPortsClient) Save(ctx context.Context, _ *proto.Port, _ ...grpc.CallOption) (*proto.Port, error) {
+	c.revision, c.conditional = 0, false
+	md, _ := metadata.FromOutgoingContext(ctx)
+	c.source = md.Get(proto.SourceMetadataKey)
+	return c.saved, c.err
+}
+
+func (c *MockPortsClient) SaveIfRevision(
+	ctx context.Context, in *proto.SaveRequest, _ ...grpc.CallOption,
+) (*proto.Port, error) {
+	c.revision, c.conditional = in.ExpectedRevision, true
+	md, _ := metadata.FromOutgoingContext(ctx)
+	c.source = md.Get(proto.SourceMetadataKey)
+	return c.saved, c.err
+}
+
+func (c *MockPortsClient) GetByUnlocode(
+	_ context.Context, in *proto.LookupRequest, _ ...grpc.CallOption,
+) (*proto.PortLookup, error) {
+	c.key = in.Key
+	return c.lookup, c.err
+}
+
+func (c *MockPortsClient) GetByAlias(
+	_ context.Context, in *proto.LookupRequest, _ ...grpc.CallOption,
+) (*proto.PortLookup, error) {
+	c.key = in.Key
+	return c.lookup, c.err
+}
+
+func (c *MockPortsClient) UpdatePort(
+	ctx context.Context, in *proto.UpdatePortRequest, _ ...grpc.CallOption,
+) (*proto.Port, error) {
+	c.update = in
+	md, _ := metadata.FromOutgoingContext(ctx)
+	c.source = md.Get(proto.SourceMetadataKey)
+	return c.grpcResponse, c.err
+}
+
+func (c *MockPortsClient) SaveBatch(
+	_ context.Context, _ *proto.PortBatch, _ ...grpc.CallOption,
+) (*proto.BatchResult, error) {
+	return c.batchResult, c.err
+}
+
+func (c *MockPortsClient) ImportPorts(
+	ctx context.Context, _ ...grpc.CallOption,
+) (proto.Ports_ImportPortsClient, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	c.batchSize = md.Get(proto.BatchSizeMetadataKey)
+	return c.stream, nil
+}
+
+type mockImportStream struct {
+	grpc.ClientStream
+	errSend error
+	errRecv error
+	summary *proto.ImportSummary
+	ports   []*proto.Port
+}
+
+func (ms *mockImportStream) Send(p *proto.Port) error {
+	if ms.errSend != nil {
+		return ms.errSend
+	}
+	ms.ports = append(ms.ports, p)
+	return nil
+}
+
+func (ms *mockImportStream) CloseAndRecv() (*proto.ImportSummary, error) {
+	return ms.summary, ms.errRecv
+}
+
+func (c *MockPortsClient) List(
+	_ context.Context, in *proto.ListRequest, _ ...grpc.CallOption,
+) (*proto.PortPage, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	if c.memory == nil || c.memory.ID <= in.Cursor || in.Filter.GetCountry() != c.memory.Country {
+		return &proto.PortPage{}, nil
+	}
+	return &proto.PortPage{Ports: []*proto.Port{proto.PortDomainToProto(c.memory)}}, nil
+}
+
+func (c *MockPortsClient) Search(
+	_ context.Context, in *proto.SearchRequest, _ ...grpc.CallOption,
+) (*proto.MatchedPorts, error) {
+	c.search = in
+	if c.err != nil {
+		return nil, c.err
+	}
+	if c.memory == nil {
+		return &proto.MatchedPorts{}, nil
+	}
+	return &proto.MatchedPorts{
+		Ports: []*proto.MatchedPort{{Port: proto.PortDomainToProto(c.memory), Score: 0.8}},
+	}, nil
+}
+
+func (c *MockPortsClient) Nearby(
+	_ context.Context, in *proto.NearbyRequest, _ ...grpc.CallOption,
+) (*proto.NearbyPorts, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	if c.memory == nil || in.RadiusKm < 1 {
+		return &proto.NearbyPorts{}, nil
+	}
+	return &proto.NearbyPorts{
+		Ports: []*proto.NearbyPort{{Port: proto.PortDomainToProto(c.memory), DistanceKm: 1}},
+	}, nil
+}
+
+func (c *MockPortsClient) WithinBox(
+	_ context.Context, _ *proto.BoundingBox, _ ...grpc.CallOption,
+) (proto.Ports_WithinBoxClient, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.boxStream, nil
+}
+
+type mockBoxStream struct {
+	grpc.ClientStream
+	errRecv error
+	ports   []*proto.Port
+}
+
+func (ms *mockBoxStream) Recv() (*proto.Port, error) {
+	if len(ms.ports) == 0 {
+		if ms.errRecv != nil {
+			return nil, ms.errRecv
+		}
+		return nil, io.EOF
+	}
+	p := ms.ports[0]
+	ms.ports = ms.ports[1:]
+	return p, nil
+}
+
+func (c *MockPortsClient) Delete(_ context.Context, in *proto.PortRequest, _ ...grpc.CallOption) (*types.Empty, error) {
+	c.revision = in.ExpectedRevision
+	if c.err != nil {
+		return &types.Empty{}, c.err
+	}
+	if c.memory != nil && in.Id == c.memory.ID {
+		return &types.Empty{}, nil
+	}
+	return &types.Empty{}, status.Error(codes.NotFound, domain.ErrNotFound.Error())
 }
 
 func (c *MockPortsClient) Get(_ context.Context, in *proto.PortRequest, _ ...grpc.CallOption) (*proto.Port, error) {
+	c.asOf = in.AsOf
 	if c.err != nil {
 		return &proto.Port{}, c.err
 	}
@@ -64,18 +266,38 @@ var examplesSave = []struct {
 		errSet: errFoo,
 		errGot: errFoo,
 	},
+	{
+		name:   "Canceled",
+		errSet: status.Error(codes.Canceled, context.Canceled.Error()),
+		errGot: context.Canceled,
+	},
 }
 
 func (s *GRPCTestSuite) TestSave() {
 	for _, ex := range examplesSave {
 		s.mock.err = ex.errSet
 		s.Run(ex.name, func() {
-			err := s.storage.Save(nil)
+			_, err := s.storage.Save(context.TODO(), nil, 0)
 			s.True(errors.Is(err, ex.errGot), "Error should be same as expected")
 		})
 	}
 }
 
+func (s *GRPCTestSuite) TestSaveValidation() {
+	st, err := status.New(codes.InvalidArgument, "invalid port").WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{Field: "id", Description: "must be UN/LOCODE"}},
+	})
+	s.Require().Nil(err)
+	s.mock.err = st.Err()
+
+	_, err = s.storage.Save(context.TODO(), nil, 0)
+	var verr *domain.ValidationError
+	if s.True(errors.As(err, &verr), "Should return validation error") {
+		s.Equal([]domain.FieldViolation{{Field: "id", Description: "must be UN/LOCODE"}}, verr.Violations,
+			"Should restore field violations")
+	}
+}
+
 var examplesGet = []struct {
 	name         string
 	errSet       error
@@ -111,6 +333,15 @@ var examplesGet = []struct {
 		grpcResponse: &proto.Port{},
 		expected:     nil,
 	},
+	{
+		name:         "Deadline exceeded",
+		errSet:       status.Error(codes.DeadlineExceeded, context.DeadlineExceeded.Error()),
+		errGot:       context.DeadlineExceeded,
+		id:           "id",
+		memory:       &domain.Port{ID: "id", City: "city", Name: "Port"},
+		grpcResponse: nil,
+		expected:     nil,
+	},
 }
 
 func (s *GRPCTestSuite) TestGet() {
@@ -119,13 +350,498 @@ func (s *GRPCTestSuite) TestGet() {
 		s.mock.memory = ex.memory
 		s.mock.grpcResponse = ex.grpcResponse
 		s.Run(ex.name, func() {
-			port, err := s.storage.Get(ex.id)
+			port, err := s.storage.Get(context.TODO(), ex.id)
 			s.True(errors.Is(err, ex.errGot), "Error should be same as expected")
 			s.Equal(ex.expected, port, "Should return port same as expected")
 		})
 	}
 }
 
+var examplesList = []struct {
+	name     string
+	errSet   error
+	errGot   error
+	cursor   string
+	filter   domain.PortFilter
+	expected domain.Page
+}{
+	{
+		name:     "No error",
+		filter:   domain.PortFilter{Country: "Belgium"},
+		expected: domain.Page{Ports: []*domain.Port{{ID: "id", Country: "Belgium"}}},
+	},
+	{
+		name:     "Filtered out",
+		filter:   domain.PortFilter{Country: "France"},
+		expected: domain.Page{Ports: []*domain.Port{}},
+	},
+	{
+		name:     "Last page",
+		cursor:   "id",
+		expected: domain.Page{Ports: []*domain.Port{}},
+	},
+	{
+		name:   "Test error",
+		errSet: errFoo,
+		errGot: errFoo,
+	},
+}
+
+func (s *GRPCTestSuite) TestList() {
+	for _, ex := range examplesList {
+		s.mock.err = ex.errSet
+		s.mock.memory = &domain.Port{ID: "id", Country: "Belgium"}
+		s.Run(ex.name, func() {
+			page, err := s.storage.List(context.TODO(), ex.filter, ex.cursor, 10)
+			s.True(errors.Is(err, ex.errGot), "Error should be same as expected")
+			s.Equal(ex.expected, page, "Should return page same as expected")
+		})
+	}
+}
+
+var examplesNearby = []struct {
+	name     string
+	errSet   error
+	errGot   error
+	radius   float64
+	expected []domain.NearbyPort
+}{
+	{
+		name:     "No error",
+		radius:   10,
+		expected: []domain.NearbyPort{{Port: domain.Port{ID: "id", Country: "Belgium"}, DistanceKm: 1}},
+	},
+	{
+		name:     "Out of radius",
+		radius:   0.5,
+		expected: []domain.NearbyPort{},
+	},
+	{
+		name:   "Deadline exceeded",
+		radius: 10,
+		errSet: status.Error(codes.DeadlineExceeded, "deadline"),
+		errGot: context.DeadlineExceeded,
+	},
+}
+
+func (s *GRPCTestSuite) TestNearby() {
+	for _, ex := range examplesNearby {
+		s.mock.err = ex.errSet
+		s.mock.memory = &domain.Port{ID: "id", Country: "Belgium"}
+		s.Run(ex.name, func() {
+			ports, err := s.storage.Nearby(context.TODO(), domain.Location{Latitude: 51.2, Longitude: 4.4}, ex.radius, 10)
+			s.True(errors.Is(err, ex.errGot), "Error should be same as expected")
+			s.Equal(ex.expected, ports, "Should return ports same as expected")
+		})
+	}
+}
+
+var examplesSearch = []struct {
+	name     string
+	errSet   error
+	errGot   error
+	memory   *domain.Port
+	expected []domain.MatchedPort
+}{
+	{
+		name:     "No error",
+		memory:   &domain.Port{ID: "NLRTM", Name: "Rotterdam"},
+		expected: []domain.MatchedPort{{Port: domain.Port{ID: "NLRTM", Name: "Rotterdam"}, Score: 0.8}},
+	},
+	{
+		name:     "Nothing found",
+		expected: []domain.MatchedPort{},
+	},
+	{
+		name:   "Deadline exceeded",
+		errSet: status.Error(codes.DeadlineExceeded, "deadline"),
+		errGot: context.DeadlineExceeded,
+	},
+}
+
+func (s *GRPCTestSuite) TestSearchByName() {
+	for _, ex := range examplesSearch {
+		s.mock.err = ex.errSet
+		s.mock.memory = ex.memory
+		s.Run(ex.name, func() {
+			ports, err := s.storage.SearchByName(context.TODO(), "rotter", 5)
+			s.Equal(&proto.SearchRequest{Query: "rotter", Limit: 5}, s.mock.search, "Should send query and limit")
+			s.True(errors.Is(err, ex.errGot), "Error should be same as expected")
+			s.Equal(ex.expected, ports, "Should return ports same as expected")
+		})
+	}
+}
+
+var examplesWithinBox = []struct {
+	name     string
+	errSet   error
+	errRecv  error
+	errFn    error
+	errGot   error
+	expected []string
+}{
+	{
+		name:     "No error",
+		expected: []string{"BEANR", "NLRTM"},
+	},
+	{
+		name:   "Test error",
+		errSet: errFoo,
+		errGot: errFoo,
+	},
+	{
+		name:     "Stream error",
+		errRecv:  status.Error(codes.Canceled, "canceled"),
+		errGot:   context.Canceled,
+		expected: []string{"BEANR", "NLRTM"},
+	},
+	{
+		name:     "Callback error",
+		errFn:    errFoo,
+		errGot:   errFoo,
+		expected: []string{"BEANR"},
+	},
+}
+
+func (s *GRPCTestSuite) TestWithinBox() {
+	for _, ex := range examplesWithinBox {
+		s.mock.err = ex.errSet
+		s.mock.boxStream = &mockBoxStream{
+			errRecv: ex.errRecv,
+			ports:   []*proto.Port{{Id: "BEANR"}, {Id: "NLRTM"}},
+		}
+		s.Run(ex.name, func() {
+			var ids []string
+			err := s.storage.WithinBox(context.TODO(), domain.BoundingBox{}, func(p *domain.Port) error {
+				ids = append(ids, p.ID)
+				return ex.errFn
+			})
+			s.True(errors.Is(err, ex.errGot), "Error should be same as expected")
+			s.Equal(ex.expected, ids, "Should pass received ports to callback")
+		})
+	}
+}
+
+func (s *GRPCTestSuite) TestWatch() {
+	errStop := errors.New("stop")
+	s.mock.err = nil
+	s.mock.watchFrom = nil
+	s.mock.watchStreams = []*mockWatchStream{
+		{
+			events: []*proto.PortEvent{
+				{Sequence: 3, Op: proto.PortEvent_CREATED, Port: &proto.Port{Id: "BEANR"}},
+				{Sequence: 4, Op: proto.PortEvent_UPDATED, Port: &proto.Port{Id: "BEANR"}},
+			},
+			errRecv: status.Error(codes.Unavailable, "server is shutting down"),
+		},
+		{
+			events: []*proto.PortEvent{{Sequence: 5, Op: proto.PortEvent_DELETED, Port: &proto.Port{Id: "BEANR"}}},
+		},
+	}
+
+	var events []domain.PortEvent
+	err := s.storage.Watch(context.TODO(), 3, func(e domain.PortEvent) error {
+		events = append(events, e)
+		if e.Op == domain.PortDeleted {
+			return errStop
+		}
+		return nil
+	})
+	s.True(errors.Is(err, errStop), "Error should be same as expected")
+	s.Equal([]int64{3, 5}, s.mock.watchFrom, "Should resume after the last received sequence")
+	s.Equal([]domain.EventOp{domain.PortCreated, domain.PortUpdated, domain.PortDeleted},
+		[]domain.EventOp{events[0].Op, events[1].Op, events[2].Op}, "Should pass received events to callback")
+	s.Equal(int64(5), events[2].Sequence, "Should pass sequence of event")
+}
+
+func (s *GRPCTestSuite) TestWatchError() {
+	s.mock.err = nil
+	s.mock.watchFrom = nil
+	s.mock.watchStreams = []*mockWatchStream{{errRecv: status.Error(codes.Canceled, "canceled")}}
+	err := s.storage.Watch(context.TODO(), 0, func(domain.PortEvent) error { return nil })
+	s.True(errors.Is(err, context.Canceled), "Should convert error of stream")
+	s.Equal([]int64{0}, s.mock.watchFrom, "Should not retry on errors other than unavailable")
+}
+
+func (s *GRPCTestSuite) TestWatchPruned() {
+	s.mock.err = nil
+	s.mock.watchFrom = nil
+	s.mock.watchStreams = []*mockWatchStream{{errRecv: status.Error(codes.OutOfRange, "events are pruned")}}
+	err := s.storage.Watch(context.TODO(), 3, func(domain.PortEvent) error { return nil })
+	s.True(errors.Is(err, domain.ErrEventsPruned), "Should report pruned events")
+}
+
+var examplesDelete = []struct {
+	name   string
+	errSet error
+	errGot error
+	id     string
+}{
+	{
+		name: "No error",
+		id:   "id",
+	},
+	{
+		name:   "Test error",
+		errSet: errFoo,
+		errGot: errFoo,
+		id:     "id",
+	},
+	{
+		name:   "Not found",
+		errGot: domain.ErrNotFound,
+		id:     "notid",
+	},
+}
+
+func (s *GRPCTestSuite) TestDelete() {
+	for _, ex := range examplesDelete {
+		s.mock.err = ex.errSet
+		s.mock.memory = &domain.Port{ID: "id"}
+		s.Run(ex.name, func() {
+			err := s.storage.Delete(context.TODO(), ex.id, 0)
+			s.True(errors.Is(err, ex.errGot), "Error should be same as expected")
+		})
+	}
+}
+
+func (s *GRPCTestSuite) TestExpectedRevision() {
+	s.mock.err = nil
+	s.mock.saved = &proto.Port{Id: "AEAJM", Revision: 3}
+	stored, err := s.storage.Save(context.TODO(), &domain.Port{ID: "AEAJM"}, 2)
+	s.Nil(err, "Should save port with no error")
+	s.Equal(&domain.Port{ID: "AEAJM", Revision: 3}, stored, "Should return stored port")
+	s.Equal(int64(2), s.mock.revision, "Should send expected revision of saved port")
+	s.True(s.mock.conditional, "Should save port with SaveIfRevision")
+
+	s.mock.memory = &domain.Port{ID: "AEAJM"}
+	err = s.storage.Delete(context.TODO(), "AEAJM", 3)
+	s.Nil(err, "Should delete port with no error")
+	s.Equal(int64(3), s.mock.revision, "Should send expected revision of deleted port")
+
+	s.mock.err = status.Error(codes.FailedPrecondition, domain.ErrRevisionMismatch.Error())
+	_, err = s.storage.Save(context.TODO(), &domain.Port{ID: "AEAJM"}, 2)
+	s.True(errors.Is(err, domain.ErrRevisionMismatch), "Should return revision mismatch on failed precondition")
+	err = s.storage.Delete(context.TODO(), "AEAJM", 3)
+	s.True(errors.Is(err, domain.ErrRevisionMismatch), "Should return revision mismatch on failed precondition")
+}
+
+var examplesLookup = []struct {
+	name     string
+	errSet   error
+	errGot   error
+	lookup   *proto.PortLookup
+	expected []*domain.Port
+}{
+	{
+		name:     "Found",
+		lookup:   &proto.PortLookup{Port: &proto.Port{Id: "AEAJM"}},
+		expected: []*domain.Port{{ID: "AEAJM"}},
+	},
+	{
+		name:     "Candidates",
+		lookup:   &proto.PortLookup{Candidates: []*proto.Port{{Id: "AEAJM"}, {Id: "AESHJ"}}},
+		expected: []*domain.Port{{ID: "AEAJM"}, {ID: "AESHJ"}},
+	},
+	{
+		name:     "Not found",
+		errSet:   status.Error(codes.NotFound, "not found"),
+		expected: []*domain.Port{},
+	},
+	{
+		name:   "Canceled",
+		errSet: status.Error(codes.Canceled, context.Canceled.Error()),
+		errGot: context.Canceled,
+	},
+}
+
+func (s *GRPCTestSuite) TestLookup() {
+	lookups := map[string]func(context.Context, string) ([]*domain.Port, error){
+		"by unlocode": s.storage.ByUnlocode,
+		"by alias":    s.storage.ByAlias,
+	}
+	for op, lookup := range lookups {
+		for _, ex := range examplesLookup {
+			s.mock.err = ex.errSet
+			s.mock.lookup = ex.lookup
+			s.Run(op+" "+ex.name, func() {
+				ports, err := lookup(context.TODO(), "AEAJM")
+				s.Equal("AEAJM", s.mock.key, "Should send lookup key")
+				s.True(errors.Is(err, ex.errGot), "Error should be same as expected")
+				s.Equal(ex.expected, ports, "Should return found ports")
+			})
+		}
+	}
+}
+
+func (s *GRPCTestSuite) TestUpdate() {
+	s.mock.err = nil
+	s.mock.grpcResponse = &proto.Port{Id: "AEAJM", Name: "Ajman", Timezone: "Asia/Muscat", Revision: 3}
+	port, err := s.storage.Update(
+		domain.WithSource(context.TODO(), "http:req-1"),
+		&domain.Port{ID: "AEAJM", Timezone: "Asia/Muscat"}, []string{"timezone"}, 2,
+	)
+	s.Nil(err, "Should update port with no error")
+	s.Equal(&domain.Port{ID: "AEAJM", Name: "Ajman", Timezone: "Asia/Muscat", Revision: 3}, port,
+		"Should return updated port")
+	s.Equal([]string{"timezone"}, s.mock.update.GetUpdateMask().GetPaths(), "Should send fields as update mask")
+	s.Equal("Asia/Muscat", s.mock.update.GetPort().GetTimezone(), "Should send port")
+	s.Equal(int64(2), s.mock.update.GetExpectedRevision(), "Should send expected revision")
+	s.Equal([]string{"http:req-1"}, s.mock.source, "Should forward source of change")
+
+	s.mock.err = status.Error(codes.NotFound, "not found")
+	_, err = s.storage.Update(context.TODO(), &domain.Port{ID: "AEAJM"}, []string{"name"}, 0)
+	s.True(errors.Is(err, domain.ErrNotFound), "Should return not found error")
+
+	s.mock.err = status.Error(codes.FailedPrecondition, domain.ErrRevisionMismatch.Error())
+	_, err = s.storage.Update(context.TODO(), &domain.Port{ID: "AEAJM"}, []string{"name"}, 2)
+	s.True(errors.Is(err, domain.ErrRevisionMismatch), "Should return revision mismatch on failed precondition")
+}
+
+func (s *GRPCTestSuite) TestSaveSource() {
+	s.mock.err = nil
+	_, err := s.storage.Save(domain.WithSource(context.TODO(), "http:req-1"), &domain.Port{ID: "AEAJM"}, 0)
+	s.Nil(err, "Should save port with no error")
+	s.Equal([]string{"http:req-1"}, s.mock.source, "Should forward source of change")
+	s.False(s.mock.conditional, "Should save port unconditionally with Save")
+
+	_, err = s.storage.Save(context.TODO(), &domain.Port{ID: "AEAJM"}, 0)
+	s.Nil(err, "Should save port with no error")
+	s.Empty(s.mock.source, "Should not forward missing source")
+}
+
+func (s *GRPCTestSuite) TestGetAsOf() {
+	asOf := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.mock.err = nil
+	s.mock.memory = &domain.Port{ID: "AEAJM"}
+	s.mock.grpcResponse = &proto.Port{Id: "AEAJM"}
+	port, err := s.storage.GetAsOf(context.TODO(), "AEAJM", asOf)
+	s.Nil(err, "Should get port with no error")
+	s.Equal("AEAJM", port.ID, "Should return expected port")
+	s.Equal(&asOf, s.mock.asOf, "Should request port as of time")
+
+	_, err = s.storage.GetAsOf(context.TODO(), "BEANR", asOf)
+	s.True(errors.Is(err, domain.ErrNotFound), "Should convert error")
+}
+
+func (s *GRPCTestSuite) TestHistory() {
+	changedAt := time.Unix(60, 0).UTC()
+	s.mock.err = nil
+	s.mock.history = &proto.PortHistory{Changes: []*proto.PortChange{
+		{Sequence: 1, Op: proto.PortEvent_CREATED, Source: "file:ports.json", ChangedAt: changedAt},
+		{Sequence: 2, Op: proto.PortEvent_DELETED, Previous: &proto.Port{Id: "AEAJM"}, ChangedAt: changedAt},
+	}}
+	history, err := s.storage.History(context.TODO(), "AEAJM")
+	s.Nil(err, "Should get history with no error")
+	s.Equal([]domain.PortChange{
+		{Sequence: 1, Op: domain.PortCreated, Source: "file:ports.json", ChangedAt: changedAt},
+		{Sequence: 2, Op: domain.PortDeleted, Previous: &domain.Port{ID: "AEAJM"}, ChangedAt: changedAt},
+	}, history, "Should convert history")
+
+	s.mock.err = status.Error(codes.NotFound, "not found")
+	_, err = s.storage.History(context.TODO(), "AEAJM")
+	s.True(errors.Is(err, domain.ErrNotFound), "Should convert error")
+}
+
+var examplesSaveBatch = []struct {
+	name     string
+	errSet   error
+	errGot   error
+	result   *proto.BatchResult
+	failures []domain.BatchFailure
+}{
+	{
+		name:   "No error",
+		result: &proto.BatchResult{Inserted: 1, Updated: 1},
+	},
+	{
+		name:   "Test error",
+		errSet: errFoo,
+		errGot: errFoo,
+	},
+	{
+		name: "Item errors",
+		result: &proto.BatchResult{Failures: []*proto.BatchFailure{
+			{Index: 1, Id: "id", Code: uint32(codes.Canceled), Message: context.Canceled.Error()},
+		}},
+		failures: []domain.BatchFailure{{Index: 1, ID: "id", Err: context.Canceled}},
+	},
+}
+
+func (s *GRPCTestSuite) TestSaveBatch() {
+	for _, ex := range examplesSaveBatch {
+		s.mock.err = ex.errSet
+		s.mock.batchResult = ex.result
+		s.Run(ex.name, func() {
+			_, err := s.storage.SaveBatch(context.TODO(), []*domain.Port{{ID: "id0"}, {ID: "id"}})
+			if ex.failures == nil {
+				s.True(errors.Is(err, ex.errGot), "Error should be same as expected")
+				return
+			}
+			var batchErr *domain.BatchError
+			s.True(errors.As(err, &batchErr), "Should return batch error")
+			s.Equal(ex.failures, batchErr.Failures, "Should return expected failures")
+		})
+	}
+}
+
+var examplesImport = []struct {
+	name     string
+	errSend  error
+	errRecv  error
+	errGot   error
+	response *proto.ImportSummary
+	summary  domain.ImportSummary
+}{
+	{
+		name: "No error",
+		response: &proto.ImportSummary{
+			Total: 2, Inserted: 1, Rejected: 1,
+			Failures: []*proto.BatchFailure{
+				{Index: 1, Id: "id", Code: uint32(codes.Internal), Message: errFoo.Error()},
+			},
+			RejectedByCode: map[string]int64{codes.Internal.String(): 1},
+		},
+		summary: domain.ImportSummary{
+			Total: 2, Inserted: 1, Rejected: 1,
+			Failures: []domain.BatchFailure{
+				{Index: 1, ID: "id", Err: status.Error(codes.Internal, errFoo.Error())},
+			},
+			RejectedByCode: map[string]int{codes.Internal.String(): 1},
+		},
+	},
+	{
+		name:    "Stream aborted",
+		errSend: io.EOF,
+		errRecv: status.Error(codes.Canceled, context.Canceled.Error()),
+		errGot:  context.Canceled,
+	},
+	{
+		name:    "Send error",
+		errSend: errFoo,
+		errGot:  errFoo,
+	},
+}
+
+func (s *GRPCTestSuite) TestImport() {
+	importer := grpcclient.NewImporter(s.mock)
+	for _, ex := range examplesImport {
+		s.mock.stream = &mockImportStream{errSend: ex.errSend, errRecv: ex.errRecv, summary: ex.response}
+		ports := make(chan *domain.Port)
+		go func() {
+			ports <- &domain.Port{ID: "id0"}
+			ports <- &domain.Port{ID: "id"}
+			close(ports)
+		}()
+		s.Run(ex.name, func() {
+			summary, err := importer.Import(context.TODO(), ports, 100)
+			s.True(errors.Is(err, ex.errGot), "Error should be same as expected")
+			s.Equal(ex.summary, summary, "Should return expected summary")
+			s.Equal([]string{"100"}, s.mock.batchSize, "Should send batch size to server")
+		})
+	}
+}
+
 func TestGRPCTestSuite(t *testing.T) {
 	suite.Run(t, new(GRPCTestSuite))
 }
@@ -0,0 +1,435 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sp4rd4/ports/pkg/domain"
+)
+
+const (
+	errorTag = "memory"
+
+	earthRadiusKm = 6371.0
+)
+
+var (
+	errNilPort      = errors.New("nil port")
+	errInvalidLimit = errors.New("limit should be positive")
+)
+
+// Storage keeps ports in a map guarded by mutex, ports are copied on the way in and out
+// so callers can not modify stored data. Every change is kept in events log for watchers
+// and in history of changed port.
+type Storage struct {
+	mu      sync.RWMutex
+	ports   map[string]*domain.Port
+	events  []domain.PortEvent
+	history map[string][]domain.PortChange
+	// changed is closed and replaced on every change to wake up watchers.
+	changed chan struct{}
+}
+
+var _ domain.PortRepository = &Storage{}
+
+func New() *Storage {
+	return &Storage{
+		ports:   make(map[string]*domain.Port),
+		history: make(map[string][]domain.PortChange),
+		changed: make(chan struct{}),
+	}
+}
+
+// put stores port with next revision and records the change, s.mu must be locked.
+func (s *Storage) put(source string, port *domain.Port) {
+	op := domain.PortCreated
+	stored := port.Clone()
+	stored.Revision = 1
+	previous, ok := s.ports[port.ID]
+	if ok {
+		op = domain.PortUpdated
+		stored.Revision = previous.Revision + 1
+	}
+	s.ports[port.ID] = stored
+	s.record(source, op, stored, previous)
+}
+
+// matchRevision checks revision of stored port for conditional writes, s.mu must be locked.
+func (s *Storage) matchRevision(id string, expectedRevision int64) error {
+	if expectedRevision == 0 {
+		return nil
+	}
+	if port, ok := s.ports[id]; !ok || port.Revision != expectedRevision {
+		return domain.ErrRevisionMismatch
+	}
+	return nil
+}
+
+// record appends event to log and change to history of port with next sequence, s.mu must be locked.
+func (s *Storage) record(source string, op domain.EventOp, port, previous *domain.Port) {
+	sequence := int64(len(s.events)) + 1
+	s.events = append(s.events, domain.PortEvent{
+		Sequence: sequence,
+		Op:       op,
+		Port:     port.Clone(),
+	})
+	s.history[port.ID] = append(s.history[port.ID], domain.PortChange{
+		Sequence:  sequence,
+		Op:        op,
+		Previous:  previous,
+		Source:    source,
+		ChangedAt: time.Now(),
+	})
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *Storage) Save(ctx context.Context, port *domain.Port, expectedRevision int64) (*domain.Port, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("[%v] save: %w", errorTag, err)
+	}
+	if port == nil {
+		return nil, fmt.Errorf("[%v] save: %w", errorTag, errNilPort)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.matchRevision(port.ID, expectedRevision); err != nil {
+		return nil, fmt.Errorf("[%v] save: %w", errorTag, err)
+	}
+	s.put(domain.SourceFromContext(ctx), port)
+	return s.ports[port.ID].Clone(), nil
+}
+
+func (s *Storage) Update(
+	ctx context.Context, port *domain.Port, fields []string, expectedRevision int64,
+) (*domain.Port, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("[%v] update: %w", errorTag, err)
+	}
+	if port == nil {
+		return nil, fmt.Errorf("[%v] update: %w", errorTag, errNilPort)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stored, ok := s.ports[port.ID]
+	if !ok {
+		return nil, fmt.Errorf("[%v] update: %w", errorTag, domain.ErrNotFound)
+	}
+	if err := s.matchRevision(port.ID, expectedRevision); err != nil {
+		return nil, fmt.Errorf("[%v] update: %w", errorTag, err)
+	}
+	updated := stored.Clone()
+	if err := updated.CopyFields(port, fields); err != nil {
+		return nil, fmt.Errorf("[%v] update: %w", errorTag, err)
+	}
+	s.put(domain.SourceFromContext(ctx), updated)
+	return s.ports[port.ID].Clone(), nil
+}
+
+func (s *Storage) Get(ctx context.Context, id string) (*domain.Port, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("[%v] get: %w", errorTag, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	port, ok := s.ports[id]
+	if !ok {
+		return nil, fmt.Errorf("[%v] get: %w", errorTag, domain.ErrNotFound)
+	}
+	return port.Clone(), nil
+}
+
+// GetAsOf finds the first change made after the moment, port before it is the one sought.
+// Port which was not changed since then is the current one.
+func (s *Storage) GetAsOf(ctx context.Context, id string, at time.Time) (*domain.Port, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("[%v] get as of: %w", errorTag, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	port, ok := s.ports[id]
+	for _, c := range s.history[id] {
+		if c.ChangedAt.After(at) {
+			port, ok = c.Previous, c.Previous != nil
+			break
+		}
+	}
+	if !ok {
+		return nil, fmt.Errorf("[%v] get as of: %w", errorTag, domain.ErrNotFound)
+	}
+	return port.Clone(), nil
+}
+
+func (s *Storage) Delete(ctx context.Context, id string, expectedRevision int64) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("[%v] delete: %w", errorTag, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.matchRevision(id, expectedRevision); err != nil {
+		return fmt.Errorf("[%v] delete: %w", errorTag, err)
+	}
+	port, ok := s.ports[id]
+	if !ok {
+		return fmt.Errorf("[%v] delete: %w", errorTag, domain.ErrNotFound)
+	}
+	delete(s.ports, id)
+	s.record(domain.SourceFromContext(ctx), domain.PortDeleted, port, port)
+	return nil
+}
+
+func (s *Storage) List(ctx context.Context, filter domain.PortFilter, cursor string, limit int) (domain.Page, error) {
+	if err := ctx.Err(); err != nil {
+		return domain.Page{}, fmt.Errorf("[%v] list: %w", errorTag, err)
+	}
+	if limit <= 0 {
+		return domain.Page{}, fmt.Errorf("[%v] list: %w", errorTag, errInvalidLimit)
+	}
+
+	ports := s.sorted(func(p *domain.Port) bool {
+		return p.ID > cursor && matches(p, filter)
+	})
+	if len(ports) <= limit {
+		return domain.Page{Ports: ports}, nil
+	}
+	return domain.Page{Ports: ports[:limit], NextCursor: ports[limit-1].ID}, nil
+}
+
+func (s *Storage) ByUnlocode(ctx context.Context, code string) ([]*domain.Port, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("[%v] by unlocode: %w", errorTag, err)
+	}
+
+	return firstCandidates(code, s.sorted(func(p *domain.Port) bool {
+		return p.ID == code || contains(p.Unlocs, code)
+	})), nil
+}
+
+func (s *Storage) ByAlias(ctx context.Context, name string) ([]*domain.Port, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("[%v] by alias: %w", errorTag, err)
+	}
+
+	return firstCandidates(name, s.sorted(func(p *domain.Port) bool {
+		return contains(p.Alias, name)
+	})), nil
+}
+
+// firstCandidates moves port having key as id in front of ports sorted by id and limits them.
+func firstCandidates(key string, ports []*domain.Port) []*domain.Port {
+	for i, p := range ports {
+		if p.ID == key {
+			copy(ports[1:i+1], ports[:i])
+			ports[0] = p
+			break
+		}
+	}
+	if len(ports) > domain.MaxLookupCandidates {
+		return ports[:domain.MaxLookupCandidates]
+	}
+	return ports
+}
+
+func matches(p *domain.Port, filter domain.PortFilter) bool {
+	return (filter.Country == "" || p.Country == filter.Country) &&
+		(filter.Province == "" || p.Province == filter.Province) &&
+		(filter.City == "" || p.City == filter.City) &&
+		(filter.Timezone == "" || p.Timezone == filter.Timezone) &&
+		(filter.Region == "" || contains(p.Regions, filter.Region))
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Storage) Nearby(
+	ctx context.Context, point domain.Location, radiusKm float64, limit int,
+) ([]domain.NearbyPort, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("[%v] nearby: %w", errorTag, err)
+	}
+
+	s.mu.RLock()
+	ports := []domain.NearbyPort{}
+	for _, p := range s.ports {
+		if d := distanceKm(point, p.Coordinates); d <= radiusKm {
+			ports = append(ports, domain.NearbyPort{Port: *p.Clone(), DistanceKm: d})
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].DistanceKm != ports[j].DistanceKm {
+			return ports[i].DistanceKm < ports[j].DistanceKm
+		}
+		return ports[i].ID < ports[j].ID
+	})
+	if len(ports) > limit {
+		ports = ports[:limit]
+	}
+	return ports, nil
+}
+
+// distanceKm is haversine great-circle distance.
+func distanceKm(a, b domain.Location) float64 {
+	lat1, lat2 := radians(a.Latitude), radians(b.Latitude)
+	dLat, dLon := lat2-lat1, radians(b.Longitude-a.Longitude)
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+// WithinBox calls fn on a snapshot of matching ports, so slow callbacks do not block writers.
+func (s *Storage) WithinBox(ctx context.Context, box domain.BoundingBox, fn func(*domain.Port) error) error {
+	ports := s.sorted(func(p *domain.Port) bool {
+		return inBox(p.Coordinates, box)
+	})
+	for _, p := range ports {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("[%v] within box: %w", errorTag, err)
+		}
+		if err := fn(p); err != nil {
+			return fmt.Errorf("[%v] within box: %w", errorTag, err)
+		}
+	}
+	return nil
+}
+
+func inBox(l domain.Location, box domain.BoundingBox) bool {
+	if l.Latitude < box.MinLat || l.Latitude > box.MaxLat {
+		return false
+	}
+	if box.MinLon > box.MaxLon {
+		return l.Longitude >= box.MinLon || l.Longitude <= box.MaxLon
+	}
+	return l.Longitude >= box.MinLon && l.Longitude <= box.MaxLon
+}
+
+// SaveBatch saves ports one by one, same id can appear in batch several times and last occurrence wins
+// like in postgres storage, earlier occurrences are counted as updated.
+func (s *Storage) SaveBatch(ctx context.Context, ports []*domain.Port) (domain.SaveResult, error) {
+	res := domain.SaveResult{}
+	if err := ctx.Err(); err != nil {
+		return res, fmt.Errorf("[%v] save batch: %w", errorTag, err)
+	}
+
+	batchErr := &domain.BatchError{}
+	last := make(map[string]int, len(ports))
+	for i, p := range ports {
+		if p == nil {
+			batchErr.Add(i, "", errNilPort)
+			continue
+		}
+		last[p.ID] = i
+	}
+
+	source := domain.SourceFromContext(ctx)
+	s.mu.Lock()
+	for i, p := range ports {
+		if p == nil {
+			continue
+		}
+		if last[p.ID] != i {
+			res.Updated++
+			continue
+		}
+		if _, ok := s.ports[p.ID]; ok {
+			res.Updated++
+		} else {
+			res.Inserted++
+		}
+		s.put(source, p)
+	}
+	s.mu.Unlock()
+
+	if batchErr.ErrOrNil() != nil {
+		return res, fmt.Errorf("[%v] save batch: %w", errorTag, batchErr)
+	}
+	return res, nil
+}
+
+func (s *Storage) Watch(ctx context.Context, fromSequence int64, fn func(domain.PortEvent) error) error {
+	s.mu.RLock()
+	if fromSequence == 0 {
+		fromSequence = int64(len(s.events)) + 1
+	}
+	s.mu.RUnlock()
+
+	for {
+		s.mu.RLock()
+		var events []domain.PortEvent
+		if fromSequence <= int64(len(s.events)) {
+			events = append(events, s.events[fromSequence-1:]...)
+		}
+		changed := s.changed
+		s.mu.RUnlock()
+
+		for _, e := range events {
+			e.Port = e.Port.Clone()
+			if err := fn(e); err != nil {
+				return fmt.Errorf("[%v] watch: %w", errorTag, err)
+			}
+			fromSequence = e.Sequence + 1
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return fmt.Errorf("[%v] watch: %w", errorTag, ctx.Err())
+		}
+	}
+}
+
+func (s *Storage) History(ctx context.Context, id string) ([]domain.PortChange, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("[%v] history: %w", errorTag, err)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	history, ok := s.history[id]
+	if !ok {
+		return nil, fmt.Errorf("[%v] history: %w", errorTag, domain.ErrNotFound)
+	}
+	changes := make([]domain.PortChange, len(history))
+	for i, c := range history {
+		c.Previous = c.Previous.Clone()
+		changes[i] = c
+	}
+	return changes, nil
+}
+
+// sorted returns copies of ports matching fn ordered by id.
+func (s *Storage) sorted(fn func(*domain.Port) bool) []*domain.Port {
+	s.mu.RLock()
+	ports := []*domain.Port{}
+	for _, p := range s.ports {
+		if fn(p) {
+			ports = append(ports, p.Clone())
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(ports, func(i, j int) bool {
+		return ports[i].ID < ports[j].ID
+	})
+	return ports
+}
@@ -0,0 +1,478 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/sp4rd4/ports/pkg/domain"
+	"github.com/sp4rd4/ports/pkg/storage/memory"
+	"github.com/stretchr/testify/suite"
+)
+
+type MemoryTestSuite struct {
+	suite.Suite
+	storage *memory.Storage
+}
+
+func (s *MemoryTestSuite) SetupTest() {
+	s.storage = memory.New()
+}
+
+func (s *MemoryTestSuite) TestSave() {
+	port := &domain.Port{
+		ID:      "PORTID",
+		Name:    "Port",
+		City:    "Boston",
+		Country: "Belgium",
+		Alias:   domain.StringArray{"PORTIDD"},
+		Regions: domain.StringArray{"Provance", "Nova Scotia"},
+		Coordinates: domain.Location{
+			Latitude:  31.03351,
+			Longitude: -17.8251657,
+		},
+		Timezone: "Asia/Dubai",
+	}
+	_, err := s.storage.Save(context.TODO(), port, 0)
+	s.Nil(err, "Should save port with no error")
+
+	lPort, err := s.storage.Get(context.TODO(), port.ID)
+	s.Nil(err, "Should load port with no error")
+	port.Revision = 1
+	s.Equal(port, lPort, "Should load port equal to saved with first revision")
+
+	port.Alias[0] = "CHANGED"
+	lPort.Regions[0] = "CHANGED"
+	lPort, err = s.storage.Get(context.TODO(), port.ID)
+	s.Nil(err, "Should load port with no error")
+	s.Equal(domain.StringArray{"PORTIDD"}, lPort.Alias, "Should not share data with saved port")
+	s.Equal(domain.StringArray{"Provance", "Nova Scotia"}, lPort.Regions, "Should not share data with loaded port")
+}
+
+func (s *MemoryTestSuite) TestSaveRevision() {
+	_, err := s.storage.Save(context.TODO(), &domain.Port{ID: "AEAJM", Name: "Ajman"}, 1)
+	s.True(errors.Is(err, domain.ErrRevisionMismatch), "Should not create port with expected revision")
+	stored, err := s.storage.Save(context.TODO(), &domain.Port{ID: "AEAJM", Name: "Ajman"}, 0)
+	s.Nil(err, "Should save port with no error")
+	s.Equal(int64(1), stored.Revision, "Should return created port with first revision")
+	stored, err = s.storage.Save(context.TODO(), &domain.Port{ID: "AEAJM", Name: "Ajman Port", Revision: 5}, 1)
+	s.Nil(err, "Should save port with expected revision")
+	s.Equal(&domain.Port{ID: "AEAJM", Name: "Ajman Port", Revision: 2}, stored, "Should return stored port")
+	_, err = s.storage.Save(context.TODO(), &domain.Port{ID: "AEAJM", Name: "Stale"}, 1)
+	s.True(errors.Is(err, domain.ErrRevisionMismatch), "Should not save port with stale revision")
+
+	port, err := s.storage.Get(context.TODO(), "AEAJM")
+	s.Nil(err, "Should load port with no error")
+	s.Equal(&domain.Port{ID: "AEAJM", Name: "Ajman Port", Revision: 2}, port, "Should increase revision on update")
+
+	err = s.storage.Delete(context.TODO(), "AEAJM", 1)
+	s.True(errors.Is(err, domain.ErrRevisionMismatch), "Should not delete port with stale revision")
+	err = s.storage.Delete(context.TODO(), "AEAJM", 2)
+	s.Nil(err, "Should delete port with expected revision")
+}
+
+func (s *MemoryTestSuite) TestUpdate() {
+	_, err := s.storage.Update(context.TODO(), &domain.Port{ID: "AEAJM"}, []string{"name"}, 0)
+	s.True(errors.Is(err, domain.ErrNotFound), "Should not update missing port")
+
+	_, err = s.storage.Save(context.TODO(), &domain.Port{
+		ID: "AEAJM", Name: "Ajman", City: "Ajman", Timezone: "Asia/Dubai", Alias: domain.StringArray{"Ajman Port"},
+	}, 0)
+	s.Nil(err, "Should save port with no error")
+
+	update := &domain.Port{ID: "AEAJM", Name: "Wiped", Timezone: "Asia/Muscat", Alias: domain.StringArray{"AJM"}}
+	port, err := s.storage.Update(context.TODO(), update, []string{"alias", "timezone"}, 0)
+	s.Nil(err, "Should update port with no error")
+	expected := &domain.Port{
+		ID: "AEAJM", Name: "Ajman", City: "Ajman", Timezone: "Asia/Muscat", Alias: domain.StringArray{"AJM"}, Revision: 2,
+	}
+	s.Equal(expected, port, "Should write only named fields")
+
+	update.Alias[0] = "CHANGED"
+	port, err = s.storage.Get(context.TODO(), "AEAJM")
+	s.Nil(err, "Should load port with no error")
+	s.Equal(expected, port, "Should not share data with updated port")
+
+	_, err = s.storage.Update(context.TODO(), update, []string{"id"}, 0)
+	s.True(errors.Is(err, domain.ErrUnknownField), "Should not update unknown fields")
+
+	_, err = s.storage.Update(context.TODO(), update, []string{"name"}, 1)
+	s.True(errors.Is(err, domain.ErrRevisionMismatch), "Should not update port with stale revision")
+	port, err = s.storage.Update(context.TODO(), update, []string{"name"}, 2)
+	s.Nil(err, "Should update port with expected revision")
+	s.Equal(int64(3), port.Revision, "Should increase revision on update")
+}
+
+func (s *MemoryTestSuite) TestLookup() {
+	_, err := s.storage.SaveBatch(context.TODO(), []*domain.Port{
+		{ID: "AEAJM", Unlocs: domain.StringArray{"AEAJM", "AEQIW"}, Alias: domain.StringArray{"Ajman Port"}},
+		{ID: "AEQIW", Unlocs: domain.StringArray{"AEQIW"}, Alias: domain.StringArray{"Umm al Qaiwain"}},
+		{ID: "AESHJ", Unlocs: domain.StringArray{"AESHJ"}, Alias: domain.StringArray{"Ajman Port", "Sharjah"}},
+	})
+	s.Nil(err, "Should save ports with no error")
+
+	ports, err := s.storage.ByUnlocode(context.TODO(), "AEQIW")
+	s.Nil(err, "Should find ports with no error")
+	s.Equal([]string{"AEQIW", "AEAJM"}, portIDs(ports), "Should find ports by id and unlocs, id match first")
+
+	ports, err = s.storage.ByAlias(context.TODO(), "Ajman Port")
+	s.Nil(err, "Should find ports with no error")
+	s.Equal([]string{"AEAJM", "AESHJ"}, portIDs(ports), "Should find all ports having alias")
+
+	ports, err = s.storage.ByAlias(context.TODO(), "ajman port")
+	s.Nil(err, "Should find ports with no error")
+	s.Empty(ports, "Should find nothing by unknown alias")
+
+	listing := make([]*domain.Port, 0, domain.MaxLookupCandidates+1)
+	for i := 0; i < domain.MaxLookupCandidates; i++ {
+		listing = append(listing, &domain.Port{ID: fmt.Sprintf("AA%03d", i), Unlocs: domain.StringArray{"ZZZZZ"}})
+	}
+	listing = append(listing, &domain.Port{ID: "ZZZZZ"})
+	_, err = s.storage.SaveBatch(context.TODO(), listing)
+	s.Nil(err, "Should save ports with no error")
+
+	ports, err = s.storage.ByUnlocode(context.TODO(), "ZZZZZ")
+	s.Nil(err, "Should find ports with no error")
+	if s.Len(ports, domain.MaxLookupCandidates, "Should limit candidates") {
+		s.Equal("ZZZZZ", ports[0].ID, "Should not cut off port having code as id")
+	}
+}
+
+func portIDs(ports []*domain.Port) []string {
+	ids := make([]string, len(ports))
+	for i := range ports {
+		ids[i] = ports[i].ID
+	}
+	return ids
+}
+
+func (s *MemoryTestSuite) TestSearchByName() {
+	_, err := s.storage.SaveBatch(context.TODO(), []*domain.Port{
+		{ID: "BEANR", Name: "Antwerp", City: "Antwerp"},
+		{ID: "NLRTM", Name: "Rotterdam", City: "Rotterdam"},
+		{ID: "NLVLA", Name: "Vlaardingen", City: "Vlaardingen", Alias: domain.StringArray{"Rotterdam Vlaardingen"}},
+	})
+	s.Nil(err, "Should save ports with no error")
+
+	ports, err := s.storage.SearchByName(context.TODO(), "rotter", 10)
+	s.Nil(err, "Should search ports with no error")
+	s.Equal([]string{"NLRTM", "NLVLA"}, matchedIDs(ports), "Should rank name prefix over alias prefix")
+	s.True(ports[0].Score > ports[1].Score, "Should return higher score for better match")
+
+	ports, err = s.storage.SearchByName(context.TODO(), "Roterdam", 1)
+	s.Nil(err, "Should search ports with no error")
+	s.Equal([]string{"NLRTM"}, matchedIDs(ports), "Should find misspelled name and respect limit")
+
+	for _, prefix := range []string{"ro", "R"} {
+		ports, err = s.storage.SearchByName(context.TODO(), prefix, 10)
+		s.Nil(err, "Should search ports with no error")
+		s.Equal([]string{"NLRTM", "NLVLA"}, matchedIDs(ports), "Should find short prefix below similarity threshold")
+	}
+
+	ports, err = s.storage.SearchByName(context.TODO(), "Antwerpen", 10)
+	s.Nil(err, "Should search ports with no error")
+	s.Equal([]string{"BEANR"}, matchedIDs(ports), "Should find similar name without prefix")
+
+	ports, err = s.storage.SearchByName(context.TODO(), "hamburg", 10)
+	s.Nil(err, "Should search ports with no error")
+	s.Empty(ports, "Should not return dissimilar ports")
+}
+
+func matchedIDs(ports []domain.MatchedPort) []string {
+	ids := make([]string, len(ports))
+	for i := range ports {
+		ids[i] = ports[i].ID
+	}
+	return ids
+}
+
+func (s *MemoryTestSuite) TestGetMissing() {
+	lPort, err := s.storage.Get(context.TODO(), "id")
+	s.Nil(lPort, "Should return nil port")
+	s.True(errors.Is(err, domain.ErrNotFound), "Should return not found error")
+}
+
+func (s *MemoryTestSuite) TestList() {
+	ports := []*domain.Port{{ID: "A", Revision: 1}, {ID: "B", Revision: 1}, {ID: "C", Revision: 1}}
+	_, err := s.storage.SaveBatch(context.TODO(), ports)
+	s.Nil(err, "Should save ports with no error")
+
+	page, err := s.storage.List(context.TODO(), domain.PortFilter{}, "", 2)
+	s.Nil(err, "Should list ports with no error")
+	s.Equal(domain.Page{Ports: ports[:2], NextCursor: "B"}, page, "Should return first page")
+
+	page, err = s.storage.List(context.TODO(), domain.PortFilter{}, page.NextCursor, 2)
+	s.Nil(err, "Should list ports with no error")
+	s.Equal(domain.Page{Ports: ports[2:]}, page, "Should return last page")
+
+	_, err = s.storage.List(context.TODO(), domain.PortFilter{}, "", 0)
+	s.NotNil(err, "Should reject non-positive limit")
+}
+
+func (s *MemoryTestSuite) TestListFilter() {
+	ports := []*domain.Port{
+		{ID: "A", Country: "Belgium", City: "Antwerp", Regions: domain.StringArray{"Europe", "Benelux"}, Revision: 1},
+		{
+			ID: "B", Country: "Belgium", City: "Ghent", Regions: domain.StringArray{"Europe"}, Timezone: "Europe/Brussels",
+			Revision: 1,
+		},
+		{ID: "C", Country: "France", City: "Calais", Regions: domain.StringArray{"Europe"}, Revision: 1},
+	}
+	_, err := s.storage.SaveBatch(context.TODO(), ports)
+	s.Nil(err, "Should save ports with no error")
+
+	page, err := s.storage.List(context.TODO(), domain.PortFilter{Country: "Belgium"}, "", 10)
+	s.Nil(err, "Should list ports with no error")
+	s.Equal(ports[:2], page.Ports, "Should filter by country")
+
+	page, err = s.storage.List(context.TODO(), domain.PortFilter{Region: "Benelux"}, "", 10)
+	s.Nil(err, "Should list ports with no error")
+	s.Equal(ports[:1], page.Ports, "Should filter by region")
+
+	page, err = s.storage.List(context.TODO(), domain.PortFilter{Region: "Europe", Timezone: "Europe/Brussels"}, "", 10)
+	s.Nil(err, "Should list ports with no error")
+	s.Equal(ports[1:2], page.Ports, "Should combine filters")
+}
+
+func (s *MemoryTestSuite) TestNearby() {
+	ports := []*domain.Port{
+		{ID: "BEANR", Coordinates: domain.Location{Latitude: 51.2194475, Longitude: 4.4024643}},
+		{ID: "FJTVU", Coordinates: domain.Location{Latitude: -16.8, Longitude: -179.97}},
+		{ID: "FRCQF", Coordinates: domain.Location{Latitude: 50.95129, Longitude: 1.858686}},
+		{ID: "NLRTM", Coordinates: domain.Location{Latitude: 51.9244201, Longitude: 4.4777325}},
+	}
+	_, err := s.storage.SaveBatch(context.TODO(), ports)
+	s.Nil(err, "Should save ports with no error")
+
+	nearby, err := s.storage.Nearby(context.TODO(), domain.Location{Latitude: 51.2, Longitude: 4.4}, 100, 10)
+	s.Nil(err, "Should query nearby ports with no error")
+	s.Equal([]string{"BEANR", "NLRTM"}, nearbyIDs(nearby), "Should return ports within radius ordered by distance")
+	s.InDelta(2.2, nearby[0].DistanceKm, 0.1, "Should return distance to port")
+
+	nearby, err = s.storage.Nearby(context.TODO(), domain.Location{Latitude: 51.2, Longitude: 4.4}, 500, 1)
+	s.Nil(err, "Should query nearby ports with no error")
+	s.Equal([]string{"BEANR"}, nearbyIDs(nearby), "Should respect limit")
+
+	nearby, err = s.storage.Nearby(context.TODO(), domain.Location{Latitude: -16.8, Longitude: 179.9}, 50, 10)
+	s.Nil(err, "Should query nearby ports with no error")
+	s.Equal([]string{"FJTVU"}, nearbyIDs(nearby), "Should find ports across antimeridian")
+}
+
+func (s *MemoryTestSuite) TestWithinBox() {
+	ports := []*domain.Port{
+		{ID: "BEANR", Coordinates: domain.Location{Latitude: 51.2194475, Longitude: 4.4024643}},
+		{ID: "FJSUV", Coordinates: domain.Location{Latitude: -18.1416, Longitude: 178.4419}},
+		{ID: "FJTVU", Coordinates: domain.Location{Latitude: -16.8, Longitude: -179.97}},
+		{ID: "NLRTM", Coordinates: domain.Location{Latitude: 51.9244201, Longitude: 4.4777325}},
+	}
+	_, err := s.storage.SaveBatch(context.TODO(), ports)
+	s.Nil(err, "Should save ports with no error")
+
+	var ids []string
+	collect := func(p *domain.Port) error {
+		ids = append(ids, p.ID)
+		return nil
+	}
+
+	box := domain.BoundingBox{MinLon: 2, MinLat: 50, MaxLon: 5, MaxLat: 52}
+	err = s.storage.WithinBox(context.TODO(), box, collect)
+	s.Nil(err, "Should query ports in box with no error")
+	s.Equal([]string{"BEANR", "NLRTM"}, ids, "Should return ports inside box")
+
+	ids = nil
+	box = domain.BoundingBox{MinLon: 170, MinLat: -20, MaxLon: -170, MaxLat: -10}
+	err = s.storage.WithinBox(context.TODO(), box, collect)
+	s.Nil(err, "Should query ports in box with no error")
+	s.Equal([]string{"FJSUV", "FJTVU"}, ids, "Should return ports in box crossing antimeridian")
+
+	err = s.storage.WithinBox(context.TODO(), domain.BoundingBox{MinLon: -180, MinLat: -90, MaxLon: 180, MaxLat: 90},
+		func(*domain.Port) error { return errors.New("stop") })
+	s.NotNil(err, "Should stop on callback error")
+}
+
+func nearbyIDs(ports []domain.NearbyPort) []string {
+	ids := make([]string, len(ports))
+	for i := range ports {
+		ids[i] = ports[i].ID
+	}
+	return ids
+}
+
+func (s *MemoryTestSuite) TestDelete() {
+	port := &domain.Port{ID: "PORTID", Name: "Port", City: "Boston"}
+	_, err := s.storage.Save(context.TODO(), port, 0)
+	s.Nil(err, "Should save port with no error")
+
+	err = s.storage.Delete(context.TODO(), port.ID, 0)
+	s.Nil(err, "Should delete port with no error")
+
+	_, err = s.storage.Get(context.TODO(), port.ID)
+	s.True(errors.Is(err, domain.ErrNotFound), "Should not find deleted port")
+
+	err = s.storage.Delete(context.TODO(), port.ID, 0)
+	s.True(errors.Is(err, domain.ErrNotFound), "Should return not found for missing port")
+}
+
+func (s *MemoryTestSuite) TestGetCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	lPort, err := s.storage.Get(ctx, "id")
+	s.Nil(lPort, "Should return nil port")
+	s.True(errors.Is(err, context.Canceled), "Should return canceled error")
+}
+
+func (s *MemoryTestSuite) TestSaveBatch() {
+	ports := []*domain.Port{
+		{ID: "PORTID", Name: "Port", City: "Boston", Alias: domain.StringArray{"PORTIDD"}},
+		{ID: "PORTID2", Name: "Porting", City: "Gyor", Regions: domain.StringArray{"Nova Scotia"}},
+		nil,
+		{ID: "PORTID", Name: "New Port", City: "Boston", Revision: 1},
+	}
+	res, err := s.storage.SaveBatch(context.TODO(), ports)
+	s.Equal(domain.SaveResult{Inserted: 2, Updated: 1}, res, "Should count duplicate as updated")
+	var batchErr *domain.BatchError
+	s.True(errors.As(err, &batchErr), "Should report nil port")
+	s.Equal(1, len(batchErr.Failures), "Should report only nil port")
+	s.Equal(2, batchErr.Failures[0].Index, "Should report nil port index")
+
+	lPort, err := s.storage.Get(context.TODO(), "PORTID")
+	s.Nil(err, "Should load port with no error")
+	s.Equal(ports[3], lPort, "Should load last saved version of port")
+
+	res, err = s.storage.SaveBatch(context.TODO(), []*domain.Port{ports[1], {ID: "PORTID3"}})
+	s.Nil(err, "Should save ports with no error")
+	s.Equal(domain.SaveResult{Inserted: 1, Updated: 1}, res, "Should count updated ports")
+}
+
+func (s *MemoryTestSuite) TestWatch() {
+	errStop := errors.New("stop")
+	_, err := s.storage.Save(context.TODO(), &domain.Port{ID: "AEAJM", Name: "Ajman"}, 0)
+	s.Nil(err, "Should save port with no error")
+	_, err = s.storage.Save(context.TODO(), &domain.Port{ID: "AEAJM", Name: "New Ajman"}, 0)
+	s.Nil(err, "Should save port with no error")
+	err = s.storage.Delete(context.TODO(), "AEAJM", 0)
+	s.Nil(err, "Should delete port with no error")
+
+	var events []domain.PortEvent
+	err = s.storage.Watch(context.TODO(), 1, func(e domain.PortEvent) error {
+		events = append(events, e)
+		if len(events) == 3 {
+			return errStop
+		}
+		return nil
+	})
+	s.True(errors.Is(err, errStop), "Should stop on callback error")
+	s.Equal([]domain.PortEvent{
+		{Sequence: 1, Op: domain.PortCreated, Port: &domain.Port{ID: "AEAJM", Name: "Ajman", Revision: 1}},
+		{Sequence: 2, Op: domain.PortUpdated, Port: &domain.Port{ID: "AEAJM", Name: "New Ajman", Revision: 2}},
+		{Sequence: 3, Op: domain.PortDeleted, Port: &domain.Port{ID: "AEAJM", Name: "New Ajman", Revision: 2}},
+	}, events, "Should replay changes from sequence")
+
+	received := make(chan domain.PortEvent)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- s.storage.Watch(ctx, 4, func(e domain.PortEvent) error {
+			received <- e
+			return nil
+		})
+	}()
+	_, err = s.storage.SaveBatch(context.TODO(), []*domain.Port{{ID: "BEANR"}, {ID: "NLRTM"}})
+	s.Nil(err, "Should save ports with no error")
+	s.Equal(domain.PortEvent{Sequence: 4, Op: domain.PortCreated, Port: &domain.Port{ID: "BEANR", Revision: 1}},
+		<-received,
+		"Should stream new changes")
+	s.Equal(int64(5), (<-received).Sequence, "Should stream changes in order")
+
+	cancel()
+	s.True(errors.Is(<-done, context.Canceled), "Should stop once context is done")
+}
+
+func (s *MemoryTestSuite) TestWatchFromNow() {
+	_, err := s.storage.Save(context.TODO(), &domain.Port{ID: "AEAJM"}, 0)
+	s.Nil(err, "Should save port with no error")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	received := make(chan domain.PortEvent, 1)
+	go func() {
+		_ = s.storage.Watch(ctx, 0, func(e domain.PortEvent) error {
+			received <- e
+			return nil
+		})
+	}()
+
+	select {
+	case e := <-received:
+		s.Fail("Should not replay past changes", "received sequence %d", e.Sequence)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func (s *MemoryTestSuite) TestHistory() {
+	ctx := domain.WithSource(context.Background(), "file:ports.json")
+	_, err := s.storage.Save(ctx, &domain.Port{ID: "AEAJM", Name: "Ajman"}, 0)
+	s.Nil(err, "Should save port with no error")
+	_, err = s.storage.SaveBatch(domain.WithSource(ctx, "http:req-1"), []*domain.Port{{ID: "AEAJM", Name: "Ajman Port"}})
+	s.Nil(err, "Should save batch with no error")
+	err = s.storage.Delete(domain.WithSource(ctx, "grpc:10.0.0.1:5000"), "AEAJM", 0)
+	s.Nil(err, "Should delete port with no error")
+
+	history, err := s.storage.History(context.TODO(), "AEAJM")
+	s.Nil(err, "Should get history with no error")
+	s.Len(history, 3, "Should record every change")
+	s.Equal(domain.PortChange{Sequence: 1, Op: domain.PortCreated, Source: "file:ports.json"},
+		domain.PortChange{Sequence: history[0].Sequence, Op: history[0].Op, Source: history[0].Source},
+		"Should record creation")
+	s.Nil(history[0].Previous, "Should not have previous port for created one")
+	s.Equal("Ajman", history[1].Previous.Name, "Should record previous port on update")
+	s.Equal("http:req-1", history[1].Source, "Should record source of update")
+	s.Equal(domain.PortDeleted, history[2].Op, "Should record deletion")
+	s.Equal("Ajman Port", history[2].Previous.Name, "Should record deleted port")
+	s.False(history[2].ChangedAt.IsZero(), "Should record time of change")
+
+	_, err = s.storage.History(context.TODO(), "BEANR")
+	s.True(errors.Is(err, domain.ErrNotFound), "Should not find history of unknown port")
+}
+
+func (s *MemoryTestSuite) TestGetAsOf() {
+	// moments are taken between changes, sleep keeps them apart on coarse clocks
+	moment := func() time.Time {
+		time.Sleep(time.Millisecond)
+		defer time.Sleep(time.Millisecond)
+		return time.Now()
+	}
+	beforeCreate := moment()
+	_, err := s.storage.Save(context.TODO(), &domain.Port{ID: "AEAJM", Name: "Ajman"}, 0)
+	s.Nil(err, "Should save port with no error")
+	afterCreate := moment()
+	_, err = s.storage.Save(context.TODO(), &domain.Port{ID: "AEAJM", Name: "Ajman Port"}, 0)
+	s.Nil(err, "Should save port with no error")
+	afterUpdate := moment()
+	err = s.storage.Delete(context.TODO(), "AEAJM", 0)
+	s.Nil(err, "Should delete port with no error")
+	afterDelete := moment()
+
+	_, err = s.storage.GetAsOf(context.TODO(), "AEAJM", beforeCreate)
+	s.True(errors.Is(err, domain.ErrNotFound), "Should not find port before creation")
+	port, err := s.storage.GetAsOf(context.TODO(), "AEAJM", afterCreate)
+	s.Nil(err, "Should get port with no error")
+	s.Equal("Ajman", port.Name, "Should return port as it was created")
+	port, err = s.storage.GetAsOf(context.TODO(), "AEAJM", afterUpdate)
+	s.Nil(err, "Should get port with no error")
+	s.Equal("Ajman Port", port.Name, "Should return updated port")
+	_, err = s.storage.GetAsOf(context.TODO(), "AEAJM", afterDelete)
+	s.True(errors.Is(err, domain.ErrNotFound), "Should not find deleted port")
+
+	_, err = s.storage.Save(context.TODO(), &domain.Port{ID: "BEANR", Name: "Antwerp"}, 0)
+	s.Nil(err, "Should save port with no error")
+	port, err = s.storage.GetAsOf(context.TODO(), "BEANR", time.Now().Add(time.Hour))
+	s.Nil(err, "Should get port with no error")
+	s.Equal("Antwerp", port.Name, "Should return current port when it was not changed since")
+}
+
+func TestMemoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MemoryTestSuite))
+}
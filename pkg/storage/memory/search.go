@@ -0,0 +1,116 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/sp4rd4/ports/pkg/domain"
+)
+
+// similarityThreshold is the default pg_trgm.word_similarity_threshold.
+const similarityThreshold = 0.6
+
+const (
+	namePrefix = iota
+	otherPrefix
+	noPrefix
+)
+
+var prefixBoost = [...]float64{namePrefix: 0.5, otherPrefix: 0.25, noPrefix: 0}
+
+// SearchByName approximates pg_trgm word similarity used by postgres storage: score is the share of query
+// trigrams found in name, city and alias of port. Ports with prefix matching query go first and are returned
+// even if their score is below threshold.
+func (s *Storage) SearchByName(ctx context.Context, query string, limit int) ([]domain.MatchedPort, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("[%v] search by name: %w", errorTag, err)
+	}
+
+	query = strings.ToLower(query)
+	queryTrigrams := trigrams(query)
+	s.mu.RLock()
+	matched := []rankedPort{}
+	for _, p := range s.ports {
+		text := p.Name + " " + p.City + " " + strings.Join(p.Alias, " ")
+		score := similarity(queryTrigrams, trigrams(text))
+		rank := prefixRank(p, query)
+		if score < similarityThreshold && rank == noPrefix {
+			continue
+		}
+		matched = append(matched, rankedPort{
+			MatchedPort: domain.MatchedPort{Port: *p.Clone(), Score: score + prefixBoost[rank]},
+			rank:        rank,
+		})
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].rank != matched[j].rank {
+			return matched[i].rank < matched[j].rank
+		}
+		if matched[i].Score != matched[j].Score {
+			return matched[i].Score > matched[j].Score
+		}
+		return matched[i].ID < matched[j].ID
+	})
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+	ports := make([]domain.MatchedPort, len(matched))
+	for i := range matched {
+		ports[i] = matched[i].MatchedPort
+	}
+	return ports, nil
+}
+
+type rankedPort struct {
+	domain.MatchedPort
+	rank int
+}
+
+// prefixRank is namePrefix if name of port starts with query, otherPrefix if city or alias does.
+func prefixRank(p *domain.Port, query string) int {
+	if strings.HasPrefix(strings.ToLower(p.Name), query) {
+		return namePrefix
+	}
+	if strings.HasPrefix(strings.ToLower(p.City), query) {
+		return otherPrefix
+	}
+	for _, a := range p.Alias {
+		if strings.HasPrefix(strings.ToLower(a), query) {
+			return otherPrefix
+		}
+	}
+	return noPrefix
+}
+
+// trigrams splits text into words of letters and digits padded the way pg_trgm does it.
+func trigrams(text string) map[string]struct{} {
+	set := map[string]struct{}{}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, w := range words {
+		padded := []rune("  " + w + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = struct{}{}
+		}
+	}
+	return set
+}
+
+func similarity(query, text map[string]struct{}) float64 {
+	if len(query) == 0 {
+		return 0
+	}
+	found := 0
+	for t := range query {
+		if _, ok := text[t]; ok {
+			found++
+		}
+	}
+	return float64(found) / float64(len(query))
+}
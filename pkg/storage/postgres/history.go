@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sp4rd4/ports/pkg/domain"
+)
+
+type changeRow struct {
+	Sequence  int64     `db:"sequence"`
+	Op        string    `db:"op"`
+	Previous  []byte    `db:"previous"`
+	Source    string    `db:"source"`
+	ChangedAt time.Time `db:"changed_at"`
+}
+
+// History reads changes recorded by trigger on ports table.
+func (s Storage) History(ctx context.Context, id string) ([]domain.PortChange, error) {
+	rows := []changeRow{}
+	err := s.db.SelectContext(ctx, &rows, `
+	SELECT sequence, op, previous, source, changed_at FROM ports_history
+	WHERE port_id = $1
+	ORDER BY sequence;
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("[%v] history: %w", errorTag, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("[%v] history: %w", errorTag, domain.ErrNotFound)
+	}
+
+	changes := make([]domain.PortChange, len(rows))
+	for i, row := range rows {
+		changes[i] = domain.PortChange{
+			Sequence:  row.Sequence,
+			Op:        domain.EventOp(row.Op),
+			Source:    row.Source,
+			ChangedAt: row.ChangedAt,
+		}
+		if row.Previous == nil {
+			continue
+		}
+		changes[i].Previous = &domain.Port{}
+		if err = json.Unmarshal(row.Previous, changes[i].Previous); err != nil {
+			return nil, fmt.Errorf("[%v] history: change %d: %w", errorTag, row.Sequence, err)
+		}
+	}
+	return changes, nil
+}
+
+// GetAsOf finds the first change made after the moment, port before it is the one sought.
+// Port which was not changed since then is read from ports table in the same snapshot.
+func (s Storage) GetAsOf(ctx context.Context, id string, at time.Time) (*domain.Port, error) {
+	tx, err := s.db.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("[%v] get as of: %w", errorTag, err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var previous []byte
+	err = tx.GetContext(ctx, &previous, `
+	SELECT previous FROM ports_history
+	WHERE port_id = $1 AND changed_at > $2
+	ORDER BY sequence
+	LIMIT 1;
+	`, id, at)
+	port := &domain.Port{}
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		err = tx.GetContext(ctx, port, selectPorts+` WHERE id=$1;`, id)
+	case err == nil && previous == nil:
+		// port was created after the moment
+		err = sql.ErrNoRows
+	case err == nil:
+		err = json.Unmarshal(previous, port)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("[%v] get as of: %w", errorTag, domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[%v] get as of: %w", errorTag, err)
+	}
+	return port, nil
+}
@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sp4rd4/ports/pkg/domain"
+)
+
+// ByUnlocode matches codes of unlocs array with GIN index, primary key is checked as well.
+// Port having code as id is ordered first so limit never cuts it off.
+func (s Storage) ByUnlocode(ctx context.Context, code string) ([]*domain.Port, error) {
+	ports := []*domain.Port{}
+	err := s.db.SelectContext(ctx, &ports, selectPorts+`
+	WHERE id = $1 OR unlocs @> ARRAY[$1]::varchar[]
+	ORDER BY (id = $1) DESC, id
+	LIMIT $2;
+	`, code, domain.MaxLookupCandidates)
+	if err != nil {
+		return nil, fmt.Errorf("[%v] by unlocode: %w", errorTag, err)
+	}
+	return ports, nil
+}
+
+// ByAlias matches names of alias array with GIN index.
+func (s Storage) ByAlias(ctx context.Context, name string) ([]*domain.Port, error) {
+	ports := []*domain.Port{}
+	err := s.db.SelectContext(ctx, &ports, selectPorts+`
+	WHERE alias @> ARRAY[$1]::varchar[]
+	ORDER BY (id = $1) DESC, id
+	LIMIT $2;
+	`, name, domain.MaxLookupCandidates)
+	if err != nil {
+		return nil, fmt.Errorf("[%v] by alias: %w", errorTag, err)
+	}
+	return ports, nil
+}
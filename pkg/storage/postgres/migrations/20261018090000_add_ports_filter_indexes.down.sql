@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "ports_country_idx";
+DROP INDEX IF EXISTS "ports_province_idx";
+DROP INDEX IF EXISTS "ports_city_idx";
+DROP INDEX IF EXISTS "ports_timezone_idx";
+DROP INDEX IF EXISTS "ports_regions_idx";
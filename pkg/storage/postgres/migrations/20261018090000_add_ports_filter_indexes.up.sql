@@ -0,0 +1,5 @@
+CREATE INDEX IF NOT EXISTS "ports_country_idx" ON "ports" ("country", "id");
+CREATE INDEX IF NOT EXISTS "ports_province_idx" ON "ports" ("province", "id");
+CREATE INDEX IF NOT EXISTS "ports_city_idx" ON "ports" ("city", "id");
+CREATE INDEX IF NOT EXISTS "ports_timezone_idx" ON "ports" ("timezone", "id");
+CREATE INDEX IF NOT EXISTS "ports_regions_idx" ON "ports" USING GIN ("regions");
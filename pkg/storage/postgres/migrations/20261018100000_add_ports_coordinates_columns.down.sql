@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS "ports_latitude_longitude_idx";
+
+ALTER TABLE "ports"
+  DROP COLUMN IF EXISTS "latitude",
+  DROP COLUMN IF EXISTS "longitude";
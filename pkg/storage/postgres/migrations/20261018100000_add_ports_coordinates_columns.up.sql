@@ -0,0 +1,5 @@
+ALTER TABLE "ports"
+  ADD COLUMN IF NOT EXISTS "latitude" double precision GENERATED ALWAYS AS (("coordinates"->>0)::double precision) STORED,
+  ADD COLUMN IF NOT EXISTS "longitude" double precision GENERATED ALWAYS AS (("coordinates"->>1)::double precision) STORED;
+
+CREATE INDEX IF NOT EXISTS "ports_latitude_longitude_idx" ON "ports" ("latitude", "longitude");
@@ -0,0 +1,13 @@
+ALTER TABLE "ports"
+  DROP COLUMN IF EXISTS "latitude",
+  DROP COLUMN IF EXISTS "longitude";
+
+UPDATE "ports"
+SET "coordinates" = jsonb_build_array("coordinates"->'lon', "coordinates"->'lat')
+WHERE jsonb_typeof("coordinates") = 'object';
+
+ALTER TABLE "ports"
+  ADD COLUMN IF NOT EXISTS "latitude" double precision GENERATED ALWAYS AS (("coordinates"->>0)::double precision) STORED,
+  ADD COLUMN IF NOT EXISTS "longitude" double precision GENERATED ALWAYS AS (("coordinates"->>1)::double precision) STORED;
+
+CREATE INDEX IF NOT EXISTS "ports_latitude_longitude_idx" ON "ports" ("latitude", "longitude");
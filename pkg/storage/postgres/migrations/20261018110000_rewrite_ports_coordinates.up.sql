@@ -0,0 +1,18 @@
+-- Coordinates were stored as [latitude, longitude] array, but loader filled it from
+-- source files keeping [longitude, latitude] order, so elements are swapped on rewrite.
+ALTER TABLE "ports"
+  DROP COLUMN IF EXISTS "latitude",
+  DROP COLUMN IF EXISTS "longitude";
+
+UPDATE "ports"
+SET "coordinates" = jsonb_build_object(
+  'lat', COALESCE(("coordinates"->>1)::double precision, 0),
+  'lon', COALESCE(("coordinates"->>0)::double precision, 0)
+)
+WHERE jsonb_typeof("coordinates") = 'array';
+
+ALTER TABLE "ports"
+  ADD COLUMN IF NOT EXISTS "latitude" double precision GENERATED ALWAYS AS (("coordinates"->>'lat')::double precision) STORED,
+  ADD COLUMN IF NOT EXISTS "longitude" double precision GENERATED ALWAYS AS (("coordinates"->>'lon')::double precision) STORED;
+
+CREATE INDEX IF NOT EXISTS "ports_latitude_longitude_idx" ON "ports" ("latitude", "longitude");
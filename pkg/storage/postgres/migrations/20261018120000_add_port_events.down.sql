@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS "ports_events_trigger" ON "ports";
+DROP FUNCTION IF EXISTS "record_port_event"();
+DROP TABLE IF EXISTS "port_events";
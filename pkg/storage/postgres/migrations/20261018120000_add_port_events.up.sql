@@ -0,0 +1,37 @@
+CREATE TABLE IF NOT EXISTS "port_events" (
+  "revision" bigserial PRIMARY KEY,
+  "op" varchar NOT NULL,
+  "port_id" varchar NOT NULL,
+  "port" jsonb NOT NULL,
+  "created_at" timestamptz NOT NULL DEFAULT now()
+);
+
+-- Writers of ports are serialized by advisory lock held until commit, so revisions become
+-- visible in order and watchers reading events after the last seen revision never skip one.
+CREATE OR REPLACE FUNCTION "record_port_event"() RETURNS trigger AS $$
+DECLARE
+  "rev" bigint;
+BEGIN
+  PERFORM pg_advisory_xact_lock(hashtext('port_events'));
+  IF TG_OP = 'DELETE' THEN
+    INSERT INTO "port_events" ("op", "port_id", "port")
+      VALUES ('deleted', OLD."id", to_jsonb(OLD) - 'latitude' - 'longitude')
+      RETURNING "revision" INTO "rev";
+  ELSE
+    INSERT INTO "port_events" ("op", "port_id", "port")
+      VALUES (
+        CASE TG_OP WHEN 'INSERT' THEN 'created' ELSE 'updated' END,
+        NEW."id",
+        to_jsonb(NEW) - 'latitude' - 'longitude'
+      )
+      RETURNING "revision" INTO "rev";
+  END IF;
+  PERFORM pg_notify('port_events', "rev"::text);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS "ports_events_trigger" ON "ports";
+CREATE TRIGGER "ports_events_trigger"
+  AFTER INSERT OR UPDATE OR DELETE ON "ports"
+  FOR EACH ROW EXECUTE FUNCTION "record_port_event"();
@@ -0,0 +1,25 @@
+-- record_port_event as it was before history
+CREATE OR REPLACE FUNCTION "record_port_event"() RETURNS trigger AS $$
+DECLARE
+  "rev" bigint;
+BEGIN
+  PERFORM pg_advisory_xact_lock(hashtext('port_events'));
+  IF TG_OP = 'DELETE' THEN
+    INSERT INTO "port_events" ("op", "port_id", "port")
+      VALUES ('deleted', OLD."id", to_jsonb(OLD) - 'latitude' - 'longitude')
+      RETURNING "revision" INTO "rev";
+  ELSE
+    INSERT INTO "port_events" ("op", "port_id", "port")
+      VALUES (
+        CASE TG_OP WHEN 'INSERT' THEN 'created' ELSE 'updated' END,
+        NEW."id",
+        to_jsonb(NEW) - 'latitude' - 'longitude'
+      )
+      RETURNING "revision" INTO "rev";
+  END IF;
+  PERFORM pg_notify('port_events', "rev"::text);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS "ports_history";
@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS "ports_history" (
+  "revision" bigint PRIMARY KEY,
+  "op" varchar NOT NULL,
+  "port_id" varchar NOT NULL,
+  "previous" jsonb,
+  "source" varchar NOT NULL DEFAULT '',
+  "changed_at" timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS "ports_history_port_id_idx" ON "ports_history" ("port_id", "revision");
+
+-- History entry shares revision with event of the same change. Source is set by writers
+-- with set_config('ports.source', ..., true) for the current transaction.
+CREATE OR REPLACE FUNCTION "record_port_event"() RETURNS trigger AS $$
+DECLARE
+  "rev" bigint;
+  "src" varchar := COALESCE(current_setting('ports.source', true), '');
+BEGIN
+  PERFORM pg_advisory_xact_lock(hashtext('port_events'));
+  IF TG_OP = 'DELETE' THEN
+    INSERT INTO "port_events" ("op", "port_id", "port")
+      VALUES ('deleted', OLD."id", to_jsonb(OLD) - 'latitude' - 'longitude')
+      RETURNING "revision" INTO "rev";
+    INSERT INTO "ports_history" ("revision", "op", "port_id", "previous", "source")
+      VALUES ("rev", 'deleted', OLD."id", to_jsonb(OLD) - 'latitude' - 'longitude', "src");
+  ELSIF TG_OP = 'INSERT' THEN
+    INSERT INTO "port_events" ("op", "port_id", "port")
+      VALUES ('created', NEW."id", to_jsonb(NEW) - 'latitude' - 'longitude')
+      RETURNING "revision" INTO "rev";
+    INSERT INTO "ports_history" ("revision", "op", "port_id", "previous", "source")
+      VALUES ("rev", 'created', NEW."id", NULL, "src");
+  ELSE
+    INSERT INTO "port_events" ("op", "port_id", "port")
+      VALUES ('updated', NEW."id", to_jsonb(NEW) - 'latitude' - 'longitude')
+      RETURNING "revision" INTO "rev";
+    INSERT INTO "ports_history" ("revision", "op", "port_id", "previous", "source")
+      VALUES ("rev", 'updated', NEW."id", to_jsonb(OLD) - 'latitude' - 'longitude', "src");
+  END IF;
+  PERFORM pg_notify('port_events', "rev"::text);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1 @@
+ALTER TABLE "ports" DROP COLUMN IF EXISTS "revision";
@@ -0,0 +1 @@
+ALTER TABLE "ports" ADD COLUMN IF NOT EXISTS "revision" bigint NOT NULL DEFAULT 1;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "ports_unlocs_idx";
+DROP INDEX IF EXISTS "ports_alias_idx";
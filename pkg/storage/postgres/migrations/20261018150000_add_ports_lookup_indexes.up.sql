@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS "ports_unlocs_idx" ON "ports" USING GIN ("unlocs");
+CREATE INDEX IF NOT EXISTS "ports_alias_idx" ON "ports" USING GIN ("alias");
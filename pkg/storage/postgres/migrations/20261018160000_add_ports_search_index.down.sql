@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "ports_search_trgm_idx";
+DROP FUNCTION IF EXISTS "ports_search_text"(varchar, varchar, varchar[]);
@@ -0,0 +1,10 @@
+CREATE EXTENSION IF NOT EXISTS "pg_trgm";
+
+-- array_to_string is only stable, wrapping it is safe for varchar[] and lets the expression be indexed.
+CREATE OR REPLACE FUNCTION "ports_search_text"("name" varchar, "city" varchar, "alias" varchar[])
+  RETURNS text AS $$
+  SELECT concat_ws(' ', "name", "city", array_to_string("alias", ' '));
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE;
+
+CREATE INDEX IF NOT EXISTS "ports_search_trgm_idx" ON "ports"
+  USING GIN ("ports_search_text"("name", "city", "alias") gin_trgm_ops);
@@ -0,0 +1,34 @@
+ALTER TABLE "ports_history" RENAME COLUMN "sequence" TO "revision";
+ALTER SEQUENCE IF EXISTS "port_events_sequence_seq" RENAME TO "port_events_revision_seq";
+ALTER TABLE "port_events" RENAME COLUMN "sequence" TO "revision";
+
+-- record_port_event as it was before rename
+CREATE OR REPLACE FUNCTION "record_port_event"() RETURNS trigger AS $$
+DECLARE
+  "rev" bigint;
+  "src" varchar := COALESCE(current_setting('ports.source', true), '');
+BEGIN
+  PERFORM pg_advisory_xact_lock(hashtext('port_events'));
+  IF TG_OP = 'DELETE' THEN
+    INSERT INTO "port_events" ("op", "port_id", "port")
+      VALUES ('deleted', OLD."id", to_jsonb(OLD) - 'latitude' - 'longitude')
+      RETURNING "revision" INTO "rev";
+    INSERT INTO "ports_history" ("revision", "op", "port_id", "previous", "source")
+      VALUES ("rev", 'deleted', OLD."id", to_jsonb(OLD) - 'latitude' - 'longitude', "src");
+  ELSIF TG_OP = 'INSERT' THEN
+    INSERT INTO "port_events" ("op", "port_id", "port")
+      VALUES ('created', NEW."id", to_jsonb(NEW) - 'latitude' - 'longitude')
+      RETURNING "revision" INTO "rev";
+    INSERT INTO "ports_history" ("revision", "op", "port_id", "previous", "source")
+      VALUES ("rev", 'created', NEW."id", NULL, "src");
+  ELSE
+    INSERT INTO "port_events" ("op", "port_id", "port")
+      VALUES ('updated', NEW."id", to_jsonb(NEW) - 'latitude' - 'longitude')
+      RETURNING "revision" INTO "rev";
+    INSERT INTO "ports_history" ("revision", "op", "port_id", "previous", "source")
+      VALUES ("rev", 'updated', NEW."id", to_jsonb(OLD) - 'latitude' - 'longitude', "src");
+  END IF;
+  PERFORM pg_notify('port_events', "rev"::text);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,32 @@
+DROP INDEX IF EXISTS "port_events_created_at_idx";
+
+-- record_port_event as it was before writers stopped being serialized
+CREATE OR REPLACE FUNCTION "record_port_event"() RETURNS trigger AS $$
+DECLARE
+  "seq" bigint;
+  "src" varchar := COALESCE(current_setting('ports.source', true), '');
+BEGIN
+  PERFORM pg_advisory_xact_lock(hashtext('port_events'));
+  IF TG_OP = 'DELETE' THEN
+    INSERT INTO "port_events" ("op", "port_id", "port")
+      VALUES ('deleted', OLD."id", to_jsonb(OLD) - 'latitude' - 'longitude')
+      RETURNING "sequence" INTO "seq";
+    INSERT INTO "ports_history" ("sequence", "op", "port_id", "previous", "source")
+      VALUES ("seq", 'deleted', OLD."id", to_jsonb(OLD) - 'latitude' - 'longitude', "src");
+  ELSIF TG_OP = 'INSERT' THEN
+    INSERT INTO "port_events" ("op", "port_id", "port")
+      VALUES ('created', NEW."id", to_jsonb(NEW) - 'latitude' - 'longitude')
+      RETURNING "sequence" INTO "seq";
+    INSERT INTO "ports_history" ("sequence", "op", "port_id", "previous", "source")
+      VALUES ("seq", 'created', NEW."id", NULL, "src");
+  ELSE
+    INSERT INTO "port_events" ("op", "port_id", "port")
+      VALUES ('updated', NEW."id", to_jsonb(NEW) - 'latitude' - 'longitude')
+      RETURNING "sequence" INTO "seq";
+    INSERT INTO "ports_history" ("sequence", "op", "port_id", "previous", "source")
+      VALUES ("seq", 'updated', NEW."id", to_jsonb(OLD) - 'latitude' - 'longitude', "src");
+  END IF;
+  PERFORM pg_notify('port_events', "seq"::text);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
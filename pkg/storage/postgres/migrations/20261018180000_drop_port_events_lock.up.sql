@@ -0,0 +1,32 @@
+-- Writers are not serialized anymore, sequences may become visible out of order. Watchers wait
+-- for transactions which could fill gaps between read events before skipping them, see Watch.
+CREATE OR REPLACE FUNCTION "record_port_event"() RETURNS trigger AS $$
+DECLARE
+  "seq" bigint;
+  "src" varchar := COALESCE(current_setting('ports.source', true), '');
+BEGIN
+  IF TG_OP = 'DELETE' THEN
+    INSERT INTO "port_events" ("op", "port_id", "port")
+      VALUES ('deleted', OLD."id", to_jsonb(OLD) - 'latitude' - 'longitude')
+      RETURNING "sequence" INTO "seq";
+    INSERT INTO "ports_history" ("sequence", "op", "port_id", "previous", "source")
+      VALUES ("seq", 'deleted', OLD."id", to_jsonb(OLD) - 'latitude' - 'longitude', "src");
+  ELSIF TG_OP = 'INSERT' THEN
+    INSERT INTO "port_events" ("op", "port_id", "port")
+      VALUES ('created', NEW."id", to_jsonb(NEW) - 'latitude' - 'longitude')
+      RETURNING "sequence" INTO "seq";
+    INSERT INTO "ports_history" ("sequence", "op", "port_id", "previous", "source")
+      VALUES ("seq", 'created', NEW."id", NULL, "src");
+  ELSE
+    INSERT INTO "port_events" ("op", "port_id", "port")
+      VALUES ('updated', NEW."id", to_jsonb(NEW) - 'latitude' - 'longitude')
+      RETURNING "sequence" INTO "seq";
+    INSERT INTO "ports_history" ("sequence", "op", "port_id", "previous", "source")
+      VALUES ("seq", 'updated', NEW."id", to_jsonb(OLD) - 'latitude' - 'longitude', "src");
+  END IF;
+  PERFORM pg_notify('port_events', "seq"::text);
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE INDEX IF NOT EXISTS "port_events_created_at_idx" ON "port_events" ("created_at");
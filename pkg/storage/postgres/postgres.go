@@ -2,9 +2,14 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
@@ -15,16 +20,40 @@ import (
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 )
 
-const errorTag = "postgres"
+const (
+	errorTag = "postgres"
+
+	portColumns = 11
+	// batchChunkSize keeps multi-row inserts well below postgres limit of 65535 bind parameters.
+	batchChunkSize = 1000
+
+	selectPorts = `SELECT id, name, city, country, alias, regions, coordinates, province, timezone, unlocs, code,
+		revision FROM ports`
+
+	earthRadiusKm = 6371.0
+	// kmPerDegree is length of one degree of latitude.
+	kmPerDegree = 111.045
+)
+
+var (
+	errNilPort      = errors.New("nil port")
+	errInvalidLimit = errors.New("limit should be positive")
+)
 
 type Storage struct {
-	db *sqlx.DB
+	db           *sqlx.DB
+	notifier     *notifier
+	pollInterval time.Duration
 }
 
 var _ domain.PortRepository = Storage{}
 
-func New(db *sql.DB) Storage {
-	return Storage{db: sqlx.NewDb(db, "postgres")}
+func New(db *sql.DB, opts ...Option) Storage {
+	s := Storage{db: sqlx.NewDb(db, "postgres"), pollInterval: pollInterval}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	return s
 }
 
 // Migrate creates migrations table if not exists and runs pending migrations,
@@ -49,24 +78,132 @@ func (s Storage) Migrate(db *sql.DB, migrationsFolder string) error {
 	return nil
 }
 
-func (s Storage) Save(port *domain.Port) error {
-	_, err := s.db.NamedExec(`
+// inTx runs fn in transaction with source of changes taken from ctx, which is recorded in history
+// by trigger on ports table.
+func (s Storage) inTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	tx, err := s.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `SELECT set_config('ports.source', $1, true);`, domain.SourceFromContext(ctx))
+	if err == nil {
+		err = fn(tx)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Save upserts port, conditional save only updates port having expected revision.
+func (s Storage) Save(ctx context.Context, port *domain.Port, expectedRevision int64) (*domain.Port, error) {
+	if port == nil {
+		return nil, fmt.Errorf("[%v] save: %w", errorTag, errNilPort)
+	}
+	values := []interface{}{
+		port.ID, port.Name, port.City, port.Country, port.Alias, port.Regions, port.Coordinates,
+		port.Province, port.Timezone, port.Unlocs, port.Code,
+	}
+
+	stored := &domain.Port{}
+	err := s.inTx(ctx, func(tx *sqlx.Tx) error {
+		if expectedRevision == 0 {
+			return tx.GetContext(ctx, stored, `
 	INSERT INTO ports (id, name, city, country, alias, regions, coordinates, province, timezone, unlocs, code)
-		VALUES (:id, :name, :city, :country, :alias, :regions, :coordinates, :province, :timezone, :unlocs, :code)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 	ON CONFLICT (id)
 		DO UPDATE SET
-			id=:id, name=:name, city=:city, country=:country, alias=:alias, regions=:regions,
-			coordinates=:coordinates, province=:province, timezone=:timezone, unlocs=:unlocs, code=:code;
-		`, port)
+			name=$2, city=$3, country=$4, alias=$5, regions=$6, coordinates=$7, province=$8, timezone=$9,
+			unlocs=$10, code=$11, revision=ports.revision + 1
+	RETURNING id, name, city, country, alias, regions, coordinates, province, timezone, unlocs, code, revision;
+		`, values...)
+		}
+
+		err := tx.GetContext(ctx, stored, `
+	UPDATE ports SET
+		name=$2, city=$3, country=$4, alias=$5, regions=$6, coordinates=$7, province=$8, timezone=$9,
+		unlocs=$10, code=$11, revision=revision + 1
+	WHERE id=$1 AND revision=$12
+	RETURNING id, name, city, country, alias, regions, coordinates, province, timezone, unlocs, code, revision;
+		`, append(values, expectedRevision)...)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrRevisionMismatch
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[%v] save: %w", errorTag, err)
+	}
+	return stored, nil
+}
+
+// Update sets only columns named by fields, names are checked against domain.PortFields by port.FieldValues.
+func (s Storage) Update(
+	ctx context.Context, port *domain.Port, fields []string, expectedRevision int64,
+) (*domain.Port, error) {
+	if port == nil {
+		return nil, fmt.Errorf("[%v] update: %w", errorTag, errNilPort)
+	}
+	values, err := port.FieldValues(fields)
+	if err != nil {
+		return nil, fmt.Errorf("[%v] update: %w", errorTag, err)
+	}
+	set := make([]string, 0, len(fields)+1)
+	for i, field := range fields {
+		set = append(set, field+"=$"+strconv.Itoa(i+2))
+	}
+	set = append(set, "revision=revision + 1")
+	where := "id=$1"
+	args := append([]interface{}{port.ID}, values...)
+	if expectedRevision != 0 {
+		args = append(args, expectedRevision)
+		where += " AND revision=$" + strconv.Itoa(len(args))
+	}
+
+	stored := &domain.Port{}
+	err = s.inTx(ctx, func(tx *sqlx.Tx) error {
+		err := tx.GetContext(ctx, stored, `
+	UPDATE ports SET `+strings.Join(set, ", ")+`
+	WHERE `+where+`
+	RETURNING id, name, city, country, alias, regions, coordinates, province, timezone, unlocs, code, revision;
+		`, args...)
+		if !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		var exists bool
+		if expectedRevision != 0 {
+			err = tx.GetContext(ctx, &exists, `SELECT EXISTS (SELECT 1 FROM ports WHERE id=$1);`, port.ID)
+			if err != nil {
+				return err
+			}
+		}
+		if exists {
+			return domain.ErrRevisionMismatch
+		}
+		return domain.ErrNotFound
+	})
 	if err != nil {
-		return fmt.Errorf("[%v] save: %w", errorTag, err)
+		return nil, fmt.Errorf("[%v] update: %w", errorTag, err)
+	}
+	return stored, nil
+}
+
+// expectAffected returns errNone if no rows were affected.
+func expectAffected(res sql.Result, errNone error) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errNone
 	}
 	return nil
 }
 
-func (s Storage) Get(id string) (*domain.Port, error) {
+func (s Storage) Get(ctx context.Context, id string) (*domain.Port, error) {
 	port := &domain.Port{}
-	err := s.db.Get(port, `SELECT * FROM ports WHERE id=$1;`, id)
+	err := s.db.GetContext(ctx, port, selectPorts+` WHERE id=$1;`, id)
 	if errors.Is(err, sql.ErrNoRows) {
 		return nil, fmt.Errorf("[%v] get: %w", errorTag, domain.ErrNotFound)
 	}
@@ -75,3 +212,306 @@ func (s Storage) Get(id string) (*domain.Port, error) {
 	}
 	return port, nil
 }
+
+// List uses keyset pagination on primary key, one extra row is selected to find out if there is next page.
+func (s Storage) List(ctx context.Context, filter domain.PortFilter, cursor string, limit int) (domain.Page, error) {
+	if limit <= 0 {
+		return domain.Page{}, fmt.Errorf("[%v] list: %w", errorTag, errInvalidLimit)
+	}
+	conds, args := filterConditions(filter)
+	conds = append(conds, "id > $"+strconv.Itoa(len(args)+1))
+	args = append(args, cursor, limit+1)
+	query := selectPorts + ` WHERE ` + strings.Join(conds, " AND ") +
+		` ORDER BY id LIMIT $` + strconv.Itoa(len(args)) + `;`
+
+	ports := []*domain.Port{}
+	err := s.db.SelectContext(ctx, &ports, query, args...)
+	if err != nil {
+		return domain.Page{}, fmt.Errorf("[%v] list: %w", errorTag, err)
+	}
+	return newPage(ports, limit), nil
+}
+
+// filterConditions builds sql conditions for set filter fields, placeholders are numbered from $1.
+func filterConditions(filter domain.PortFilter) ([]string, []interface{}) {
+	var (
+		conds []string
+		args  []interface{}
+	)
+	add := func(cond string, arg interface{}) {
+		args = append(args, arg)
+		conds = append(conds, fmt.Sprintf(cond, "$"+strconv.Itoa(len(args))))
+	}
+	if filter.Country != "" {
+		add("country = %v", filter.Country)
+	}
+	if filter.Province != "" {
+		add("province = %v", filter.Province)
+	}
+	if filter.City != "" {
+		add("city = %v", filter.City)
+	}
+	if filter.Region != "" {
+		add("regions @> ARRAY[%v]::varchar[]", filter.Region)
+	}
+	if filter.Timezone != "" {
+		add("timezone = %v", filter.Timezone)
+	}
+	return conds, args
+}
+
+// Nearby selects ports within bounding box of the circle using index on coordinates
+// and then filters and sorts them by haversine distance.
+func (s Storage) Nearby(
+	ctx context.Context, point domain.Location, radiusKm float64, limit int,
+) ([]domain.NearbyPort, error) {
+	box := newBoundingBox(point, radiusKm)
+	query := `
+	SELECT * FROM (
+		SELECT id, name, city, country, alias, regions, coordinates, province, timezone, unlocs, code, revision,
+			2 * $1::float8 * asin(sqrt(
+				power(sin(radians(latitude - $2) / 2), 2) +
+				cos(radians($2)) * cos(radians(latitude)) * power(sin(radians(longitude - $3) / 2), 2)
+			)) AS distance_km
+		FROM ports
+		WHERE latitude BETWEEN $4 AND $5
+			AND (longitude BETWEEN $6 AND $7 OR longitude BETWEEN $8 AND $9)
+	) AS p
+	WHERE distance_km <= $10
+	ORDER BY distance_km, id
+	LIMIT $11;
+	`
+	ports := []domain.NearbyPort{}
+	err := s.db.SelectContext(ctx, &ports, query,
+		earthRadiusKm, point.Latitude, point.Longitude,
+		box.minLat, box.maxLat, box.minLon[0], box.maxLon[0], box.minLon[1], box.maxLon[1],
+		radiusKm, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("[%v] nearby: %w", errorTag, err)
+	}
+	return ports, nil
+}
+
+// WithinBox iterates over ports inside box without loading them all in memory.
+func (s Storage) WithinBox(ctx context.Context, box domain.BoundingBox, fn func(*domain.Port) error) error {
+	lonCond := `longitude BETWEEN $3 AND $4`
+	if box.MinLon > box.MaxLon {
+		lonCond = `(longitude >= $3 OR longitude <= $4)`
+	}
+	query := selectPorts + ` WHERE latitude BETWEEN $1 AND $2 AND ` + lonCond + ` ORDER BY id;`
+	rows, err := s.db.QueryxContext(ctx, query, box.MinLat, box.MaxLat, box.MinLon, box.MaxLon)
+	if err != nil {
+		return fmt.Errorf("[%v] within box: %w", errorTag, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		port := &domain.Port{}
+		if err = rows.StructScan(port); err != nil {
+			return fmt.Errorf("[%v] within box: %w", errorTag, err)
+		}
+		if err = fn(port); err != nil {
+			return fmt.Errorf("[%v] within box: %w", errorTag, err)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("[%v] within box: %w", errorTag, err)
+	}
+	return nil
+}
+
+// boundingBox covers circle on the sphere, longitude is split in two ranges
+// when box crosses antimeridian, unused range is empty.
+type boundingBox struct {
+	minLat, maxLat float64
+	minLon, maxLon [2]float64
+}
+
+func newBoundingBox(point domain.Location, radiusKm float64) boundingBox {
+	dLat := radiusKm / kmPerDegree
+	box := boundingBox{
+		minLat: point.Latitude - dLat,
+		maxLat: point.Latitude + dLat,
+		minLon: [2]float64{-180, 1},
+		maxLon: [2]float64{180, 0},
+	}
+	if box.minLat <= -90 || box.maxLat >= 90 {
+		// circle contains pole, every longitude is possible
+		return box
+	}
+
+	dLon := radiusKm / (kmPerDegree * math.Cos(point.Latitude*math.Pi/180))
+	if dLon >= 180 {
+		return box
+	}
+	minLon, maxLon := point.Longitude-dLon, point.Longitude+dLon
+	switch {
+	case minLon < -180:
+		box.minLon = [2]float64{minLon + 360, -180}
+		box.maxLon = [2]float64{180, maxLon}
+	case maxLon > 180:
+		box.minLon = [2]float64{minLon, -180}
+		box.maxLon = [2]float64{180, maxLon - 360}
+	default:
+		box.minLon[0], box.maxLon[0] = minLon, maxLon
+	}
+	return box
+}
+
+func newPage(ports []*domain.Port, limit int) domain.Page {
+	if len(ports) <= limit {
+		return domain.Page{Ports: ports}
+	}
+	ports = ports[:limit]
+	return domain.Page{Ports: ports, NextCursor: ports[limit-1].ID}
+}
+
+func (s Storage) Delete(ctx context.Context, id string, expectedRevision int64) error {
+	err := s.inTx(ctx, func(tx *sqlx.Tx) error {
+		if expectedRevision == 0 {
+			res, err := tx.ExecContext(ctx, `DELETE FROM ports WHERE id=$1;`, id)
+			if err != nil {
+				return err
+			}
+			return expectAffected(res, domain.ErrNotFound)
+		}
+		res, err := tx.ExecContext(ctx, `DELETE FROM ports WHERE id=$1 AND revision=$2;`, id, expectedRevision)
+		if err != nil {
+			return err
+		}
+		return expectAffected(res, domain.ErrRevisionMismatch)
+	})
+	if err != nil {
+		return fmt.Errorf("[%v] delete: %w", errorTag, err)
+	}
+	return nil
+}
+
+// SaveBatch upserts ports with multi-row inserts. If chunk insert fails ports of that chunk
+// are saved one by one, so failing items can be reported with *domain.BatchError.
+func (s Storage) SaveBatch(ctx context.Context, ports []*domain.Port) (domain.SaveResult, error) {
+	res := domain.SaveResult{}
+	batchErr := &domain.BatchError{}
+	for start := 0; start < len(ports); start += batchChunkSize {
+		end := start + batchChunkSize
+		if end > len(ports) {
+			end = len(ports)
+		}
+		err := s.saveChunk(ctx, ports[start:end], start, &res, batchErr)
+		if err != nil {
+			return res, fmt.Errorf("[%v] save batch: %w", errorTag, err)
+		}
+	}
+	if batchErr.ErrOrNil() != nil {
+		return res, fmt.Errorf("[%v] save batch: %w", errorTag, batchErr)
+	}
+	return res, nil
+}
+
+func (s Storage) saveChunk(
+	ctx context.Context, ports []*domain.Port, offset int, res *domain.SaveResult, batchErr *domain.BatchError,
+) error {
+	// same id can't be affected twice by one upsert, last occurrence wins. Earlier ones are
+	// counted as updated once it is saved, as if ports were saved one by one, and fail with it otherwise.
+	last := make(map[string]int, len(ports))
+	superseded := make(map[string][]int)
+	for i, p := range ports {
+		if p == nil {
+			batchErr.Add(offset+i, "", errNilPort)
+			continue
+		}
+		if prev, ok := last[p.ID]; ok {
+			superseded[p.ID] = append(superseded[p.ID], offset+prev)
+		}
+		last[p.ID] = i
+	}
+	unique := make([]*domain.Port, 0, len(last))
+	indexes := make([]int, 0, len(last))
+	for i, p := range ports {
+		if p != nil && last[p.ID] == i {
+			unique = append(unique, p)
+			indexes = append(indexes, offset+i)
+		}
+	}
+	if len(unique) == 0 {
+		return nil
+	}
+
+	err := s.upsert(ctx, unique, res)
+	if err == nil {
+		for _, dups := range superseded {
+			res.Updated += len(dups)
+		}
+		return nil
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	for i, p := range unique {
+		saveErr := s.upsert(ctx, unique[i:i+1], res)
+		if saveErr == nil {
+			res.Updated += len(superseded[p.ID])
+			continue
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		for _, dup := range superseded[p.ID] {
+			batchErr.Add(dup, p.ID, saveErr)
+		}
+		batchErr.Add(indexes[i], p.ID, saveErr)
+	}
+	return nil
+}
+
+// upsert saves ports with single statement, xmax of inserted rows is 0
+// which allows to tell created ports from updated ones.
+func (s Storage) upsert(ctx context.Context, ports []*domain.Port, res *domain.SaveResult) error {
+	args := make([]interface{}, 0, len(ports)*portColumns)
+	var b strings.Builder
+	b.WriteString(`
+	INSERT INTO ports (id, name, city, country, alias, regions, coordinates, province, timezone, unlocs, code)
+		VALUES `)
+	for i, p := range ports {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString("(")
+		for c := 1; c <= portColumns; c++ {
+			if c > 1 {
+				b.WriteString(", ")
+			}
+			b.WriteString("$" + strconv.Itoa(i*portColumns+c))
+		}
+		b.WriteString(")")
+		args = append(args,
+			p.ID, p.Name, p.City, p.Country, p.Alias, p.Regions, p.Coordinates, p.Province, p.Timezone, p.Unlocs, p.Code,
+		)
+	}
+	b.WriteString(`
+	ON CONFLICT (id)
+		DO UPDATE SET
+			name=EXCLUDED.name, city=EXCLUDED.city, country=EXCLUDED.country, alias=EXCLUDED.alias,
+			regions=EXCLUDED.regions, coordinates=EXCLUDED.coordinates, province=EXCLUDED.province,
+			timezone=EXCLUDED.timezone, unlocs=EXCLUDED.unlocs, code=EXCLUDED.code, revision=ports.revision + 1
+	RETURNING (xmax = 0) AS inserted;
+		`)
+
+	var inserted []bool
+	err := s.inTx(ctx, func(tx *sqlx.Tx) error {
+		return tx.SelectContext(ctx, &inserted, b.String(), args...)
+	})
+	if err != nil {
+		return err
+	}
+	for _, ins := range inserted {
+		if ins {
+			res.Inserted++
+		} else {
+			res.Updated++
+		}
+	}
+	return nil
+}
@@ -1,12 +1,14 @@
 package postgres_test
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/ory/dockertest"
 	"github.com/sp4rd4/ports/pkg/domain"
@@ -74,7 +76,7 @@ func (s *PostgresTestSuite) SetupSuite() {
 	if err != nil {
 		s.T().Fatalf("Unable to connect to postgres: %s", err)
 	}
-	storage := postgres.New(db)
+	storage := postgres.New(db, postgres.Listen(postgresHost))
 	err = storage.Migrate(dbMigrate, "migrations")
 	if !s.Nil(err, "Should migrate db with no error") {
 		s.T().Fatal("Migration failed")
@@ -84,7 +86,11 @@ func (s *PostgresTestSuite) SetupSuite() {
 }
 
 func (s *PostgresTestSuite) TearDownSuite() {
-	err := s.db.Close()
+	err := s.storage.Close()
+	if err != nil {
+		s.T().Fatal("Listener close failed")
+	}
+	err = s.db.Close()
 	if err != nil {
 		s.T().Fatal("DB close failed")
 	}
@@ -112,12 +118,13 @@ func (s *PostgresTestSuite) TestSave() {
 		Province: "",
 		Timezone: "Asia/Dubai",
 	}
-	err := s.storage.Save(port)
+	_, err := s.storage.Save(context.TODO(), port, 0)
 	s.Nil(err, "Should save port with no error")
 
-	lPort, err := s.storage.Get(port.ID)
+	lPort, err := s.storage.Get(context.TODO(), port.ID)
 	s.Nil(err, "Should load port with no error")
-	s.Equal(port, lPort, "Should load port equal to saved")
+	port.Revision = 1
+	s.Equal(port, lPort, "Should load port equal to saved with first revision")
 }
 
 func (s *PostgresTestSuite) TestConflictingSaves() {
@@ -135,17 +142,18 @@ func (s *PostgresTestSuite) TestConflictingSaves() {
 		Province: "",
 		Timezone: "Asia/Dubai",
 	}
-	err := s.storage.Save(port)
+	_, err := s.storage.Save(context.TODO(), port, 0)
 	s.Nil(err, "Should save port with no error")
 
 	port.Name = "New Port"
 	port.Country = "France"
-	err = s.storage.Save(port)
+	_, err = s.storage.Save(context.TODO(), port, 0)
 	s.Nil(err, "Should save port with no error")
 
-	lPort, err := s.storage.Get(port.ID)
+	lPort, err := s.storage.Get(context.TODO(), port.ID)
 	s.Nil(err, "Should load port with no error")
-	s.Equal(port, lPort, "Should load port equal to modified")
+	port.Revision = 2
+	s.Equal(port, lPort, "Should load port equal to modified with next revision")
 }
 
 func (s *PostgresTestSuite) TestMultipleSaves() {
@@ -176,27 +184,500 @@ func (s *PostgresTestSuite) TestMultipleSaves() {
 		Timezone: "Asia/Beijing",
 	}
 
-	err := s.storage.Save(port1)
+	_, err := s.storage.Save(context.TODO(), port1, 0)
 	s.Nil(err, "Should save port with no error")
 
-	err = s.storage.Save(port2)
+	_, err = s.storage.Save(context.TODO(), port2, 0)
 	s.Nil(err, "Should save port with no error")
+	port1.Revision, port2.Revision = 1, 1
 
-	lPort, err := s.storage.Get(port1.ID)
+	lPort, err := s.storage.Get(context.TODO(), port1.ID)
 	s.Nil(err, "Should load port with no error")
 	s.Equal(port1, lPort, "Should load port equal to first")
 
-	lPort, err = s.storage.Get(port2.ID)
+	lPort, err = s.storage.Get(context.TODO(), port2.ID)
 	s.Nil(err, "Should load port with no error")
 	s.Equal(port2, lPort, "Should load port equal to first")
 }
 
+func (s *PostgresTestSuite) TestSaveRevision() {
+	_, err := s.storage.Save(context.TODO(), &domain.Port{ID: "PORTID", Name: "Port"}, 1)
+	s.True(errors.Is(err, domain.ErrRevisionMismatch), "Should not create port with expected revision")
+	stored, err := s.storage.Save(context.TODO(), &domain.Port{ID: "PORTID", Name: "Port"}, 0)
+	s.Nil(err, "Should save port with no error")
+	s.Equal(int64(1), stored.Revision, "Should return created port with first revision")
+	stored, err = s.storage.Save(context.TODO(), &domain.Port{ID: "PORTID", Name: "New Port", Revision: 5}, 1)
+	s.Nil(err, "Should save port with expected revision")
+	s.Equal("New Port", stored.Name, "Should return stored port")
+	s.Equal(int64(2), stored.Revision, "Should return stored port with increased revision")
+	_, err = s.storage.Save(context.TODO(), &domain.Port{ID: "PORTID", Name: "Stale"}, 1)
+	s.True(errors.Is(err, domain.ErrRevisionMismatch), "Should not save port with stale revision")
+
+	port, err := s.storage.Get(context.TODO(), "PORTID")
+	s.Nil(err, "Should load port with no error")
+	s.Equal("New Port", port.Name, "Should save port with expected revision")
+	s.Equal(int64(2), port.Revision, "Should increase revision on update")
+
+	err = s.storage.Delete(context.TODO(), "PORTID", 1)
+	s.True(errors.Is(err, domain.ErrRevisionMismatch), "Should not delete port with stale revision")
+	err = s.storage.Delete(context.TODO(), "PORTID", 2)
+	s.Nil(err, "Should delete port with expected revision")
+}
+
+func (s *PostgresTestSuite) TestUpdate() {
+	_, err := s.storage.Update(context.TODO(), &domain.Port{ID: "PORTID"}, []string{"name"}, 0)
+	s.True(errors.Is(err, domain.ErrNotFound), "Should not update missing port")
+
+	_, err = s.storage.Save(context.TODO(), &domain.Port{
+		ID: "PORTID", Name: "Port", City: "Boston", Timezone: "Asia/Dubai", Alias: domain.StringArray{"PORT"},
+	}, 0)
+	s.Nil(err, "Should save port with no error")
+
+	update := &domain.Port{
+		ID: "PORTID", Timezone: "Asia/Muscat", Alias: domain.StringArray{"PORTIDD"},
+		Coordinates: domain.Location{Latitude: 31.03351, Longitude: -17.8251657},
+	}
+	port, err := s.storage.Update(context.TODO(), update, []string{"alias", "timezone", "coordinates"}, 0)
+	s.Nil(err, "Should update port with no error")
+	s.Equal("Port", port.Name, "Should not write fields missing in mask")
+	s.Equal("Boston", port.City, "Should not write fields missing in mask")
+	s.Equal("Asia/Muscat", port.Timezone, "Should write named fields")
+	s.Equal(domain.StringArray{"PORTIDD"}, port.Alias, "Should write named fields")
+	s.Equal(update.Coordinates, port.Coordinates, "Should write named fields")
+	s.Equal(int64(2), port.Revision, "Should increase revision on update")
+
+	lPort, err := s.storage.Get(context.TODO(), "PORTID")
+	s.Nil(err, "Should load port with no error")
+	s.Equal(port, lPort, "Should return stored port")
+
+	_, err = s.storage.Update(context.TODO(), update, []string{"name; DROP TABLE ports"}, 0)
+	s.True(errors.Is(err, domain.ErrUnknownField), "Should not update unknown columns")
+
+	_, err = s.storage.Update(context.TODO(), update, []string{"timezone"}, 1)
+	s.True(errors.Is(err, domain.ErrRevisionMismatch), "Should not update port with stale revision")
+	_, err = s.storage.Update(context.TODO(), &domain.Port{ID: "ZAPLZ"}, []string{"name"}, 1)
+	s.True(errors.Is(err, domain.ErrNotFound), "Should not update missing port with expected revision")
+	port, err = s.storage.Update(context.TODO(), update, []string{"timezone"}, 2)
+	s.Nil(err, "Should update port with expected revision")
+	s.Equal(int64(3), port.Revision, "Should increase revision on update")
+}
+
+func (s *PostgresTestSuite) TestLookup() {
+	_, err := s.storage.SaveBatch(context.TODO(), []*domain.Port{
+		{ID: "AEAJM", Unlocs: domain.StringArray{"AEAJM", "AEQIW"}, Alias: domain.StringArray{"Ajman Port"}},
+		{ID: "AEQIW", Unlocs: domain.StringArray{"AEQIW"}, Alias: domain.StringArray{"Umm al Qaiwain"}},
+		{ID: "AESHJ", Unlocs: domain.StringArray{"AESHJ"}, Alias: domain.StringArray{"Ajman Port", "Sharjah"}},
+	})
+	s.Nil(err, "Should save ports with no error")
+
+	ports, err := s.storage.ByUnlocode(context.TODO(), "AEQIW")
+	s.Nil(err, "Should find ports with no error")
+	s.Equal([]string{"AEQIW", "AEAJM"}, lookupIDs(ports), "Should find ports by id and unlocs, id match first")
+
+	ports, err = s.storage.ByAlias(context.TODO(), "Ajman Port")
+	s.Nil(err, "Should find ports with no error")
+	s.Equal([]string{"AEAJM", "AESHJ"}, lookupIDs(ports), "Should find all ports having alias")
+
+	ports, err = s.storage.ByAlias(context.TODO(), "ajman port")
+	s.Nil(err, "Should find ports with no error")
+	s.Empty(ports, "Should find nothing by unknown alias")
+
+	listing := make([]*domain.Port, 0, domain.MaxLookupCandidates+1)
+	for i := 0; i < domain.MaxLookupCandidates; i++ {
+		listing = append(listing, &domain.Port{ID: fmt.Sprintf("AA%03d", i), Unlocs: domain.StringArray{"ZZZZZ"}})
+	}
+	listing = append(listing, &domain.Port{ID: "ZZZZZ"})
+	_, err = s.storage.SaveBatch(context.TODO(), listing)
+	s.Nil(err, "Should save ports with no error")
+
+	ports, err = s.storage.ByUnlocode(context.TODO(), "ZZZZZ")
+	s.Nil(err, "Should find ports with no error")
+	if s.Len(ports, domain.MaxLookupCandidates, "Should limit candidates") {
+		s.Equal("ZZZZZ", ports[0].ID, "Should not cut off port having code as id")
+	}
+}
+
+func lookupIDs(ports []*domain.Port) []string {
+	ids := make([]string, len(ports))
+	for i := range ports {
+		ids[i] = ports[i].ID
+	}
+	return ids
+}
+
+func (s *PostgresTestSuite) TestSearchByName() {
+	_, err := s.storage.SaveBatch(context.TODO(), []*domain.Port{
+		{ID: "BEANR", Name: "Antwerp", City: "Antwerp"},
+		{ID: "NLRTM", Name: "Rotterdam", City: "Rotterdam"},
+		{ID: "NLVLA", Name: "Vlaardingen", City: "Vlaardingen", Alias: domain.StringArray{"Rotterdam Vlaardingen"}},
+	})
+	s.Nil(err, "Should save ports with no error")
+
+	ports, err := s.storage.SearchByName(context.TODO(), "rotter", 10)
+	s.Nil(err, "Should search ports with no error")
+	s.Equal([]string{"NLRTM", "NLVLA"}, matchedIDs(ports), "Should rank name prefix over alias prefix")
+	s.True(ports[0].Score > ports[1].Score, "Should return higher score for better match")
+
+	ports, err = s.storage.SearchByName(context.TODO(), "Roterdam", 1)
+	s.Nil(err, "Should search ports with no error")
+	s.Equal([]string{"NLRTM"}, matchedIDs(ports), "Should find misspelled name and respect limit")
+
+	for _, prefix := range []string{"ro", "R"} {
+		ports, err = s.storage.SearchByName(context.TODO(), prefix, 10)
+		s.Nil(err, "Should search ports with no error")
+		s.Equal([]string{"NLRTM", "NLVLA"}, matchedIDs(ports), "Should find short prefix below similarity threshold")
+	}
+
+	ports, err = s.storage.SearchByName(context.TODO(), "Antwerpen", 10)
+	s.Nil(err, "Should search ports with no error")
+	s.Equal([]string{"BEANR"}, matchedIDs(ports), "Should find similar name without prefix")
+
+	ports, err = s.storage.SearchByName(context.TODO(), "hamburg", 10)
+	s.Nil(err, "Should search ports with no error")
+	s.Empty(ports, "Should not return dissimilar ports")
+}
+
+func matchedIDs(ports []domain.MatchedPort) []string {
+	ids := make([]string, len(ports))
+	for i := range ports {
+		ids[i] = ports[i].ID
+	}
+	return ids
+}
+
 func (s *PostgresTestSuite) TestGetMIssing() {
-	lPort, err := s.storage.Get("id")
+	lPort, err := s.storage.Get(context.TODO(), "id")
 	s.Nil(lPort, "Should return nil port")
 	s.True(errors.Is(err, domain.ErrNotFound), "Should return not found error")
 }
 
+func (s *PostgresTestSuite) TestList() {
+	ports := []*domain.Port{{ID: "A", Revision: 1}, {ID: "B", Revision: 1}, {ID: "C", Revision: 1}}
+	_, err := s.storage.SaveBatch(context.TODO(), ports)
+	s.Nil(err, "Should save ports with no error")
+
+	page, err := s.storage.List(context.TODO(), domain.PortFilter{}, "", 2)
+	s.Nil(err, "Should list ports with no error")
+	s.Equal(domain.Page{Ports: ports[:2], NextCursor: "B"}, page, "Should return first page")
+
+	page, err = s.storage.List(context.TODO(), domain.PortFilter{}, page.NextCursor, 2)
+	s.Nil(err, "Should list ports with no error")
+	s.Equal(domain.Page{Ports: ports[2:]}, page, "Should return last page")
+
+	_, err = s.storage.List(context.TODO(), domain.PortFilter{}, "", 0)
+	s.NotNil(err, "Should reject non-positive limit")
+}
+
+func (s *PostgresTestSuite) TestListFilter() {
+	ports := []*domain.Port{
+		{ID: "A", Country: "Belgium", City: "Antwerp", Regions: domain.StringArray{"Europe", "Benelux"}, Revision: 1},
+		{
+			ID: "B", Country: "Belgium", City: "Ghent", Regions: domain.StringArray{"Europe"}, Timezone: "Europe/Brussels",
+			Revision: 1,
+		},
+		{ID: "C", Country: "France", City: "Calais", Regions: domain.StringArray{"Europe"}, Revision: 1},
+	}
+	_, err := s.storage.SaveBatch(context.TODO(), ports)
+	s.Nil(err, "Should save ports with no error")
+
+	page, err := s.storage.List(context.TODO(), domain.PortFilter{Country: "Belgium"}, "", 10)
+	s.Nil(err, "Should list ports with no error")
+	s.Equal(ports[:2], page.Ports, "Should filter by country")
+
+	page, err = s.storage.List(context.TODO(), domain.PortFilter{Region: "Benelux"}, "", 10)
+	s.Nil(err, "Should list ports with no error")
+	s.Equal(ports[:1], page.Ports, "Should filter by region")
+
+	page, err = s.storage.List(context.TODO(), domain.PortFilter{Region: "Europe", Timezone: "Europe/Brussels"}, "", 10)
+	s.Nil(err, "Should list ports with no error")
+	s.Equal(ports[1:2], page.Ports, "Should combine filters")
+}
+
+func (s *PostgresTestSuite) TestNearby() {
+	ports := []*domain.Port{
+		{ID: "BEANR", Coordinates: domain.Location{Latitude: 51.2194475, Longitude: 4.4024643}},
+		{ID: "FJTVU", Coordinates: domain.Location{Latitude: -16.8, Longitude: -179.97}},
+		{ID: "FRCQF", Coordinates: domain.Location{Latitude: 50.95129, Longitude: 1.858686}},
+		{ID: "NLRTM", Coordinates: domain.Location{Latitude: 51.9244201, Longitude: 4.4777325}},
+	}
+	_, err := s.storage.SaveBatch(context.TODO(), ports)
+	s.Nil(err, "Should save ports with no error")
+
+	nearby, err := s.storage.Nearby(context.TODO(), domain.Location{Latitude: 51.2, Longitude: 4.4}, 100, 10)
+	s.Nil(err, "Should query nearby ports with no error")
+	s.Equal([]string{"BEANR", "NLRTM"}, nearbyIDs(nearby), "Should return ports within radius ordered by distance")
+	s.InDelta(2.2, nearby[0].DistanceKm, 0.1, "Should return distance to port")
+
+	nearby, err = s.storage.Nearby(context.TODO(), domain.Location{Latitude: 51.2, Longitude: 4.4}, 500, 1)
+	s.Nil(err, "Should query nearby ports with no error")
+	s.Equal([]string{"BEANR"}, nearbyIDs(nearby), "Should respect limit")
+
+	nearby, err = s.storage.Nearby(context.TODO(), domain.Location{Latitude: -16.8, Longitude: 179.9}, 50, 10)
+	s.Nil(err, "Should query nearby ports with no error")
+	s.Equal([]string{"FJTVU"}, nearbyIDs(nearby), "Should find ports across antimeridian")
+}
+
+func (s *PostgresTestSuite) TestWithinBox() {
+	ports := []*domain.Port{
+		{ID: "BEANR", Coordinates: domain.Location{Latitude: 51.2194475, Longitude: 4.4024643}},
+		{ID: "FJSUV", Coordinates: domain.Location{Latitude: -18.1416, Longitude: 178.4419}},
+		{ID: "FJTVU", Coordinates: domain.Location{Latitude: -16.8, Longitude: -179.97}},
+		{ID: "NLRTM", Coordinates: domain.Location{Latitude: 51.9244201, Longitude: 4.4777325}},
+	}
+	_, err := s.storage.SaveBatch(context.TODO(), ports)
+	s.Nil(err, "Should save ports with no error")
+
+	var ids []string
+	collect := func(p *domain.Port) error {
+		ids = append(ids, p.ID)
+		return nil
+	}
+
+	box := domain.BoundingBox{MinLon: 2, MinLat: 50, MaxLon: 5, MaxLat: 52}
+	err = s.storage.WithinBox(context.TODO(), box, collect)
+	s.Nil(err, "Should query ports in box with no error")
+	s.Equal([]string{"BEANR", "NLRTM"}, ids, "Should return ports inside box")
+
+	ids = nil
+	box = domain.BoundingBox{MinLon: 170, MinLat: -20, MaxLon: -170, MaxLat: -10}
+	err = s.storage.WithinBox(context.TODO(), box, collect)
+	s.Nil(err, "Should query ports in box with no error")
+	s.Equal([]string{"FJSUV", "FJTVU"}, ids, "Should return ports in box crossing antimeridian")
+
+	err = s.storage.WithinBox(context.TODO(), domain.BoundingBox{MinLon: -180, MinLat: -90, MaxLon: 180, MaxLat: 90},
+		func(*domain.Port) error { return errors.New("stop") })
+	s.NotNil(err, "Should stop on callback error")
+}
+
+func nearbyIDs(ports []domain.NearbyPort) []string {
+	ids := make([]string, len(ports))
+	for i := range ports {
+		ids[i] = ports[i].ID
+	}
+	return ids
+}
+
+func (s *PostgresTestSuite) TestDelete() {
+	port := &domain.Port{ID: "PORTID", Name: "Port", City: "Boston"}
+	_, err := s.storage.Save(context.TODO(), port, 0)
+	s.Nil(err, "Should save port with no error")
+
+	err = s.storage.Delete(context.TODO(), port.ID, 0)
+	s.Nil(err, "Should delete port with no error")
+
+	_, err = s.storage.Get(context.TODO(), port.ID)
+	s.True(errors.Is(err, domain.ErrNotFound), "Should not find deleted port")
+
+	err = s.storage.Delete(context.TODO(), port.ID, 0)
+	s.True(errors.Is(err, domain.ErrNotFound), "Should return not found for missing port")
+}
+
+func (s *PostgresTestSuite) TestGetCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	lPort, err := s.storage.Get(ctx, "id")
+	s.Nil(lPort, "Should return nil port")
+	s.True(errors.Is(err, context.Canceled), "Should return canceled error")
+}
+
+func (s *PostgresTestSuite) TestSaveBatch() {
+	ports := []*domain.Port{
+		{ID: "PORTID", Name: "Port", City: "Boston", Alias: domain.StringArray{"PORTIDD"}},
+		{ID: "PORTID2", Name: "Porting", City: "Gyor", Regions: domain.StringArray{"Nova Scotia"}, Revision: 1},
+		nil,
+		{ID: "PORTID", Name: "New Port", City: "Boston", Revision: 1},
+	}
+	res, err := s.storage.SaveBatch(context.TODO(), ports)
+	s.Equal(domain.SaveResult{Inserted: 2, Updated: 1}, res, "Should count duplicate as updated")
+	var batchErr *domain.BatchError
+	s.True(errors.As(err, &batchErr), "Should report nil port")
+	s.Equal(1, len(batchErr.Failures), "Should report only nil port")
+	s.Equal(2, batchErr.Failures[0].Index, "Should report nil port index")
+
+	lPort, err := s.storage.Get(context.TODO(), "PORTID")
+	s.Nil(err, "Should load port with no error")
+	s.Equal(ports[3], lPort, "Should load last saved version of port")
+
+	lPort, err = s.storage.Get(context.TODO(), "PORTID2")
+	s.Nil(err, "Should load port with no error")
+	s.Equal(ports[1], lPort, "Should load port equal to saved")
+
+	res, err = s.storage.SaveBatch(context.TODO(), []*domain.Port{ports[1], {ID: "PORTID3"}})
+	s.Nil(err, "Should save ports with no error")
+	s.Equal(domain.SaveResult{Inserted: 1, Updated: 1}, res, "Should count updated ports")
+}
+
+func (s *PostgresTestSuite) TestWatch() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	errStop := errors.New("stop")
+
+	_, err := s.storage.Save(ctx, &domain.Port{ID: "WATCH", Name: "Port"}, 0)
+	s.Nil(err, "Should save port with no error")
+	_, err = s.storage.Save(ctx, &domain.Port{ID: "WATCH", Name: "New Port"}, 0)
+	s.Nil(err, "Should save port with no error")
+	err = s.storage.Delete(ctx, "WATCH", 0)
+	s.Nil(err, "Should delete port with no error")
+
+	var events []domain.PortEvent
+	err = s.storage.Watch(ctx, 1, func(e domain.PortEvent) error {
+		if e.Port.ID != "WATCH" {
+			return nil
+		}
+		events = append(events, e)
+		if len(events) == 3 {
+			return errStop
+		}
+		return nil
+	})
+	s.True(errors.Is(err, errStop), "Should stop on callback error")
+	if !s.Equal(3, len(events), "Should replay changes") {
+		return
+	}
+	s.Equal([]domain.EventOp{domain.PortCreated, domain.PortUpdated, domain.PortDeleted},
+		[]domain.EventOp{events[0].Op, events[1].Op, events[2].Op}, "Should record operations")
+	s.Equal("New Port", events[2].Port.Name, "Should record last version of deleted port")
+	s.True(events[0].Sequence < events[1].Sequence && events[1].Sequence < events[2].Sequence,
+		"Should increase sequence")
+
+	received := make(chan domain.PortEvent)
+	go func() {
+		_ = s.storage.Watch(ctx, events[2].Sequence+1, func(e domain.PortEvent) error {
+			received <- e
+			return errStop
+		})
+	}()
+	_, err = s.storage.SaveBatch(ctx, []*domain.Port{{ID: "WATCH2", Name: "Port"}})
+	s.Nil(err, "Should save ports with no error")
+	select {
+	case e := <-received:
+		s.Equal(domain.PortCreated, e.Op, "Should stream new changes")
+		s.Equal("WATCH2", e.Port.ID, "Should stream changed port")
+	case <-ctx.Done():
+		s.Fail("Should receive change notification")
+	}
+}
+
+func (s *PostgresTestSuite) TestWatchGap() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	errStop := errors.New("stop")
+
+	tx, err := s.db.Begin()
+	s.Nil(err, "Should begin transaction with no error")
+	_, err = tx.Exec("INSERT INTO ports (id, name) VALUES ('GAP1', 'Port');")
+	s.Nil(err, "Should insert port with no error")
+	_, err = s.storage.Save(ctx, &domain.Port{ID: "GAP2", Name: "Port"}, 0)
+	s.Nil(err, "Should save port with no error")
+
+	var from int64
+	err = s.db.QueryRow("SELECT MIN(sequence) FROM port_events WHERE port->>'id' = 'GAP2';").Scan(&from)
+	s.Nil(err, "Should get sequence with no error")
+	s.Nil(tx.Rollback(), "Should roll back with no error")
+
+	var received domain.PortEvent
+	err = s.storage.Watch(ctx, from-1, func(e domain.PortEvent) error {
+		received = e
+		return errStop
+	})
+	s.True(errors.Is(err, errStop), "Should stop on callback error")
+	s.Equal("GAP2", received.Port.ID, "Should skip rolled back change")
+}
+
+func (s *PostgresTestSuite) TestPruneEvents() {
+	_, err := s.storage.Save(context.TODO(), &domain.Port{ID: "PRUNE", Name: "Port"}, 0)
+	s.Nil(err, "Should save port with no error")
+	_, err = s.storage.Save(context.TODO(), &domain.Port{ID: "PRUNE", Name: "New Port"}, 0)
+	s.Nil(err, "Should save port with no error")
+
+	pruned, err := s.storage.PruneEvents(context.TODO(), time.Now().Add(-time.Hour))
+	s.Nil(err, "Should prune events with no error")
+	s.Equal(int64(0), pruned, "Should keep recent events")
+
+	pruned, err = s.storage.PruneEvents(context.TODO(), time.Now().Add(time.Hour))
+	s.Nil(err, "Should prune events with no error")
+	s.True(pruned > 0, "Should prune old events")
+
+	history, err := s.storage.History(context.TODO(), "PRUNE")
+	s.Nil(err, "Should get history with no error")
+	s.Equal(2, len(history), "Should keep history")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var last domain.PortEvent
+	err = s.storage.Watch(ctx, 1, func(e domain.PortEvent) error {
+		last = e
+		return nil
+	})
+	s.True(errors.Is(err, domain.ErrEventsPruned), "Should fail to resume from pruned events")
+	s.Nil(last.Port, "Should not stream events after pruned ones")
+}
+
+func (s *PostgresTestSuite) TestHistory() {
+	ctx := domain.WithSource(context.Background(), "file:ports.json")
+	_, err := s.storage.Save(ctx, &domain.Port{ID: "HISTORY", Name: "Port"}, 0)
+	s.Nil(err, "Should save port with no error")
+	_, err = s.storage.SaveBatch(domain.WithSource(ctx, "http:req-1"), []*domain.Port{{ID: "HISTORY", Name: "New Port"}})
+	s.Nil(err, "Should save ports with no error")
+	err = s.storage.Delete(domain.WithSource(ctx, "grpc:10.0.0.1:5000"), "HISTORY", 0)
+	s.Nil(err, "Should delete port with no error")
+
+	history, err := s.storage.History(context.TODO(), "HISTORY")
+	s.Nil(err, "Should get history with no error")
+	if !s.Equal(3, len(history), "Should record every change") {
+		return
+	}
+	s.Equal([]domain.EventOp{domain.PortCreated, domain.PortUpdated, domain.PortDeleted},
+		[]domain.EventOp{history[0].Op, history[1].Op, history[2].Op}, "Should record operations")
+	s.Equal([]string{"file:ports.json", "http:req-1", "grpc:10.0.0.1:5000"},
+		[]string{history[0].Source, history[1].Source, history[2].Source}, "Should record sources")
+	s.Nil(history[0].Previous, "Should not have previous port for created one")
+	s.Equal("Port", history[1].Previous.Name, "Should record previous port on update")
+	s.Equal("New Port", history[2].Previous.Name, "Should record deleted port")
+	s.True(history[0].Sequence < history[1].Sequence && history[1].Sequence < history[2].Sequence,
+		"Should increase sequence")
+	s.False(history[0].ChangedAt.IsZero(), "Should record time of change")
+
+	_, err = s.storage.History(context.TODO(), "NOHISTORY")
+	s.True(errors.Is(err, domain.ErrNotFound), "Should not find history of unknown port")
+}
+
+func (s *PostgresTestSuite) TestGetAsOf() {
+	// changed_at is time of transaction start taken by db, sleeps keep moments apart from it
+	moment := func() time.Time {
+		time.Sleep(10 * time.Millisecond)
+		defer time.Sleep(10 * time.Millisecond)
+		return time.Now()
+	}
+	beforeCreate := moment()
+	_, err := s.storage.Save(context.TODO(), &domain.Port{ID: "ASOF", Name: "Port"}, 0)
+	s.Nil(err, "Should save port with no error")
+	afterCreate := moment()
+	_, err = s.storage.Save(context.TODO(), &domain.Port{ID: "ASOF", Name: "New Port"}, 0)
+	s.Nil(err, "Should save port with no error")
+	afterUpdate := moment()
+	err = s.storage.Delete(context.TODO(), "ASOF", 0)
+	s.Nil(err, "Should delete port with no error")
+	afterDelete := moment()
+
+	_, err = s.storage.GetAsOf(context.TODO(), "ASOF", beforeCreate)
+	s.True(errors.Is(err, domain.ErrNotFound), "Should not find port before creation")
+	port, err := s.storage.GetAsOf(context.TODO(), "ASOF", afterCreate)
+	s.Nil(err, "Should get port with no error")
+	s.Equal("Port", port.Name, "Should return port as it was created")
+	port, err = s.storage.GetAsOf(context.TODO(), "ASOF", afterUpdate)
+	s.Nil(err, "Should get port with no error")
+	s.Equal("New Port", port.Name, "Should return updated port")
+	_, err = s.storage.GetAsOf(context.TODO(), "ASOF", afterDelete)
+	s.True(errors.Is(err, domain.ErrNotFound), "Should not find deleted port")
+
+	_, err = s.storage.Save(context.TODO(), &domain.Port{ID: "ASOF2", Name: "Port"}, 0)
+	s.Nil(err, "Should save port with no error")
+	port, err = s.storage.GetAsOf(context.TODO(), "ASOF2", time.Now().Add(time.Hour))
+	s.Nil(err, "Should get port with no error")
+	s.Equal("Port", port.Name, "Should return current port when it was not changed since")
+}
+
 func TestPostgresTestSuite(t *testing.T) {
 	suite.Run(t, new(PostgresTestSuite))
 }
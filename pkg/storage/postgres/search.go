@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/sp4rd4/ports/pkg/domain"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SearchByName ranks ports by pg_trgm word similarity of query to name, city and alias, which is what
+// ports_search_trgm_idx is built on. Ports having name, city or alias starting with query go first and are
+// returned even if query is too short to be similar enough, e.g. "ro" for Rotterdam.
+func (s Storage) SearchByName(ctx context.Context, query string, limit int) ([]domain.MatchedPort, error) {
+	prefix := likeEscaper.Replace(strings.ToLower(query)) + "%"
+	ports := []domain.MatchedPort{}
+	err := s.db.SelectContext(ctx, &ports, `
+	SELECT id, name, city, country, alias, regions, coordinates, province, timezone, unlocs, code, revision,
+		word_similarity($1, ports_search_text(name, city, alias)) + CASE prefix_rank
+			WHEN 0 THEN 0.5
+			WHEN 1 THEN 0.25
+			ELSE 0
+		END AS score
+	FROM (
+		SELECT *, CASE
+			WHEN lower(name) LIKE $2 THEN 0
+			WHEN lower(city) LIKE $2 OR EXISTS (SELECT 1 FROM unnest(alias) a WHERE lower(a) LIKE $2) THEN 1
+			ELSE 2
+		END AS prefix_rank
+		FROM ports
+		WHERE $1 <% ports_search_text(name, city, alias)
+			OR lower(name) LIKE $2 OR lower(city) LIKE $2
+			OR EXISTS (SELECT 1 FROM unnest(alias) a WHERE lower(a) LIKE $2)
+	) AS matched
+	ORDER BY prefix_rank, score DESC, id
+	LIMIT $3;
+	`, query, prefix, limit)
+	if err != nil {
+		return nil, fmt.Errorf("[%v] search by name: %w", errorTag, err)
+	}
+	return ports, nil
+}
@@ -0,0 +1,264 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/sp4rd4/ports/pkg/domain"
+)
+
+const (
+	eventsChannel = "port_events"
+	// eventsBatchSize limits events read from db at once.
+	eventsBatchSize = 1000
+
+	// pollInterval bounds delay of changes when there is no listener or its notifications were lost.
+	pollInterval         = time.Second
+	listenerPollInterval = 30 * time.Second
+
+	listenerMinReconnect = 10 * time.Second
+	listenerMaxReconnect = time.Minute
+)
+
+type Option func(*Storage)
+
+// Listen wakes watchers up with notifications received over dedicated connection to dsn
+// instead of polling db every second.
+func Listen(dsn string) Option {
+	return func(s *Storage) {
+		s.notifier = newNotifier(pq.NewListener(dsn, listenerMinReconnect, listenerMaxReconnect, nil))
+		s.pollInterval = listenerPollInterval
+	}
+}
+
+// notifier fans notifications of the single listener out to watchers.
+type notifier struct {
+	listener *pq.Listener
+
+	mu          sync.Mutex
+	subscribers map[chan struct{}]struct{}
+}
+
+func newNotifier(listener *pq.Listener) *notifier {
+	n := &notifier{listener: listener, subscribers: make(map[chan struct{}]struct{})}
+	go func() {
+		// Listen blocks until connection is established, watchers keep polling meanwhile
+		// and on failure.
+		if err := listener.Listen(eventsChannel); err != nil {
+			return
+		}
+		// nil notification is sent after reconnect, when notifications could be missed
+		for range listener.NotificationChannel() {
+			n.broadcast()
+		}
+	}()
+	return n
+}
+
+// subscribe returns channel receiving a signal after notification, signals are merged
+// if subscriber is busy. Nil notifier returns nil channel which never receives.
+func (n *notifier) subscribe() (<-chan struct{}, func()) {
+	if n == nil {
+		return nil, func() {}
+	}
+	ch := make(chan struct{}, 1)
+	n.mu.Lock()
+	n.subscribers[ch] = struct{}{}
+	n.mu.Unlock()
+	return ch, func() {
+		n.mu.Lock()
+		delete(n.subscribers, ch)
+		n.mu.Unlock()
+	}
+}
+
+func (n *notifier) broadcast() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for ch := range n.subscribers {
+		select {
+		case ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Close stops listener connection if there is one.
+func (s Storage) Close() error {
+	if s.notifier == nil {
+		return nil
+	}
+	if err := s.notifier.listener.Close(); err != nil {
+		return fmt.Errorf("[%v] close: %w", errorTag, err)
+	}
+	return nil
+}
+
+type eventRow struct {
+	Sequence int64  `db:"sequence"`
+	Op       string `db:"op"`
+	Port     []byte `db:"port"`
+}
+
+// gap is a sequence missing between read events. Writers are not serialized, so it can belong
+// to transaction still in progress, rolled back one or pruned event.
+type gap struct {
+	sequence int64
+	// writers are transactions which had events table locked when gap was seen, only they could fill it.
+	writers map[string]bool
+}
+
+// pending tells whether some of gap writers are among running ones.
+func (g gap) pending(running map[string]bool) bool {
+	for w := range g.writers {
+		if running[w] {
+			return true
+		}
+	}
+	return false
+}
+
+// Watch reads events recorded by trigger on ports table, it is woken up by notifications
+// and polls db in case they were lost. Events are passed in order of sequence, on gap watch waits
+// for transactions writing events when it was seen before going on. It fails with domain.ErrEventsPruned
+// if events starting from fromSequence are not kept anymore.
+func (s Storage) Watch(ctx context.Context, fromSequence int64, fn func(domain.PortEvent) error) error {
+	wake, unsubscribe := s.notifier.subscribe()
+	defer unsubscribe()
+
+	if fromSequence == 0 {
+		err := s.db.GetContext(ctx, &fromSequence, `SELECT COALESCE(MAX(sequence), 0) + 1 FROM port_events;`)
+		if err != nil {
+			return fmt.Errorf("[%v] watch: %w", errorTag, err)
+		}
+	}
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	var missing gap
+	for {
+		// writers finished before events are read are visible to the read
+		var running map[string]bool
+		if missing.sequence == fromSequence && len(missing.writers) > 0 {
+			var err error
+			if running, err = s.eventWriters(ctx); err != nil {
+				return fmt.Errorf("[%v] watch: %w", errorTag, err)
+			}
+		}
+		events, err := s.events(ctx, fromSequence)
+		if err != nil {
+			return fmt.Errorf("[%v] watch: %w", errorTag, err)
+		}
+
+		blocked, reread := false, false
+		for _, e := range events {
+			if e.Sequence != fromSequence {
+				if missing.sequence != fromSequence {
+					// writers are read after events, so the ones which could fill the gap are among them,
+					// events are read again to see those finished meanwhile
+					if missing, err = s.newGap(ctx, fromSequence); err != nil {
+						return fmt.Errorf("[%v] watch: %w", errorTag, err)
+					}
+					blocked, reread = true, true
+					break
+				}
+				if missing.pending(running) {
+					blocked = true
+					break
+				}
+			}
+			if err = fn(e); err != nil {
+				return fmt.Errorf("[%v] watch: %w", errorTag, err)
+			}
+			fromSequence = e.Sequence + 1
+		}
+		if reread || !blocked && len(events) == eventsBatchSize {
+			continue
+		}
+
+		select {
+		case <-wake:
+		case <-ticker.C:
+		case <-ctx.Done():
+			return fmt.Errorf("[%v] watch: %w", errorTag, ctx.Err())
+		}
+	}
+}
+
+// newGap records gap at sequence, it fails with domain.ErrEventsPruned if sequence is older than kept events.
+func (s Storage) newGap(ctx context.Context, sequence int64) (gap, error) {
+	var oldest int64
+	if err := s.db.GetContext(ctx, &oldest, `SELECT COALESCE(MIN(sequence), 0) FROM port_events;`); err != nil {
+		return gap{}, err
+	}
+	if sequence < oldest {
+		return gap{}, domain.ErrEventsPruned
+	}
+	writers, err := s.eventWriters(ctx)
+	if err != nil {
+		return gap{}, err
+	}
+	return gap{sequence: sequence, writers: writers}, nil
+}
+
+// eventWriters returns virtual ids of other transactions having events table locked. Table is locked
+// before sequence is taken and until transaction ends, so unrelated transactions are not waited for.
+func (s Storage) eventWriters(ctx context.Context) (map[string]bool, error) {
+	ids := []string{}
+	err := s.db.SelectContext(ctx, &ids, `
+	SELECT virtualtransaction FROM pg_locks
+	WHERE locktype = 'relation' AND relation = 'port_events'::regclass AND pid IS DISTINCT FROM pg_backend_pid();
+	`)
+	if err != nil {
+		return nil, err
+	}
+	writers := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		writers[id] = true
+	}
+	return writers, nil
+}
+
+// PruneEvents deletes events recorded before the moment, watchers resuming from them fail with
+// domain.ErrEventsPruned. The last event is kept so it is known which sequences were pruned.
+// History of ports is kept.
+func (s Storage) PruneEvents(ctx context.Context, before time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `
+	DELETE FROM port_events WHERE created_at < $1 AND sequence < (SELECT MAX(sequence) FROM port_events);
+	`, before)
+	if err != nil {
+		return 0, fmt.Errorf("[%v] prune events: %w", errorTag, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("[%v] prune events: %w", errorTag, err)
+	}
+	return n, nil
+}
+
+func (s Storage) events(ctx context.Context, fromSequence int64) ([]domain.PortEvent, error) {
+	rows := []eventRow{}
+	err := s.db.SelectContext(ctx, &rows, `
+	SELECT sequence, op, port FROM port_events
+	WHERE sequence >= $1
+	ORDER BY sequence
+	LIMIT $2;
+	`, fromSequence, eventsBatchSize)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]domain.PortEvent, len(rows))
+	for i, row := range rows {
+		port := &domain.Port{}
+		if err = json.Unmarshal(row.Port, port); err != nil {
+			return nil, fmt.Errorf("event %d: %w", row.Sequence, err)
+		}
+		events[i] = domain.PortEvent{Sequence: row.Sequence, Op: domain.EventOp(row.Op), Port: port}
+	}
+	return events, nil
+}